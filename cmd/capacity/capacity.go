@@ -0,0 +1,44 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/capacity"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdCapacity shows the capacity report of the nodes & their ZFS pools or
+// LVM volume groups
+func NewCmdCapacity(rootCmd *cobra.Command) *cobra.Command {
+	var casType string
+	var thresholds capacity.Thresholds
+	cmd := &cobra.Command{
+		Use:   "capacity [NODE...]",
+		Short: "Shows the free, provisioned & overcommitted space of the nodes, pools and volume groups",
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(capacity.Get(args, casType, output, thresholds), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s", util.LVMCasType, util.ZFSCasType))
+	cmd.Flags().Float64VarP(&thresholds.Used, "used-threshold", "", capacity.DefaultThresholds.Used, "flag the nodes, pools and volume groups used above this percentage")
+	cmd.Flags().Float64VarP(&thresholds.Overcommit, "overcommit-threshold", "", capacity.DefaultThresholds.Overcommit, "flag the nodes, pools and volume groups provisioned above this percentage of their total space")
+	return cmd
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openebs/openebsctl/pkg/check"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdCheck runs the health checks of the installed engines, it exits
+// with a non-zero code if any of them warns or fails
+func NewCmdCheck(rootCmd *cobra.Command) *cobra.Command {
+	var casType string
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Checks the health of the installed engines, their CSI drivers, nodes and volumes",
+		Long: fmt.Sprintf(`Checks the health of the installed engines, their CSI drivers, nodes and volumes.
+Each check passes, warns or fails. The exit code is 0 if all the checks pass,
+%d if any check fails or the checks can't be run, and %d if any check warns & none fail.`, check.ExitCodeFail, check.ExitCodeWarn),
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			status, err := check.Check(casType, output)
+			util.CheckErr(err, util.Fatal)
+			os.Exit(check.ExitCode(status))
+		},
+	}
+	cmd.Flags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s, %s, %s", util.LVMCasType, util.ZFSCasType, util.LocalPvHostpathCasType, util.MayastorCasType))
+	return cmd
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/orphan"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// defaultAuditLog is the file the deleted resources are appended to
+const defaultAuditLog = "openebs-cleanup-audit.log"
+
+// NewCmdCleanup deletes the orphaned volumes of the engines
+func NewCmdCleanup(rootCmd *cobra.Command) *cobra.Command {
+	var casType, auditLog string
+	var dryRun, yes bool
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Deletes the orphaned ZFSVolumes, LVMVolumes and retained PVs which are Released",
+		Long: `Deletes the orphaned volumes found by the orphans command:
+- ZFSVolumes and LVMVolumes without a PV, along with their dataset or logical volume
+- PVs with the Retain reclaim policy in the Released phase
+It is a dry run by default, which only lists what would be deleted. With --dry-run=false
+it asks for a confirmation, unless --yes is set, and appends what it deleted to the audit log.
+The volumes still mounted by a pod or attached to a node are never deleted.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(orphan.Cleanup(casType, dryRun, yes, auditLog), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s, %s", util.LVMCasType, util.ZFSCasType, util.LocalPvHostpathCasType))
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", true, "only list the resources which would be deleted")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "delete without asking for a confirmation")
+	cmd.Flags().StringVarP(&auditLog, "audit-log", "", defaultAuditLog, "file the deleted resources are appended to")
+	return cmd
+}
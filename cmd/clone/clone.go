@@ -0,0 +1,46 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/clone"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdClone creates a PVC from a ZFS or LVM PVC or VolumeSnapshot
+func NewCmdClone(rootCmd *cobra.Command) *cobra.Command {
+	var namespace, name, class string
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "clone (pvc|volumesnapshot)/NAME",
+		Short: "Creates a PVC from a PVC or a VolumeSnapshot & waits till it is bound",
+		Example: `  kubectl openebs clone pvc/data --name data-clone
+  kubectl openebs clone volumesnapshot/data-snap --name data-restore -n apps`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(clone.Clone(args[0], namespace, name, class, timeout), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "namespace of the source & of the clone")
+	cmd.Flags().StringVarP(&name, "name", "", "", "name of the clone, defaults to <pvc>-clone-<timestamp>")
+	cmd.Flags().StringVarP(&class, "storage-class", "", "", "storageclass of the clone, defaults to the storageclass of the source pvc")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "", 5*time.Minute, "time to wait for the clone to be bound")
+	return cmd
+}
@@ -24,12 +24,16 @@ import (
 
 // NewCmdClusterInfo shows OpenEBSCTL cluster-info
 func NewCmdClusterInfo(rootCmd *cobra.Command) *cobra.Command {
+	var watch bool
 	cmd := &cobra.Command{
 		Use:   "cluster-info",
 		Short: "Show component version, status and running components for each installed engine",
 		Run: func(cmd *cobra.Command, args []string) {
-			util.CheckErr(clusterinfo.ShowClusterInfo(), util.Fatal)
+			output, _ := cmd.Flags().GetString("output")
+			watch, _ := cmd.Flags().GetBool("watch")
+			util.CheckErr(clusterinfo.ShowClusterInfo(output, watch), util.Fatal)
 		},
 	}
+	cmd.PersistentFlags().BoolVarP(&watch, "watch", "w", false, "after showing the engines, watch the component pods & print the changed engines")
 	return cmd
 }
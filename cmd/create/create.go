@@ -0,0 +1,62 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package create
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/storageclass"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdCreate provides options for creating OpenEBS resources
+func NewCmdCreate(rootCmd *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "create",
+		Short:     "Provides create operations related to a StorageClass",
+		ValidArgs: []string{"storageclass"},
+	}
+	cmd.AddCommand(
+		NewCmdCreateStorageClass(),
+	)
+	return cmd
+}
+
+// NewCmdCreateStorageClass asks for the parameters of a StorageClass of an
+// engine & creates it
+func NewCmdCreateStorageClass() *cobra.Command {
+	var casType, name string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:     "storageclass [NAME]",
+		Aliases: []string{"sc"},
+		Short:   "Asks for the parameters of a StorageClass of the engine & creates it",
+		Example: `  kubectl openebs create storageclass --cas-type localpv-zfs
+  kubectl openebs create storageclass openebs-lvm --cas-type localpv-lvm --dry-run -o yaml > sc.yaml`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			if len(args) == 1 {
+				name = args[0]
+			}
+			util.CheckErr(storageclass.Create(casType, name, dryRun, output), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s, %s, asked if not provided", util.LVMCasType, util.ZFSCasType, util.LocalPvHostpathCasType))
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "only print the storageclass, in the yaml or json output format")
+	return cmd
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dashboard
+
+import (
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/dashboard"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDashboard shows a live full-screen view of the engines, the storage,
+// the volumes & the PVCs
+func NewCmdDashboard(rootCmd *cobra.Command) *cobra.Command {
+	var interval time.Duration
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Shows a live terminal dashboard of the OpenEBS engines, storage, volumes and PVCs",
+		Long: `Shows a live terminal dashboard of the OpenEBS engines, storage, volumes and PVCs.
+The tabs are switched with the arrow keys, tab or 1-4, / filters the rows as you type
+& enter shows the describe view of the selected row.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(dashboard.Run(interval), util.Fatal)
+		},
+	}
+	cmd.Flags().DurationVarP(&interval, "interval", "", dashboard.DefaultInterval, "the interval between two refreshes of the active tab")
+	return cmd
+}
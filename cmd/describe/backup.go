@@ -0,0 +1,50 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package describe
+
+import (
+	"github.com/openebs/openebsctl/pkg/backup"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDescribeBackup displays ZFS-LocalPV backup related information.
+func NewCmdDescribeBackup() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "backup",
+		Aliases: []string{"backups", "bkp"},
+		Short:   "Displays ZFS-LocalPV backup related information",
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(backup.Describe(args, output), util.Fatal)
+		},
+	}
+	return cmd
+}
+
+// NewCmdDescribeRestore displays ZFS-LocalPV restore related information.
+func NewCmdDescribeRestore() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "restore",
+		Aliases: []string{"restores", "rst"},
+		Short:   "Displays ZFS-LocalPV restore related information",
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(backup.DescribeRestores(args, output), util.Fatal)
+		},
+	}
+	return cmd
+}
@@ -24,13 +24,18 @@ import (
 func NewCmdDescribe(rootCmd *cobra.Command) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:       "describe",
-		ValidArgs: []string{"storage", "volume", "pvc"},
+		ValidArgs: []string{"storage", "volume", "pvc", "snapshot", "backup", "restore", "node", "storageclass"},
 		Short:     "Provide detailed information about an OpenEBS resource",
 	}
 	cmd.AddCommand(
 		NewCmdDescribeVolume(),
 		NewCmdDescribePVC(),
 		NewCmdDescribeStorage(),
+		NewCmdDescribeSnapshot(),
+		NewCmdDescribeBackup(),
+		NewCmdDescribeRestore(),
+		NewCmdDescribeNode(),
+		NewCmdDescribeStorageClass(),
 	)
 	return cmd
 }
@@ -36,7 +36,8 @@ func NewCmdDescribePVC() *cobra.Command {
 				pvNs = "default"
 			}
 			openebsNamespace, _ = cmd.Flags().GetString("openebs-namespace")
-			util.CheckErr(persistentvolumeclaim.Describe(args, pvNs, openebsNamespace), util.Fatal)
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(persistentvolumeclaim.Describe(args, pvNs, openebsNamespace, output), util.Fatal)
 		},
 	}
 	cmd.PersistentFlags().StringVarP(&openebsNs, "openebs-namespace", "", "", "to read the openebs namespace from user.\nIf not provided it is determined from components.")
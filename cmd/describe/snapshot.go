@@ -0,0 +1,43 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package describe
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/snapshot"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDescribeSnapshot displays OpenEBS snapshot related information.
+func NewCmdDescribeSnapshot() *cobra.Command {
+	var casType string
+	cmd := &cobra.Command{
+		Use:     "snapshot",
+		Aliases: []string{"snapshots", "snap"},
+		Short:   "Displays snapshot related information",
+		Run: func(cmd *cobra.Command, args []string) {
+			casType, _ := cmd.Flags().GetString("cas-type")
+			casType = strings.ToLower(casType)
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(snapshot.Describe(args, casType, output), util.Fatal)
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s", util.LVMCasType, util.ZFSCasType))
+	return cmd
+}
@@ -37,7 +37,8 @@ func NewCmdDescribeStorage() *cobra.Command {
 			openebsNs, _ := cmd.Flags().GetString("openebs-namespace")
 			casType, _ := cmd.Flags().GetString("cas-type")
 			casType = strings.ToLower(casType)
-			util.CheckErr(storage.Describe(args, openebsNs, casType), util.Fatal)
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(storage.Describe(args, openebsNs, casType, output), util.Fatal)
 		},
 	}
 	cmd.PersistentFlags().StringVarP(&openebsNs, "openebs-namespace", "", "", "to read the openebs namespace from user.\nIf not provided it is determined from components.")
@@ -0,0 +1,37 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package describe
+
+import (
+	"github.com/openebs/openebsctl/pkg/storageclass"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDescribeStorageClass checks the StorageClasses against their engines
+func NewCmdDescribeStorageClass() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "storageclass NAME...",
+		Aliases: []string{"storageclasses", "sc"},
+		Short:   "Checks the parameters of the StorageClass(es) against their engine and shows the volumes using them",
+		Args:    cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(storageclass.Describe(args, output), util.Fatal)
+		},
+	}
+	return cmd
+}
@@ -31,7 +31,8 @@ func NewCmdDescribeVolume() *cobra.Command {
 		Short:   "Displays Openebs information",
 		Run: func(cmd *cobra.Command, args []string) {
 			openebsNS, _ := cmd.Flags().GetString("openebs-namespace")
-			util.CheckErr(volume.Describe(args, openebsNS), util.Fatal)
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(volume.Describe(args, openebsNS, output), util.Fatal)
 		},
 	}
 	cmd.PersistentFlags().StringVarP(&openebsNs, "openebs-namespace", "", "", "to read the openebs namespace from user.\nIf not provided it is determined from components.")
@@ -0,0 +1,53 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"github.com/openebs/openebsctl/pkg/diagnose"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDiagnose provides options for diagnosing OpenEBS resources
+func NewCmdDiagnose(rootCmd *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "diagnose",
+		Short:     "Provides diagnosis of stuck resources related to a Volume",
+		ValidArgs: []string{"pvc"},
+	}
+	cmd.AddCommand(
+		NewCmdDiagnosePVC(),
+	)
+	return cmd
+}
+
+// NewCmdDiagnosePVC shows the likely causes of a PVC being stuck
+func NewCmdDiagnosePVC() *cobra.Command {
+	var namespace string
+	cmd := &cobra.Command{
+		Use:     "pvc NAME",
+		Aliases: []string{"pvcs"},
+		Short:   "Shows the ranked likely causes of a Pending or unusable PVC",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(diagnose.Diagnose(args[0], namespace, output), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "namespace of the PVC")
+	return cmd
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package draincheck
+
+import (
+	"os"
+
+	"github.com/openebs/openebsctl/pkg/node"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdDrainCheck shows the impact of draining a node on its local volumes
+func NewCmdDrainCheck(rootCmd *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drain-check NODE",
+		Short: "Shows the local volumes of a node and the workloads which would lose their storage if it is drained",
+		Long: `Shows the ZFS, LVM and hostpath volumes pinned to a node, the pods and the controllers using them,
+their ready pods on the other nodes and whether draining the node leaves them unavailable.
+Nothing is changed. The exit code is 1 if pods would lose their storage or on errors.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			storageLoss, err := node.DrainCheck(args[0], output)
+			util.CheckErr(err, util.Fatal)
+			if storageLoss {
+				os.Exit(1)
+			}
+		},
+	}
+	return cmd
+}
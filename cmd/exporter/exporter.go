@@ -0,0 +1,41 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package exporter
+
+import (
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/exporter"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdExporter serves the pool, volume, engine & orphan metrics in the
+// Prometheus text format
+func NewCmdExporter(rootCmd *cobra.Command) *cobra.Command {
+	var listen string
+	var interval time.Duration
+	cmd := &cobra.Command{
+		Use:   "exporter",
+		Short: "Serves the metrics of the OpenEBS pools, volumes, engines and orphaned resources for Prometheus",
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(exporter.Run(listen, interval), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&listen, "listen", "", exporter.DefaultListenAddress, "the address to serve the metrics on")
+	cmd.Flags().DurationVarP(&interval, "interval", "", exporter.DefaultInterval, "the interval between two collections of the metrics")
+	return cmd
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package get
+
+import (
+	"github.com/openebs/openebsctl/pkg/backup"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdGetBackup displays status of ZFS-LocalPV Backup(s)
+func NewCmdGetBackup() *cobra.Command {
+	var volume string
+	cmd := &cobra.Command{
+		Use:     "backup",
+		Aliases: []string{"backups", "bkp"},
+		Short:   "Displays status information about ZFS-LocalPV Backup(s)",
+		Run: func(cmd *cobra.Command, args []string) {
+			volume, _ := cmd.Flags().GetString("volume")
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(backup.Get(args, volume, output), util.Fatal)
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&volume, "volume", "", "", "only list the backups of this volume")
+	return cmd
+}
+
+// NewCmdGetRestore displays status of ZFS-LocalPV Restore(s)
+func NewCmdGetRestore() *cobra.Command {
+	var volume string
+	cmd := &cobra.Command{
+		Use:     "restore",
+		Aliases: []string{"restores", "rst"},
+		Short:   "Displays status information about ZFS-LocalPV Restore(s)",
+		Run: func(cmd *cobra.Command, args []string) {
+			volume, _ := cmd.Flags().GetString("volume")
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(backup.GetRestores(args, volume, output), util.Fatal)
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&volume, "volume", "", "", "only list the restores into this volume")
+	return cmd
+}
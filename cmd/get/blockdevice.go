@@ -0,0 +1,41 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package get
+
+import (
+	"github.com/openebs/openebsctl/pkg/blockdevice"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdGetBD displays the NDM BlockDevices of the nodes
+func NewCmdGetBD() *cobra.Command {
+	var labelSelector, fieldSelector string
+	cmd := &cobra.Command{
+		Use:     "bd [NAME...]",
+		Aliases: []string{"bds", "blockdevice", "blockdevices"},
+		Short:   "Displays the NDM BlockDevices of the nodes and their claim states",
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			labelSelector, _ := cmd.Flags().GetString("selector")
+			fieldSelector, _ := cmd.Flags().GetString("field-selector")
+			util.CheckErr(blockdevice.Get(args, output, labelSelector, fieldSelector), util.Fatal)
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&labelSelector, "selector", "l", "", "label selector to filter on, supports '=', '==', and '!=', i.e. -l kubernetes.io/hostname=node1")
+	cmd.PersistentFlags().StringVarP(&fieldSelector, "field-selector", "", "", "field selector to filter on, i.e. --field-selector metadata.name=blockdevice-1")
+	return cmd
+}
@@ -25,11 +25,16 @@ func NewCmdGet(rootCmd *cobra.Command) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:       "get",
 		Short:     "Provides fetching operations related to a Volume/Storage",
-		ValidArgs: []string{"storage", "volume", "bd"},
+		ValidArgs: []string{"storage", "volume", "snapshot", "backup", "restore", "node", "bd"},
 	}
 	cmd.AddCommand(
 		NewCmdGetVolume(),
 		NewCmdGetStorage(),
+		NewCmdGetSnapshot(),
+		NewCmdGetBackup(),
+		NewCmdGetRestore(),
+		NewCmdGetNode(),
+		NewCmdGetBD(),
 	)
 	return cmd
 }
@@ -0,0 +1,37 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"github.com/openebs/openebsctl/pkg/node"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdGetNode displays the OpenEBS components, pools & volumes of the nodes
+func NewCmdGetNode() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "node [NAME...]",
+		Aliases: []string{"nodes", "no"},
+		Short:   "Displays the engine agents, pools and volumes of the node(s)",
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(node.Get(args, output), util.Fatal)
+		},
+	}
+	return cmd
+}
@@ -28,6 +28,8 @@ import (
 func NewCmdGetStorage() *cobra.Command {
 	var casType string
 	var openebsNs string
+	var labelSelector, fieldSelector string
+	var watch bool
 	cmd := &cobra.Command{
 		Use:     "storage",
 		Aliases: []string{"storages", "s"},
@@ -35,10 +37,17 @@ func NewCmdGetStorage() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			openebsNS, _ := cmd.Flags().GetString("openebs-namespace")
 			casType, _ := cmd.Flags().GetString("cas-type")
-			util.CheckErr(storage.Get(args, openebsNS, casType), util.Fatal)
+			output, _ := cmd.Flags().GetString("output")
+			labelSelector, _ := cmd.Flags().GetString("selector")
+			fieldSelector, _ := cmd.Flags().GetString("field-selector")
+			watch, _ := cmd.Flags().GetBool("watch")
+			util.CheckErr(storage.Get(args, openebsNS, casType, output, labelSelector, fieldSelector, watch), util.Fatal)
 		},
 	}
 	cmd.PersistentFlags().StringVarP(&openebsNs, "openebs-namespace", "", "", "to read the openebs namespace from user.\nIf not provided it is determined from components.")
-	cmd.PersistentFlags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s", util.LVMCasType, util.ZFSCasType))
+	cmd.PersistentFlags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s, %s", util.LVMCasType, util.ZFSCasType, util.MayastorCasType))
+	cmd.PersistentFlags().StringVarP(&labelSelector, "selector", "l", "", "label selector to filter on, supports '=', '==', and '!=', i.e. -l key1=value1,key2=value2")
+	cmd.PersistentFlags().StringVarP(&fieldSelector, "field-selector", "", "", "field selector to filter on, i.e. --field-selector metadata.name=pvc-1")
+	cmd.PersistentFlags().BoolVarP(&watch, "watch", "w", false, "after listing the storage, watch for changes & print the changed storage")
 	return cmd
 }
@@ -28,6 +28,9 @@ import (
 func NewCmdGetVolume() *cobra.Command {
 	var openebsNs string
 	var casType string
+	var labelSelector, fieldSelector string
+	var filter util.VolumeFilter
+	var watch bool
 	cmd := &cobra.Command{
 		Use:     "volume",
 		Aliases: []string{"vol", "v", "volumes"},
@@ -35,10 +38,21 @@ func NewCmdGetVolume() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			openebsNS, _ := cmd.Flags().GetString("openebs-namespace")
 			casType, _ := cmd.Flags().GetString("cas-type")
-			util.CheckErr(volume.Get(args, openebsNS, casType), util.Fatal)
+			output, _ := cmd.Flags().GetString("output")
+			labelSelector, _ := cmd.Flags().GetString("selector")
+			fieldSelector, _ := cmd.Flags().GetString("field-selector")
+			watch, _ := cmd.Flags().GetBool("watch")
+			util.CheckErr(volume.Get(args, openebsNS, casType, output, labelSelector, fieldSelector, filter, watch), util.Fatal)
 		},
 	}
 	cmd.PersistentFlags().StringVarP(&openebsNs, "openebs-namespace", "", "", "to read the openebs namespace from user.\nIf not provided it is determined from components.")
-	cmd.PersistentFlags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s", util.LVMCasType, util.ZFSCasType))
+	cmd.PersistentFlags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s, %s, %s, %s", util.LVMCasType, util.ZFSCasType, util.LocalPvHostpathCasType, util.LocalPvDeviceCasType, util.MayastorCasType))
+	cmd.PersistentFlags().StringVarP(&labelSelector, "selector", "l", "", "label selector to filter on, supports '=', '==', and '!=', i.e. -l key1=value1,key2=value2")
+	cmd.PersistentFlags().StringVarP(&fieldSelector, "field-selector", "", "", "field selector to filter on, i.e. --field-selector metadata.name=pvc-1")
+	cmd.PersistentFlags().StringVarP(&filter.Node, "node", "", "", "only list the volumes provisioned on this node")
+	cmd.PersistentFlags().StringVarP(&filter.Pool, "pool", "", "", "only list the volumes of this ZFS pool or LVM volume group")
+	cmd.PersistentFlags().StringVarP(&filter.StorageClass, "storageclass", "", "", "only list the volumes of this storage class")
+	cmd.PersistentFlags().StringVarP(&filter.PVCNamespace, "pvc-namespace", "", "", "only list the volumes claimed by PVCs of this namespace")
+	cmd.PersistentFlags().BoolVarP(&watch, "watch", "w", false, "after listing the volumes, watch for changes & print the changed volumes")
 	return cmd
 }
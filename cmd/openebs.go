@@ -18,11 +18,26 @@ package cmd
 
 import (
 	"flag"
+	"fmt"
+	"strings"
 
+	"github.com/openebs/openebsctl/cmd/capacity"
+	"github.com/openebs/openebsctl/cmd/check"
+	"github.com/openebs/openebsctl/cmd/cleanup"
+	"github.com/openebs/openebsctl/cmd/clone"
 	"github.com/openebs/openebsctl/cmd/clusterinfo"
 	"github.com/openebs/openebsctl/cmd/completion"
+	"github.com/openebs/openebsctl/cmd/create"
+	"github.com/openebs/openebsctl/cmd/dashboard"
 	"github.com/openebs/openebsctl/cmd/describe"
+	"github.com/openebs/openebsctl/cmd/diagnose"
+	"github.com/openebs/openebsctl/cmd/draincheck"
+	"github.com/openebs/openebsctl/cmd/exporter"
 	"github.com/openebs/openebsctl/cmd/get"
+	"github.com/openebs/openebsctl/cmd/orphans"
+	"github.com/openebs/openebsctl/cmd/resize"
+	"github.com/openebs/openebsctl/cmd/snapshot"
+	"github.com/openebs/openebsctl/cmd/top"
 	v "github.com/openebs/openebsctl/cmd/version"
 	"github.com/openebs/openebsctl/pkg/util"
 	"github.com/spf13/cobra"
@@ -37,7 +52,7 @@ func NewOpenebsCommand() *cobra.Command {
 	//var openebsNs string
 	cmd := &cobra.Command{
 		Use:       "openebs",
-		ValidArgs: []string{"get", "describe", "completion"},
+		ValidArgs: []string{"get", "describe", "snapshot", "clone", "resize", "capacity", "diagnose", "check", "drain-check", "orphans", "cleanup", "create", "top", "exporter", "dashboard", "completion"},
 		Short:     "kubectl openebs is a a kubectl plugin for interacting with OpenEBS storage components",
 		Long: `openebs is a a kubectl plugin for interacting with OpenEBS storage components such as storage(zfspools, volumegroups), volumes, pvcs.
 Find out more about OpenEBS on https://openebs.io/`,
@@ -48,10 +63,25 @@ Find out more about OpenEBS on https://openebs.io/`,
 		completion.NewCmdCompletion(cmd),
 		get.NewCmdGet(cmd),
 		describe.NewCmdDescribe(cmd),
+		snapshot.NewCmdSnapshot(cmd),
+		clone.NewCmdClone(cmd),
+		resize.NewCmdResize(cmd),
+		capacity.NewCmdCapacity(cmd),
+		diagnose.NewCmdDiagnose(cmd),
+		check.NewCmdCheck(cmd),
+		draincheck.NewCmdDrainCheck(cmd),
+		orphans.NewCmdOrphans(cmd),
+		cleanup.NewCmdCleanup(cmd),
+		create.NewCmdCreate(cmd),
+		top.NewCmdTop(cmd),
+		exporter.NewCmdExporter(cmd),
+		dashboard.NewCmdDashboard(cmd),
 		v.NewCmdVersion(cmd),
 		clusterinfo.NewCmdClusterInfo(cmd),
 	)
 	cmd.PersistentFlags().StringVarP(&util.Kubeconfig, "kubeconfig", "c", "", "path to config file")
+	cmd.PersistentFlags().StringP("output", "o", "", fmt.Sprintf("output format, one of: %s.\nListings also support: %s",
+		strings.Join(util.OutputFormats, "|"), strings.Join(util.TemplateOutputFormats, "=...|")+"=..."))
 	cmd.Flags().AddGoFlagSet(flag.CommandLine)
 	_ = flag.CommandLine.Parse([]string{})
 	_ = viper.BindPFlag("namespace", cmd.PersistentFlags().Lookup("namespace"))
@@ -0,0 +1,48 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/orphan"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdOrphans shows the leftover resources of the engines
+func NewCmdOrphans(rootCmd *cobra.Command) *cobra.Command {
+	var casType string
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "Finds the leftover volumes, snapshots, PVs, failed hostpath cleanups and VolumeAttachments of deleted volumes",
+		Long: `Finds the leftover resources of the engines which still hold space or attachments:
+- ZFSVolumes and LVMVolumes without a PV
+- PVs with the Retain reclaim policy in the Released phase
+- FailedHostpathCleanups, the hostpath directories of deleted PVs whose cleanup pods didn't succeed
+- ZFSSnapshots and LVMSnapshots whose source volume is deleted
+- VolumeAttachments of the OpenEBS CSI drivers for deleted PVs
+The directories on the nodes aren't listed, so the leaked hostpath directories without a cleanup pod
+are not detected.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(orphan.Get(casType, output), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s, %s", util.LVMCasType, util.ZFSCasType, util.LocalPvHostpathCasType))
+	return cmd
+}
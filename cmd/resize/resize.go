@@ -0,0 +1,58 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resize
+
+import (
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/resize"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdResize provides options for expanding OpenEBS Volumes
+func NewCmdResize(rootCmd *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "resize",
+		Short:     "Provides expansion operations related to a Volume",
+		ValidArgs: []string{"pvc"},
+	}
+	cmd.AddCommand(
+		NewCmdResizePVC(),
+	)
+	return cmd
+}
+
+// NewCmdResizePVC expands a ZFS or LVM PVC
+func NewCmdResizePVC() *cobra.Command {
+	var namespace, size string
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:     "pvc NAME --size SIZE",
+		Aliases: []string{"pvcs"},
+		Short:   "Expands the PVC & waits till its volume & filesystem are resized",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(resize.Resize(args[0], namespace, size, timeout), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "namespace of the PVC")
+	cmd.Flags().StringVarP(&size, "size", "", "", "the new size of the PVC, e.g. 20Gi")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "", 5*time.Minute, "time to wait for the resize to finish")
+	_ = cmd.MarkFlagRequired("size")
+	return cmd
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/snapshot"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const defaultTimeout = 5 * time.Minute
+
+// NewCmdSnapshot provides options for managing OpenEBS Snapshots
+func NewCmdSnapshot(rootCmd *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "snapshot",
+		Aliases:   []string{"snap"},
+		Short:     "Provides create & delete operations related to a Snapshot",
+		ValidArgs: []string{"create", "delete"},
+	}
+	cmd.AddCommand(
+		NewCmdSnapshotCreate(),
+		NewCmdSnapshotDelete(),
+	)
+	return cmd
+}
+
+// NewCmdSnapshotCreate creates a CSI VolumeSnapshot of a ZFS or LVM PVC
+func NewCmdSnapshotCreate() *cobra.Command {
+	var namespace, name, class string
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "create PVC",
+		Short: "Creates a VolumeSnapshot of the PVC & waits till it is ready to use",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(snapshot.Create(args[0], namespace, name, class, timeout), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "namespace of the PVC & of the VolumeSnapshot")
+	cmd.Flags().StringVarP(&name, "name", "", "", "name of the VolumeSnapshot, defaults to <pvc>-snap-<timestamp>")
+	cmd.Flags().StringVarP(&class, "class", "", "", "the VolumeSnapshotClass, defaults to the class of the PVC's CSI driver")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "", defaultTimeout, "time to wait for the snapshot to be ready")
+	return cmd
+}
+
+// NewCmdSnapshotDelete deletes the CSI VolumeSnapshots of ZFS or LVM PVCs
+func NewCmdSnapshotDelete() *cobra.Command {
+	var namespace string
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "delete VOLUMESNAPSHOT...",
+		Short: "Deletes the VolumeSnapshots & waits till they are removed",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(snapshot.Delete(args, namespace, timeout), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "namespace of the VolumeSnapshots")
+	cmd.Flags().DurationVarP(&timeout, "timeout", "", defaultTimeout, "time to wait for the snapshots to be deleted")
+	return cmd
+}
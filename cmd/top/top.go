@@ -0,0 +1,60 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package top
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/openebs/openebsctl/pkg/volume"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTop provides the commands showing the used space of the OpenEBS resources
+func NewCmdTop(rootCmd *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "top",
+		Short:     "Shows the used space of the OpenEBS volumes",
+		ValidArgs: []string{"volume"},
+	}
+	cmd.AddCommand(
+		NewCmdTopVolume(),
+	)
+	return cmd
+}
+
+// NewCmdTopVolume shows the space used by the filesystems of the volumes as
+// reported by the kubelets
+func NewCmdTopVolume() *cobra.Command {
+	var casType, sortBy string
+	var filter util.VolumeFilter
+	cmd := &cobra.Command{
+		Use:     "volume",
+		Aliases: []string{"vol", "v", "volumes"},
+		Short:   "Shows the used & available space of the volumes mounted by the pods",
+		Run: func(cmd *cobra.Command, args []string) {
+			output, _ := cmd.Flags().GetString("output")
+			util.CheckErr(volume.Top(casType, output, sortBy, filter), util.Fatal)
+		},
+	}
+	cmd.Flags().StringVarP(&casType, "cas-type", "", "", fmt.Sprintf("the type of the engine %s, %s, %s, %s, %s", util.LVMCasType, util.ZFSCasType, util.LocalPvHostpathCasType, util.LocalPvDeviceCasType, util.MayastorCasType))
+	cmd.Flags().StringVarP(&sortBy, "sort-by", "", volume.SortByUsedPercent, fmt.Sprintf("sort the volumes by one of %s, %s, %s, %s", volume.SortByUsedPercent, volume.SortByUsed, volume.SortByAvailable, volume.SortByName))
+	cmd.Flags().StringVarP(&filter.Node, "node", "", "", "only show the volumes provisioned on this node")
+	cmd.Flags().StringVarP(&filter.Pool, "pool", "", "", "only show the volumes of this ZFS pool or LVM volume group")
+	cmd.Flags().StringVarP(&filter.StorageClass, "storageclass", "", "", "only show the volumes of this storage class")
+	cmd.Flags().StringVarP(&filter.PVCNamespace, "pvc-namespace", "", "", "only show the volumes claimed by PVCs of this namespace")
+	return cmd
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// backupListKind is the kind of the machine-readable backup listing
+	backupListKind = "BackupList"
+	// backupDescribeKind is the kind of the machine-readable backup describe
+	backupDescribeKind = "BackupDescriptionList"
+)
+
+const backupInfo = `
+{{.Name}} Details :
+------------------
+NAME                : {{.Name}}
+NAMESPACE           : {{.Namespace}}
+VOLUME              : {{.Volume}}
+NODE                : {{.Node}}
+SNAPSHOT            : {{.Snapshot}}
+PREVIOUS SNAPSHOT   : {{.PrevSnapshot}}
+DESTINATION         : {{.Destination}}
+STATE               : {{.State}}
+CREATION TIME       : {{.CreationTime}}
+`
+
+// Get lists the ZFSBackups, if volume is not empty only the backups of that
+// volume are listed
+func Get(backups []string, volume, output string) error {
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	found, err := GetZFSBackups(k, backups, volume)
+	if err != nil {
+		return fmt.Errorf("failed to list ZFSBackups: %v", err)
+	}
+	if len(found) == 0 {
+		if util.IsStructuredOutput(output) {
+			return util.PrintList(output, backupListKind, nil)
+		}
+		return util.HandleEmptyTableError("Backup", "", util.ZFSCasType)
+	}
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, len(found))
+		for i := range found {
+			items[i] = found[i]
+		}
+		return util.PrintList(output, backupListKind, items)
+	}
+	util.TablePrinter(util.BackupListColumnDefinitions, BackupRows(found), printers.PrintOptions{Wide: true})
+	return nil
+}
+
+// Describe describes the ZFSBackups
+func Describe(backups []string, output string) error {
+	if len(backups) == 0 {
+		return errors.New("please provide atleast one backup name to describe")
+	}
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	found, err := GetZFSBackups(k, backups, "")
+	if err != nil {
+		return fmt.Errorf("failed to list ZFSBackups: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, b := range found {
+		names[b.Name] = true
+	}
+	for _, name := range backups {
+		if !names[name] {
+			_, _ = fmt.Fprintf(os.Stderr, "Error from server (NotFound): zfsbackup %s not found\n", name)
+		}
+	}
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, len(found))
+		for i := range found {
+			items[i] = found[i]
+		}
+		return util.PrintList(output, backupDescribeKind, items)
+	}
+	for _, b := range found {
+		if err := util.PrintByTemplate("backup", backupInfo, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetZFSBackups returns the ZFSBackups with the names, all of them if names is
+// empty, if volume is not empty only the backups of that volume are returned
+func GetZFSBackups(c *client.K8sClient, names []string, volume string) ([]util.Backup, error) {
+	zbkps, err := c.GetZFSBackups(names, "")
+	if err != nil {
+		return nil, err
+	}
+	var backups []util.Backup
+	for _, zb := range zbkps.Items {
+		if volume != "" && zb.Spec.VolumeName != volume {
+			continue
+		}
+		backups = append(backups, util.Backup{
+			Name:         zb.Name,
+			Namespace:    zb.Namespace,
+			Volume:       zb.Spec.VolumeName,
+			Node:         zb.Spec.OwnerNodeID,
+			Snapshot:     zb.Spec.SnapName,
+			PrevSnapshot: zb.Spec.PrevSnapName,
+			Destination:  zb.Spec.BackupDest,
+			State:        string(zb.Status),
+			CreationTime: zb.CreationTimestamp.UTC().Format(time.RFC3339),
+		})
+	}
+	return backups, nil
+}
+
+// BackupRows returns the table rows of the backups
+func BackupRows(backups []util.Backup) []metav1.TableRow {
+	var rows []metav1.TableRow
+	for _, b := range backups {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{b.Name, b.Volume, b.Node, b.Snapshot, b.Destination, b.State, b.CreationTime}})
+	}
+	return rows
+}
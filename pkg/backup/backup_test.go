@@ -0,0 +1,103 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package backup
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+)
+
+func TestGetZFSBackups(t *testing.T) {
+	tests := []struct {
+		name   string
+		names  []string
+		volume string
+		want   []string
+	}{
+		{"all the backups", nil, "", []string{"nightly.pvc-1", "nightly.pvc-2"}},
+		{"backup by name", []string{"nightly.pvc-2"}, "", []string{"nightly.pvc-2"}},
+		{"backups of a volume", nil, "pvc-1", []string{"nightly.pvc-1"}},
+		{"backups of a volume without backups", nil, "pvc-3", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &client.K8sClient{ZFCS: fake.NewSimpleClientset(&zfsBackup1, &zfsBackup2)}
+			backups, err := GetZFSBackups(c, tt.names, tt.volume)
+			if err != nil {
+				t.Fatalf("GetZFSBackups() error = %v", err)
+			}
+			var got []string
+			for _, b := range backups {
+				got = append(got, b.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetZFSBackups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetZFSBackupsDetails(t *testing.T) {
+	c := &client.K8sClient{ZFCS: fake.NewSimpleClientset(&zfsBackup2)}
+	backups, err := GetZFSBackups(c, nil, "")
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("GetZFSBackups() = %v, %v, want 1 backup", backups, err)
+	}
+	got := backups[0]
+	got.CreationTime = ""
+	want := util.Backup{Name: "nightly.pvc-2", Namespace: "zfs", Volume: "pvc-2", Node: "node2", Snapshot: "nightly",
+		PrevSnapshot: "weekly", Destination: "10.0.0.2:9010", State: "InProgress"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetZFSBackups() = %+v, want %+v", got, want)
+	}
+	if cells := len(BackupRows(backups)[0].Cells); cells != len(util.BackupListColumnDefinitions) {
+		t.Errorf("BackupRows() has %d cells, want %d", cells, len(util.BackupListColumnDefinitions))
+	}
+}
+
+func TestGetZFSRestores(t *testing.T) {
+	tests := []struct {
+		name   string
+		volume string
+		want   []util.Restore
+	}{
+		{"all the restores", "", []util.Restore{{Name: "restore-1.pvc-3", Namespace: "zfs", Volume: "pvc-3",
+			Node: "node1", Source: "10.0.0.1:9010", State: "Pending"}}},
+		{"restores into a volume without restores", "pvc-1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &client.K8sClient{ZFCS: fake.NewSimpleClientset(&zfsRestore1)}
+			got, err := GetZFSRestores(c, nil, tt.volume)
+			if err != nil {
+				t.Fatalf("GetZFSRestores() error = %v", err)
+			}
+			for i := range got {
+				got[i].CreationTime = ""
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetZFSRestores() = %+v, want %+v", got, tt.want)
+			}
+			if len(got) > 0 && len(RestoreRows(got)[0].Cells) != len(util.RestoreListColumnDefinitions) {
+				t.Errorf("RestoreRows() has %d cells, want %d", len(RestoreRows(got)[0].Cells), len(util.RestoreListColumnDefinitions))
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// restoreListKind is the kind of the machine-readable restore listing
+	restoreListKind = "RestoreList"
+	// restoreDescribeKind is the kind of the machine-readable restore describe
+	restoreDescribeKind = "RestoreDescriptionList"
+)
+
+const restoreInfo = `
+{{.Name}} Details :
+------------------
+NAME            : {{.Name}}
+NAMESPACE       : {{.Namespace}}
+VOLUME          : {{.Volume}}
+NODE            : {{.Node}}
+SOURCE          : {{.Source}}
+STATE           : {{.State}}
+CREATION TIME   : {{.CreationTime}}
+`
+
+// GetRestores lists the ZFSRestores, if volume is not empty only the restores
+// into that volume are listed
+func GetRestores(restores []string, volume, output string) error {
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	found, err := GetZFSRestores(k, restores, volume)
+	if err != nil {
+		return fmt.Errorf("failed to list ZFSRestores: %v", err)
+	}
+	if len(found) == 0 {
+		if util.IsStructuredOutput(output) {
+			return util.PrintList(output, restoreListKind, nil)
+		}
+		return util.HandleEmptyTableError("Restore", "", util.ZFSCasType)
+	}
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, len(found))
+		for i := range found {
+			items[i] = found[i]
+		}
+		return util.PrintList(output, restoreListKind, items)
+	}
+	util.TablePrinter(util.RestoreListColumnDefinitions, RestoreRows(found), printers.PrintOptions{Wide: true})
+	return nil
+}
+
+// DescribeRestores describes the ZFSRestores
+func DescribeRestores(restores []string, output string) error {
+	if len(restores) == 0 {
+		return errors.New("please provide atleast one restore name to describe")
+	}
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	found, err := GetZFSRestores(k, restores, "")
+	if err != nil {
+		return fmt.Errorf("failed to list ZFSRestores: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, r := range found {
+		names[r.Name] = true
+	}
+	for _, name := range restores {
+		if !names[name] {
+			_, _ = fmt.Fprintf(os.Stderr, "Error from server (NotFound): zfsrestore %s not found\n", name)
+		}
+	}
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, len(found))
+		for i := range found {
+			items[i] = found[i]
+		}
+		return util.PrintList(output, restoreDescribeKind, items)
+	}
+	for _, r := range found {
+		if err := util.PrintByTemplate("restore", restoreInfo, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetZFSRestores returns the ZFSRestores with the names, all of them if names
+// is empty, if volume is not empty only the restores into that volume are
+// returned
+func GetZFSRestores(c *client.K8sClient, names []string, volume string) ([]util.Restore, error) {
+	zrsts, err := c.GetZFSRestores(names, "")
+	if err != nil {
+		return nil, err
+	}
+	var restores []util.Restore
+	for _, zr := range zrsts.Items {
+		if volume != "" && zr.Spec.VolumeName != volume {
+			continue
+		}
+		restores = append(restores, util.Restore{
+			Name:         zr.Name,
+			Namespace:    zr.Namespace,
+			Volume:       zr.Spec.VolumeName,
+			Node:         zr.Spec.OwnerNodeID,
+			Source:       zr.Spec.RestoreSrc,
+			State:        string(zr.Status),
+			CreationTime: zr.CreationTimestamp.UTC().Format(time.RFC3339),
+		})
+	}
+	return restores, nil
+}
+
+// RestoreRows returns the table rows of the restores
+func RestoreRows(restores []util.Restore) []metav1.TableRow {
+	var rows []metav1.TableRow
+	for _, r := range restores {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{r.Name, r.Volume, r.Node, r.Source, r.State, r.CreationTime}})
+	}
+	return rows
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package backup
+
+import (
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var zfsBackup1 = zfs.ZFSBackup{
+	TypeMeta: metav1.TypeMeta{
+		Kind:       "ZFSBackup",
+		APIVersion: "zfs.openebs.io/v1",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "nightly.pvc-1",
+		Namespace: "zfs",
+	},
+	Spec: zfs.ZFSBackupSpec{
+		VolumeName:  "pvc-1",
+		OwnerNodeID: "node1",
+		SnapName:    "nightly",
+		BackupDest:  "10.0.0.1:9010",
+	},
+	Status: zfs.BKPZFSStatusDone,
+}
+
+var zfsBackup2 = zfs.ZFSBackup{
+	TypeMeta: metav1.TypeMeta{
+		Kind:       "ZFSBackup",
+		APIVersion: "zfs.openebs.io/v1",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "nightly.pvc-2",
+		Namespace: "zfs",
+	},
+	Spec: zfs.ZFSBackupSpec{
+		VolumeName:   "pvc-2",
+		OwnerNodeID:  "node2",
+		SnapName:     "nightly",
+		PrevSnapName: "weekly",
+		BackupDest:   "10.0.0.2:9010",
+	},
+	Status: zfs.BKPZFSStatusInProgress,
+}
+
+var zfsRestore1 = zfs.ZFSRestore{
+	TypeMeta: metav1.TypeMeta{
+		Kind:       "ZFSRestore",
+		APIVersion: "zfs.openebs.io/v1",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "restore-1.pvc-3",
+		Namespace: "zfs",
+	},
+	Spec: zfs.ZFSRestoreSpec{
+		VolumeName:  "pvc-3",
+		OwnerNodeID: "node1",
+		RestoreSrc:  "10.0.0.1:9010",
+	},
+	Status: zfs.RSTZFSStatusPending,
+}
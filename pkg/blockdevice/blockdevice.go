@@ -0,0 +1,107 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package blockdevice
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// blockDeviceListKind is the kind of the machine-readable blockdevice listing
+const blockDeviceListKind = "BlockDeviceList"
+
+// Get lists the NDM BlockDevices matching the label & field selectors, all
+// the BlockDevices are listed if none are passed
+func Get(bds []string, output, labelSelector, fieldSelector string) error {
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	if err := util.CheckSelectors(bds, labelSelector, fieldSelector); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	descs, err := GetBlockDeviceDescs(k, bds, labelSelector, fieldSelector)
+	if err != nil {
+		return err
+	}
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, len(descs))
+		for i := range descs {
+			items[i] = descs[i]
+		}
+		return util.PrintList(output, blockDeviceListKind, items)
+	}
+	if len(descs) == 0 {
+		return util.HandleEmptyTableError("blockdevices", "", "")
+	}
+	var rows []metav1.TableRow
+	for _, d := range descs {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{d.Name, d.Node, d.Path, d.Size, d.ClaimState, d.Status, d.FSType, d.MountPoint}})
+	}
+	util.TablePrinter(util.BlockDeviceListColumnDefinitions, rows, printers.PrintOptions{})
+	return nil
+}
+
+// GetBlockDeviceDescs returns the details of the BlockDevices sorted by their
+// node & name, all the BlockDevices matching the selectors are returned if
+// bds is empty
+func GetBlockDeviceDescs(k *client.K8sClient, bds []string, labelSelector, fieldSelector string) ([]util.BlockDeviceDesc, error) {
+	list, err := k.GetBlockDevices(bds, labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	descs := make([]util.BlockDeviceDesc, 0, len(list))
+	for _, bd := range list {
+		d := util.BlockDeviceDesc{
+			Name:       bd.Name,
+			Namespace:  bd.Namespace,
+			Node:       bd.Spec.NodeAttributes.NodeName,
+			Path:       bd.Spec.Path,
+			Size:       util.ConvertToIBytes(strconv.FormatInt(bd.Spec.Capacity.Storage, 10)),
+			Serial:     orNotAvailable(bd.Spec.Details.Serial),
+			Model:      orNotAvailable(bd.Spec.Details.Model),
+			DeviceType: orNotAvailable(bd.Spec.Details.DeviceType),
+			ClaimState: orNotAvailable(bd.Status.ClaimState),
+			Status:     orNotAvailable(bd.Status.State),
+			FSType:     orNotAvailable(bd.Spec.FileSystem.Type),
+			MountPoint: orNotAvailable(bd.Spec.FileSystem.Mountpoint),
+		}
+		if bd.Spec.ClaimRef != nil && bd.Spec.ClaimRef.Name != "" {
+			d.BlockDeviceClaim = bd.Spec.ClaimRef.Namespace + "/" + bd.Spec.ClaimRef.Name
+		}
+		descs = append(descs, d)
+	}
+	sort.Slice(descs, func(i, j int) bool {
+		if descs[i].Node != descs[j].Node {
+			return descs[i].Node < descs[j].Node
+		}
+		return descs[i].Name < descs[j].Name
+	})
+	return descs, nil
+}
+
+// orNotAvailable returns N/A for the details NDM couldn't discover
+func orNotAvailable(value string) string {
+	if value == "" {
+		return util.NotAvailable
+	}
+	return value
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package blockdevice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+)
+
+func TestGetBlockDeviceDescs(t *testing.T) {
+	claimed := util.BlockDeviceDesc{
+		Name: "blockdevice-b", Namespace: "openebs", Node: "node2", Path: "/dev/sdb", Size: "10.0GiB",
+		Serial: "QM00001", Model: "QEMU HARDDISK", DeviceType: "disk", ClaimState: "Claimed",
+		BlockDeviceClaim: "openebs/bdc-pvc-1", Status: "Active", FSType: "N/A", MountPoint: "N/A",
+	}
+	formatted := util.BlockDeviceDesc{
+		Name: "blockdevice-a", Namespace: "openebs", Node: "node2", Path: "/dev/sdc", Size: "5.0GiB",
+		Serial: "N/A", Model: "N/A", DeviceType: "N/A", ClaimState: "Unclaimed",
+		Status: "Active", FSType: "ext4", MountPoint: "/mnt/disk",
+	}
+	node1 := util.BlockDeviceDesc{
+		Name: "blockdevice-c", Namespace: "openebs", Node: "node1", Path: "/dev/sdb", Size: "10.0GiB",
+		Serial: "N/A", Model: "N/A", DeviceType: "N/A", ClaimState: "Unclaimed",
+		Status: "Inactive", FSType: "N/A", MountPoint: "N/A",
+	}
+	tests := []struct {
+		name    string
+		c       *client.K8sClient
+		bds     []string
+		want    []util.BlockDeviceDesc
+		wantErr bool
+	}{
+		{
+			name: "all blockdevices sorted by node & name",
+			c:    &client.K8sClient{DynCS: newNDMClient(claimedBD, formattedBD, node1BD)},
+			want: []util.BlockDeviceDesc{node1, formatted, claimed},
+		},
+		{
+			name: "blockdevices by name",
+			c:    &client.K8sClient{DynCS: newNDMClient(claimedBD, formattedBD, node1BD)},
+			bds:  []string{"blockdevice-b", "blockdevice-x"},
+			want: []util.BlockDeviceDesc{claimed},
+		},
+		{
+			name: "no blockdevices present",
+			c:    &client.K8sClient{DynCS: newNDMClient()},
+			want: []util.BlockDeviceDesc{},
+		},
+		{
+			name:    "dynamic client not configured",
+			c:       &client.K8sClient{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetBlockDeviceDescs(tt.c, tt.bds, "", "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBlockDeviceDescs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBlockDeviceDescs() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package blockdevice
+
+import (
+	"github.com/openebs/openebsctl/pkg/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newNDMClient returns a fake dynamic client serving the NDM resources
+func newNDMClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		client.BlockDeviceGVR:      "BlockDeviceList",
+		client.BlockDeviceClaimGVR: "BlockDeviceClaimList",
+	}, objects...)
+}
+
+var (
+	claimedBD = &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "openebs.io/v1alpha1",
+		"kind":       "BlockDevice",
+		"metadata":   map[string]interface{}{"name": "blockdevice-b", "namespace": "openebs"},
+		"spec": map[string]interface{}{
+			"path":           "/dev/sdb",
+			"capacity":       map[string]interface{}{"storage": int64(10737418240)},
+			"details":        map[string]interface{}{"serial": "QM00001", "model": "QEMU HARDDISK", "deviceType": "disk"},
+			"nodeAttributes": map[string]interface{}{"nodeName": "node2"},
+			"claimRef":       map[string]interface{}{"kind": "BlockDeviceClaim", "name": "bdc-pvc-1", "namespace": "openebs"},
+		},
+		"status": map[string]interface{}{"claimState": "Claimed", "state": "Active"},
+	}}
+	formattedBD = &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "openebs.io/v1alpha1",
+		"kind":       "BlockDevice",
+		"metadata":   map[string]interface{}{"name": "blockdevice-a", "namespace": "openebs"},
+		"spec": map[string]interface{}{
+			"path":           "/dev/sdc",
+			"capacity":       map[string]interface{}{"storage": int64(5368709120)},
+			"nodeAttributes": map[string]interface{}{"nodeName": "node2"},
+			"filesystem":     map[string]interface{}{"fsType": "ext4", "mountPoint": "/mnt/disk"},
+		},
+		"status": map[string]interface{}{"claimState": "Unclaimed", "state": "Active"},
+	}}
+	node1BD = &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "openebs.io/v1alpha1",
+		"kind":       "BlockDevice",
+		"metadata":   map[string]interface{}{"name": "blockdevice-c", "namespace": "openebs"},
+		"spec": map[string]interface{}{
+			"path":           "/dev/sdb",
+			"capacity":       map[string]interface{}{"storage": int64(10737418240)},
+			"nodeAttributes": map[string]interface{}{"nodeName": "node1"},
+		},
+		"status": map[string]interface{}{"claimState": "Unclaimed", "state": "Inactive"},
+	}}
+)
@@ -0,0 +1,256 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// capacityListKind is the kind of the machine-readable capacity report
+	capacityListKind = "CapacityList"
+
+	firstElemPrefix = `├─`
+	lastElemPrefix  = `└─`
+
+	// HighUsageFlag is set once the used percentage crosses its threshold
+	HighUsageFlag = "HighUsage"
+	// OvercommittedFlag is set once the overcommit percentage crosses its threshold
+	OvercommittedFlag = "Overcommitted"
+)
+
+// Thresholds are the percentages above which a node, pool or volume group
+// is flagged
+type Thresholds struct {
+	// Used is the threshold of the used percentage
+	Used float64
+	// Overcommit is the threshold of the provisioned space as a percentage
+	// of the total space
+	Overcommit float64
+}
+
+// DefaultThresholds are the thresholds used unless others are passed
+var DefaultThresholds = Thresholds{Used: 80, Overcommit: 100}
+
+// Usage is the space of a pool or a volume group & the space provisioned
+// for the volumes on it
+type Usage struct {
+	total       resource.Quantity
+	free        resource.Quantity
+	provisioned resource.Quantity
+}
+
+// add adds the space of the other usage, the usage of a node is the sum of
+// the usages of its pools
+func (u *Usage) add(other Usage) {
+	u.total.Add(other.total)
+	u.free.Add(other.free)
+	u.provisioned.Add(other.provisioned)
+}
+
+// PoolUsage is the usage of a ZFS pool or a LVM volume group
+type PoolUsage struct {
+	name string
+	Usage
+}
+
+// NodeUsage is the usage of the pools or volume groups of a node
+type NodeUsage struct {
+	node    string
+	casType string
+	pools   []PoolUsage
+}
+
+// Node returns the name of the node
+func (n NodeUsage) Node() string {
+	return n.node
+}
+
+// CasType returns the cas-type of the pools of the node
+func (n NodeUsage) CasType() string {
+	return n.casType
+}
+
+// Pools returns the usages of the pools or volume groups of the node
+func (n NodeUsage) Pools() []PoolUsage {
+	return n.pools
+}
+
+// Name returns the name of the pool or volume group
+func (p PoolUsage) Name() string {
+	return p.name
+}
+
+// Total returns the total space of the pool or volume group in bytes
+func (u Usage) Total() int64 {
+	return u.total.Value()
+}
+
+// Free returns the free space of the pool or volume group in bytes
+func (u Usage) Free() int64 {
+	return u.free.Value()
+}
+
+// Get shows the capacity report of the nodes & their pools or volume groups
+// of one or all cas-types, only the passed nodes are reported if any
+func Get(nodes []string, casType, output string, thresholds Thresholds) error {
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	report, err := Report(k, nodes, casType, thresholds)
+	if err != nil {
+		return err
+	}
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, 0, len(report))
+		for _, c := range report {
+			items = append(items, c)
+		}
+		return util.PrintList(output, capacityListKind, items)
+	}
+	if len(report) == 0 {
+		return fmt.Errorf("no zfs pools or lvm volume groups found")
+	}
+	util.TablePrinter(util.CapacityListColumnDefinitions, capacityRows(report), printers.PrintOptions{Wide: true})
+	return nil
+}
+
+// Report returns the capacity report of the nodes of one or all cas-types
+func Report(k *client.K8sClient, nodes []string, casType string, thresholds Thresholds) ([]util.NodeCapacity, error) {
+	var usages []NodeUsage
+	if f, ok := CasListMap()[casType]; ok {
+		found, err := f(k, nodes)
+		if err != nil {
+			return nil, err
+		}
+		usages = found
+	} else if casType != "" {
+		return nil, fmt.Errorf("cas-type %s is not supported", casType)
+	} else {
+		// the errors are ignored as all the engines may not be installed
+		for _, f := range CasList() {
+			if found, err := f(k, nodes); err == nil {
+				usages = append(usages, found...)
+			}
+		}
+	}
+	var report []util.NodeCapacity
+	for _, n := range usages {
+		var total Usage
+		pools := make([]util.PoolCapacity, 0, len(n.pools))
+		for _, p := range n.pools {
+			total.add(p.Usage)
+			pools = append(pools, util.PoolCapacity{Name: p.name, CapacityUsage: p.report(thresholds)})
+		}
+		report = append(report, util.NodeCapacity{
+			Node:          n.node,
+			CasType:       n.casType,
+			CapacityUsage: total.report(thresholds),
+			Pools:         pools,
+		})
+	}
+	return report, nil
+}
+
+// report returns the human readable usage along with the thresholds it has
+// crossed
+func (u Usage) report(thresholds Thresholds) util.CapacityUsage {
+	total := float64(u.total.Value())
+	var used, overcommit float64
+	if total > 0 {
+		used = float64(u.total.Value()-u.free.Value()) / total * 100
+		overcommit = float64(u.provisioned.Value()) / total * 100
+	}
+	headroom := u.total.DeepCopy()
+	headroom.Sub(u.provisioned)
+	var flags []string
+	if used > thresholds.Used {
+		flags = append(flags, HighUsageFlag)
+	}
+	if overcommit > thresholds.Overcommit {
+		flags = append(flags, OvercommittedFlag)
+	}
+	return util.CapacityUsage{
+		Total:          util.ConvertToIBytes(u.total.String()),
+		Free:           util.ConvertToIBytes(u.free.String()),
+		UsedPercentage: fmt.Sprintf("%0.1f%%", used),
+		Provisioned:    util.ConvertToIBytes(u.provisioned.String()),
+		Overcommit:     fmt.Sprintf("%0.1f%%", overcommit),
+		Headroom:       signedIBytes(headroom),
+		Flags:          flags,
+	}
+}
+
+// signedIBytes returns the quantity in the binary units, with a minus sign if
+// it is negative
+func signedIBytes(q resource.Quantity) string {
+	if q.Sign() >= 0 {
+		return util.ConvertToIBytes(q.String())
+	}
+	q.Neg()
+	return "-" + util.ConvertToIBytes(q.String())
+}
+
+// capacityRows returns the table rows of the nodes & of their pools or
+// volume groups as a tree
+func capacityRows(report []util.NodeCapacity) []metav1.TableRow {
+	var rows []metav1.TableRow
+	for _, n := range report {
+		rows = append(rows, metav1.TableRow{Cells: usageCells(n.Node, n.CasType, n.CapacityUsage)})
+		for i, p := range n.Pools {
+			prefix := firstElemPrefix
+			if i == len(n.Pools)-1 {
+				prefix = lastElemPrefix
+			}
+			rows = append(rows, metav1.TableRow{Cells: usageCells(prefix+p.Name, "", p.CapacityUsage)})
+		}
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{"", "", "", "", "", "", "", "", ""}})
+	}
+	return rows
+}
+
+// usageCells returns the cells of a row of the capacity report
+func usageCells(name, casType string, u util.CapacityUsage) []interface{} {
+	flags := strings.Join(u.Flags, ",")
+	if flags == "" {
+		flags = "OK"
+	}
+	return []interface{}{name, casType, u.Total, u.Free, u.UsedPercentage, u.Provisioned, u.Overcommit, u.Headroom, flags}
+}
+
+// CasList returns a list of functions by cas-types for the capacity report
+func CasList() []func(*client.K8sClient, []string) ([]NodeUsage, error) {
+	// a good hack to implement immutable lists in Golang & also write tests for it
+	return []func(*client.K8sClient, []string) ([]NodeUsage, error){GetZFSCapacity, GetLVMCapacity}
+}
+
+// CasListMap returns a map cas-types to functions for the capacity report
+func CasListMap() map[string]func(*client.K8sClient, []string) ([]NodeUsage, error) {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient, []string) ([]NodeUsage, error){
+		util.ZFSCasType: GetZFSCapacity,
+		util.LVMCasType: GetLVMCapacity,
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"reflect"
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+)
+
+func TestCasList(t *testing.T) {
+	if len(CasList()) != len(CasListMap()) {
+		t.Errorf("CasList() & CasListMap() have %d & %d cas-types", len(CasList()), len(CasListMap()))
+	}
+}
+
+func TestGetCapacity(t *testing.T) {
+	zfsPool := util.CapacityUsage{
+		Total:          "10.0GiB",
+		Free:           "6.0GiB",
+		UsedPercentage: "40.0%",
+		Provisioned:    "14.0GiB",
+		Overcommit:     "140.0%",
+		Headroom:       "-4.0GiB",
+		Flags:          []string{OvercommittedFlag},
+	}
+	zfsWant := util.NodeCapacity{
+		Node:          "node1",
+		CasType:       util.ZFSCasType,
+		CapacityUsage: zfsPool,
+		Pools:         []util.PoolCapacity{{Name: "zfspv", CapacityUsage: zfsPool}},
+	}
+	lvmWant := util.NodeCapacity{
+		Node:    "node2",
+		CasType: util.LVMCasType,
+		CapacityUsage: util.CapacityUsage{
+			Total:          "20.0GiB",
+			Free:           "11.0GiB",
+			UsedPercentage: "45.0%",
+			Provisioned:    "8.0GiB",
+			Overcommit:     "40.0%",
+			Headroom:       "12.0GiB",
+		},
+		Pools: []util.PoolCapacity{
+			{Name: "lvmvg", CapacityUsage: util.CapacityUsage{
+				Total:          "10.0GiB",
+				Free:           "1.0GiB",
+				UsedPercentage: "90.0%",
+				Provisioned:    "8.0GiB",
+				Overcommit:     "80.0%",
+				Headroom:       "2.0GiB",
+				Flags:          []string{HighUsageFlag},
+			}},
+			{Name: "lvmvg-2", CapacityUsage: util.CapacityUsage{
+				Total:          "10.0GiB",
+				Free:           "10.0GiB",
+				UsedPercentage: "0.0%",
+				Provisioned:    "0.0B",
+				Overcommit:     "0.0%",
+				Headroom:       "10.0GiB",
+			}},
+		},
+	}
+	thresholds := Thresholds{Used: 80, Overcommit: 100}
+	tests := []struct {
+		name       string
+		nodes      []string
+		casType    string
+		thresholds Thresholds
+		want       []util.NodeCapacity
+		wantErr    bool
+	}{
+		{"all the nodes", nil, "", thresholds, []util.NodeCapacity{zfsWant, lvmWant}, false},
+		{"nodes of a cas-type", nil, util.ZFSCasType, thresholds, []util.NodeCapacity{zfsWant}, false},
+		{"node by name", []string{"node2"}, "", thresholds, []util.NodeCapacity{lvmWant}, false},
+		{"unsupported cas-type", nil, "cstor", thresholds, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				ZFCS:  zfsfake.NewSimpleClientset(&zfsNode1, &zfsThickVol1, &zfsThinVol1),
+				LVMCS: lvmfake.NewSimpleClientset(&lvmNode1, &lvmVol1),
+			}
+			got, err := Report(k, tt.nodes, tt.casType, tt.thresholds)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Report() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Report() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapacityRows(t *testing.T) {
+	report := []util.NodeCapacity{{Node: "node1", Pools: []util.PoolCapacity{{Name: "zfspv"}, {Name: "zfspv-2"}}}}
+	rows := capacityRows(report)
+	if len(rows) != 4 {
+		t.Fatalf("capacityRows() has %d rows, want 4", len(rows))
+	}
+	for _, r := range rows {
+		if len(r.Cells) != len(util.CapacityListColumnDefinitions) {
+			t.Errorf("capacityRows() has %d cells, want %d", len(r.Cells), len(util.CapacityListColumnDefinitions))
+		}
+	}
+	if rows[1].Cells[0] != firstElemPrefix+"zfspv" || rows[2].Cells[0] != lastElemPrefix+"zfspv-2" {
+		t.Errorf("capacityRows() pools = %v, %v", rows[1].Cells[0], rows[2].Cells[0])
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GetLVMCapacity returns the usage of the LVM volume groups of the nodes, the
+// thin provisioned volumes can overcommit a volume group
+func GetLVMCapacity(k *client.K8sClient, nodes []string) ([]NodeUsage, error) {
+	lvmNodes, _, err := k.GetLVMNodes(nodes, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	lvols, _, err := k.GetLVMvol(nil, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// 1. Sum the capacities of the volumes of each volume group of each node
+	provisioned := make(map[string]resource.Quantity)
+	for _, lv := range lvols.Items {
+		capacity, err := resource.ParseQuantity(lv.Spec.Capacity)
+		if err != nil {
+			continue
+		}
+		key := lv.Spec.OwnerNodeID + "/" + lv.Spec.VolGroup
+		p := provisioned[key]
+		p.Add(capacity)
+		provisioned[key] = p
+	}
+	// 2. Add them to the volume groups of the LVMNodes
+	var usages []NodeUsage
+	for _, ln := range lvmNodes.Items {
+		n := NodeUsage{node: ln.Name, casType: util.LVMCasType}
+		for _, vg := range ln.VolumeGroups {
+			n.pools = append(n.pools, PoolUsage{
+				name:  vg.Name,
+				Usage: Usage{total: vg.Size, free: vg.Free, provisioned: provisioned[ln.Name+"/"+vg.Name]},
+			})
+		}
+		usages = append(usages, n)
+	}
+	return usages, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var zfsNode1 = zfs.ZFSNode{
+	ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+	Pools:      []zfs.Pool{{Name: "zfspv", Free: resource.MustParse("6Gi")}},
+}
+
+var zfsThickVol1 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "openebs"},
+	Spec: zfs.VolumeInfo{
+		OwnerNodeID: "node1",
+		PoolName:    "zfspv",
+		Capacity:    "4294967296",
+	},
+}
+
+var zfsThinVol1 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-2", Namespace: "openebs"},
+	Spec: zfs.VolumeInfo{
+		OwnerNodeID:   "node1",
+		PoolName:      "zfspv",
+		Capacity:      "10737418240",
+		ThinProvision: "yes",
+	},
+}
+
+var lvmNode1 = lvm.LVMNode{
+	ObjectMeta: metav1.ObjectMeta{Name: "node2", Namespace: "openebs"},
+	VolumeGroups: []lvm.VolumeGroup{
+		{Name: "lvmvg", Size: resource.MustParse("10Gi"), Free: resource.MustParse("1Gi")},
+		{Name: "lvmvg-2", Size: resource.MustParse("10Gi"), Free: resource.MustParse("10Gi")},
+	},
+}
+
+var lvmVol1 = lvm.LVMVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-3", Namespace: "openebs"},
+	Spec: lvm.VolumeInfo{
+		OwnerNodeID: "node2",
+		VolGroup:    "lvmvg",
+		Capacity:    "8589934592",
+	},
+}
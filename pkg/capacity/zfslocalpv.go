@@ -0,0 +1,71 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GetZFSCapacity returns the usage of the ZFS pools of the nodes. The ZFSNodes
+// only have the free space of a pool, so its total space is taken as the free
+// space & the space reserved by its thick provisioned volumes.
+func GetZFSCapacity(k *client.K8sClient, nodes []string) ([]NodeUsage, error) {
+	zfsNodes, _, err := k.GetZFSNodes(nodes, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	zvols, _, err := k.GetZFSVols(nil, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// 1. Sum the capacities of the volumes of each pool of each node
+	provisioned := make(map[string]resource.Quantity)
+	reserved := make(map[string]resource.Quantity)
+	for _, zv := range zvols.Items {
+		capacity, err := resource.ParseQuantity(zv.Spec.Capacity)
+		if err != nil {
+			continue
+		}
+		key := zv.Spec.OwnerNodeID + "/" + zv.Spec.PoolName
+		p := provisioned[key]
+		p.Add(capacity)
+		provisioned[key] = p
+		if zv.Spec.ThinProvision != "yes" {
+			r := reserved[key]
+			r.Add(capacity)
+			reserved[key] = r
+		}
+	}
+	// 2. Add them to the pools of the ZFSNodes
+	var usages []NodeUsage
+	for _, zn := range zfsNodes.Items {
+		n := NodeUsage{node: zn.Name, casType: util.ZFSCasType}
+		for _, pool := range zn.Pools {
+			key := zn.Name + "/" + pool.Name
+			total := pool.Free.DeepCopy()
+			total.Add(reserved[key])
+			n.pools = append(n.pools, PoolUsage{
+				name:  pool.Name,
+				Usage: Usage{total: total, free: pool.Free, provisioned: provisioned[key]},
+			})
+		}
+		usages = append(usages, n)
+	}
+	return usages, nil
+}
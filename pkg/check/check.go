@@ -0,0 +1,450 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// checkListKind is the kind of the machine-readable checks
+	checkListKind = "CheckList"
+
+	// StatusPass is the status of a check which found no problems
+	StatusPass = "Pass"
+	// StatusWarn is the status of a check which found problems that might go away
+	StatusWarn = "Warn"
+	// StatusFail is the status of a check which found problems
+	StatusFail = "Fail"
+
+	// ExitCodeFail is the exit code when any of the checks failed, it is the
+	// exit code of the errors too
+	ExitCodeFail = 1
+	// ExitCodeWarn is the exit code when any of the checks warned & none failed
+	ExitCodeWarn = 2
+
+	// The names of the checks
+	controlPlaneCheck = "control-plane"
+	csiDriverCheck    = "csi-driver"
+	csiNodePodsCheck  = "csi-node-pods"
+	engineNodesCheck  = "engine-nodes"
+	volumesCheck      = "volumes"
+
+	// volumePendingState is the state of a ZFSVolume or LVMVolume being created
+	volumePendingState = "Pending"
+	// volumeFailedState is the state of a ZFSVolume or LVMVolume which failed to be created
+	volumeFailedState = "Failed"
+)
+
+// statusRank ranks the statuses, the status of all the checks is the worst one
+var statusRank = map[string]int{StatusPass: 0, StatusWarn: 1, StatusFail: 2}
+
+// EngineState is the state of the resources specific to a CSI engine
+type EngineState struct {
+	// nodeKind is the kind of the node resources, i.e. ZFSNode or LVMNode
+	nodeKind string
+	// nodes are the names of the nodes having a ZFSNode or LVMNode
+	nodes map[string]bool
+	// pending & failed are the ZFSVolumes or LVMVolumes in these states
+	pending []string
+	failed  []string
+}
+
+// claims are the PVCs, PVs & pods of the cluster, they are shared by the
+// checks of all the engines
+type claims struct {
+	pvcs []corev1.PersistentVolumeClaim
+	pvs  map[string]corev1.PersistentVolume
+	pods []corev1.Pod
+	// scs are the StorageClasses of the PVCs, nil if not found
+	scs map[string]*storagev1.StorageClass
+}
+
+// Check runs the health checks of one or all the installed engines & prints
+// their results, the returned status is the worst one of the checks
+func Check(casType, output string) (string, error) {
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return "", err
+	}
+	k := client.NewK8sClient()
+	results, err := runChecks(k, casType)
+	if err != nil {
+		return "", err
+	}
+	status := Status(results)
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, 0, len(results))
+		for _, r := range results {
+			items = append(items, r)
+		}
+		return status, util.PrintList(output, checkListKind, items)
+	}
+	util.TablePrinter(util.CheckColumnDefinitions, checkRows(results), printers.PrintOptions{})
+	return status, nil
+}
+
+// Status returns the worst status of the results, Pass if there are none
+func Status(results []util.CheckResult) string {
+	status := StatusPass
+	for _, r := range results {
+		if statusRank[r.Status] > statusRank[status] {
+			status = r.Status
+		}
+	}
+	return status
+}
+
+// ExitCode returns the exit code of the status of the checks
+func ExitCode(status string) int {
+	switch status {
+	case StatusFail:
+		return ExitCodeFail
+	case StatusWarn:
+		return ExitCodeWarn
+	default:
+		return 0
+	}
+}
+
+// runChecks returns the results of the checks of the cas-type, or of all
+// the installed engines if it is empty
+func runChecks(k *client.K8sClient, casType string) ([]util.CheckResult, error) {
+	var casTypes []string
+	if casType != "" {
+		if _, ok := util.CasTypeToComponentNamesMap[casType]; !ok {
+			return nil, fmt.Errorf("cas-type %s is not supported", casType)
+		}
+		casTypes = []string{casType}
+	} else {
+		for c := range util.CasTypeToComponentNamesMap {
+			casTypes = append(casTypes, c)
+		}
+		sort.Strings(casTypes)
+	}
+	c, err := getClaims(k)
+	if err != nil {
+		return nil, err
+	}
+	var results []util.CheckResult
+	for _, ct := range casTypes {
+		// 1. Check the control plane, the engines which aren't installed are
+		// skipped unless asked for
+		result, installed := checkControlPlane(k, ct)
+		if !installed && casType == "" {
+			continue
+		}
+		results = append(results, result)
+		if !installed {
+			continue
+		}
+		// 2. Check the CSI driver, its node pods, its nodes & its volumes
+		var state *EngineState
+		if f, ok := CasListMap()[ct]; ok {
+			driver := util.CasTypeToCSIProvisionerMap[ct]
+			results = append(results, checkCSIDriver(k, ct, driver))
+			nodePods, result := checkCSINodePods(k, ct, driver, c)
+			results = append(results, result)
+			state, err = f(k)
+			if err != nil {
+				results = append(results, util.CheckResult{Check: engineNodesCheck, CasType: ct, Status: StatusFail,
+					Message: fmt.Sprintf("failed to get the resources of the engine: %v", err)})
+				continue
+			}
+			results = append(results, checkEngineNodes(ct, state, nodePods))
+		}
+		results = append(results, checkVolumes(ct, provisionerOf(ct), state, c))
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("none of the OpenEBS storage engines are installed in this cluster")
+	}
+	return results, nil
+}
+
+// getClaims returns the PVCs, PVs & pods of the cluster along with the
+// StorageClasses of the PVCs
+func getClaims(k *client.K8sClient) (*claims, error) {
+	pvcs, err := k.GetPVCs("", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	pvs, err := k.GetPVs(nil, "", "")
+	if err != nil {
+		return nil, err
+	}
+	pods, err := k.GetAllPods("")
+	if err != nil {
+		return nil, err
+	}
+	c := &claims{
+		pvcs: pvcs.Items,
+		pvs:  make(map[string]corev1.PersistentVolume),
+		pods: pods.Items,
+		scs:  make(map[string]*storagev1.StorageClass),
+	}
+	for _, pv := range pvs.Items {
+		c.pvs[pv.Name] = pv
+	}
+	for _, pvc := range pvcs.Items {
+		if name := pvc.Spec.StorageClassName; name != nil {
+			if _, ok := c.scs[*name]; !ok {
+				// the PVCs without a StorageClass are not checked
+				c.scs[*name], _ = k.GetSC(*name)
+			}
+		}
+	}
+	return c, nil
+}
+
+// checkControlPlane checks that the pods of all the components of the engine
+// are ready, the engine is not installed if it has no component pods
+func checkControlPlane(k *client.K8sClient, casType string) (util.CheckResult, bool) {
+	result := util.CheckResult{Check: controlPlaneCheck, CasType: casType, Status: StatusPass}
+	componentNames := util.CasTypeToComponentNamesMap[casType]
+	labelKey := util.ComponentLabelKey(casType)
+	pods, err := k.GetPods(fmt.Sprintf("%s in (%s)", labelKey, componentNames), "", "")
+	if err != nil {
+		result.Status, result.Message = StatusFail, fmt.Sprintf("failed to get the component pods: %v", err)
+		return result, false
+	}
+	if len(pods.Items) == 0 {
+		result.Status, result.Message = StatusFail, "the components of the engine are not installed"
+		return result, false
+	}
+	ready := make(map[string]int)
+	total := make(map[string]int)
+	for _, pod := range pods.Items {
+		component := pod.Labels[labelKey]
+		total[component]++
+		if util.IsPodReady(pod) {
+			ready[component]++
+		}
+	}
+	var problems []string
+	for _, component := range strings.Split(componentNames, ",") {
+		switch {
+		case total[component] == 0:
+			result.Status = StatusFail
+			problems = append(problems, component+" is not installed")
+		case ready[component] == 0:
+			result.Status = StatusFail
+			problems = append(problems, fmt.Sprintf("%s has no ready pods, 0/%d", component, total[component]))
+		case ready[component] < total[component]:
+			result.Status = worse(result.Status, StatusWarn)
+			problems = append(problems, fmt.Sprintf("%s has %d/%d ready pods", component, ready[component], total[component]))
+		}
+	}
+	if len(problems) == 0 {
+		result.Message = fmt.Sprintf("all the %d component pods are ready", len(pods.Items))
+	} else {
+		result.Message = strings.Join(problems, ", ")
+	}
+	return result, true
+}
+
+// checkCSIDriver checks that the CSIDriver object of the engine is registered
+func checkCSIDriver(k *client.K8sClient, casType, driver string) util.CheckResult {
+	result := util.CheckResult{Check: csiDriverCheck, CasType: casType, Status: StatusPass,
+		Message: fmt.Sprintf("csidriver %s is registered", driver)}
+	_, err := k.GetCSIDriver(driver)
+	if k8serrors.IsNotFound(err) {
+		result.Status, result.Message = StatusFail, fmt.Sprintf("csidriver %s is not registered", driver)
+	} else if err != nil {
+		result.Status, result.Message = StatusFail, fmt.Sprintf("failed to get the csidriver %s: %v", driver, err)
+	}
+	return result
+}
+
+// checkCSINodePods checks that every node running pods with volumes of the
+// driver has a ready CSI node pod, the CSI node pods are returned too
+func checkCSINodePods(k *client.K8sClient, casType, driver string, c *claims) ([]corev1.Pod, util.CheckResult) {
+	result := util.CheckResult{Check: csiNodePodsCheck, CasType: casType, Status: StatusPass}
+	component := util.CasTypeToCSINodeComponentMap[casType]
+	pods, err := k.GetPods("openebs.io/component-name="+component, "", "")
+	if err != nil {
+		result.Status, result.Message = StatusFail, fmt.Sprintf("failed to get the %s pods: %v", component, err)
+		return nil, result
+	}
+	readyNodes := make(map[string]bool)
+	for _, pod := range pods.Items {
+		if util.IsPodReady(pod) {
+			readyNodes[pod.Spec.NodeName] = true
+		}
+	}
+	nodes := c.volumeNodes(driver)
+	var missing []string
+	for _, node := range nodes {
+		if !readyNodes[node] {
+			missing = append(missing, node)
+		}
+	}
+	if len(missing) > 0 {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("no ready %s pod on the nodes with volumes: %s", component, strings.Join(missing, ", "))
+	} else {
+		result.Message = fmt.Sprintf("all the %d nodes with volumes have a ready %s pod", len(nodes), component)
+	}
+	return pods.Items, result
+}
+
+// checkEngineNodes checks that every node running a CSI node pod has a
+// ZFSNode or LVMNode
+func checkEngineNodes(casType string, state *EngineState, nodePods []corev1.Pod) util.CheckResult {
+	result := util.CheckResult{Check: engineNodesCheck, CasType: casType, Status: StatusPass}
+	var missing []string
+	for _, pod := range nodePods {
+		if node := pod.Spec.NodeName; node != "" && !state.nodes[node] {
+			missing = append(missing, node)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) > 0 {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("no %s for the nodes: %s", state.nodeKind, strings.Join(missing, ", "))
+	} else {
+		result.Message = fmt.Sprintf("all the %d nodes of the engine have a %s", len(nodePods), state.nodeKind)
+	}
+	return result
+}
+
+// checkVolumes checks that no PVC of the provisioner is Pending, no PV is
+// Failed & no volume of the engine is Pending or Failed. A PVC waiting for
+// its first consumer isn't pending.
+func checkVolumes(casType, provisioner string, state *EngineState, c *claims) util.CheckResult {
+	result := util.CheckResult{Check: volumesCheck, CasType: casType, Status: StatusPass}
+	var pending, failed []string
+	for _, pvc := range c.pvcs {
+		if pvc.Status.Phase != corev1.ClaimPending || pvc.Spec.StorageClassName == nil {
+			continue
+		}
+		sc := c.scs[*pvc.Spec.StorageClassName]
+		if sc == nil || sc.Provisioner != provisioner {
+			continue
+		}
+		if sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer &&
+			pvc.Annotations[util.SelectedNodeKey] == "" {
+			continue
+		}
+		pending = append(pending, "pvc/"+pvc.Namespace+"/"+pvc.Name)
+	}
+	for _, pv := range c.pvs {
+		if pv.Status.Phase == corev1.VolumeFailed && provisionerOfPV(pv) == provisioner {
+			failed = append(failed, "pv/"+pv.Name)
+		}
+	}
+	if state != nil {
+		pending = append(pending, state.pending...)
+		failed = append(failed, state.failed...)
+	}
+	sort.Strings(pending)
+	sort.Strings(failed)
+	var problems []string
+	if len(failed) > 0 {
+		result.Status = StatusFail
+		problems = append(problems, "failed: "+strings.Join(failed, ", "))
+	}
+	if len(pending) > 0 {
+		result.Status = worse(result.Status, StatusWarn)
+		problems = append(problems, "pending: "+strings.Join(pending, ", "))
+	}
+	if len(problems) == 0 {
+		result.Message = "no volumes are pending or failed"
+	} else {
+		result.Message = strings.Join(problems, "; ")
+	}
+	return result
+}
+
+// volumeNodes returns the sorted nodes of the pods using the PVCs whose PVs
+// are of the CSI driver
+func (c *claims) volumeNodes(driver string) []string {
+	volumes := make(map[string]bool)
+	for _, pvc := range c.pvcs {
+		if pv, ok := c.pvs[pvc.Spec.VolumeName]; ok && pv.Spec.CSI != nil && pv.Spec.CSI.Driver == driver {
+			volumes[pvc.Namespace+"/"+pvc.Name] = true
+		}
+	}
+	found := make(map[string]bool)
+	var nodes []string
+	for _, pod := range c.pods {
+		if pod.Spec.NodeName == "" || found[pod.Spec.NodeName] {
+			continue
+		}
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim != nil && volumes[pod.Namespace+"/"+vol.PersistentVolumeClaim.ClaimName] {
+				found[pod.Spec.NodeName] = true
+				nodes = append(nodes, pod.Spec.NodeName)
+				break
+			}
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// provisionerOf returns the provisioner of the StorageClasses of the engine
+func provisionerOf(casType string) string {
+	if casType == util.LocalPvHostpathCasType {
+		return util.LocalPVHostpathProvisioner
+	}
+	return util.CasTypeToCSIProvisionerMap[casType]
+}
+
+// provisionerOfPV returns the CSI driver of the PV, or the provisioner which
+// created it for the non-CSI ones
+func provisionerOfPV(pv corev1.PersistentVolume) string {
+	if pv.Spec.CSI != nil {
+		return pv.Spec.CSI.Driver
+	}
+	return pv.Annotations["pv.kubernetes.io/provisioned-by"]
+}
+
+// worse returns the worse one of the statuses
+func worse(a, b string) string {
+	if statusRank[b] > statusRank[a] {
+		return b
+	}
+	return a
+}
+
+// checkRows returns the table rows of the results with colored statuses
+func checkRows(results []util.CheckResult) []metav1.TableRow {
+	colors := map[string]util.Color{StatusPass: util.Green, StatusWarn: util.Orange, StatusFail: util.Red}
+	var rows []metav1.TableRow
+	for _, r := range results {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{r.Check, r.CasType, util.ColorText(r.Status, colors[r.Status]), r.Message}})
+	}
+	return rows
+}
+
+// CasListMap returns a map cas-types to functions for the health checks
+func CasListMap() map[string]func(*client.K8sClient) (*EngineState, error) {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient) (*EngineState, error){
+		util.ZFSCasType: GetZFSState,
+		util.LVMCasType: GetLVMState,
+	}
+}
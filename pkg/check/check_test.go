@@ -0,0 +1,183 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRunChecks(t *testing.T) {
+	healthy := []runtime.Object{
+		newPod("zfs-controller-0", util.ZFSLocalPVcsiControllerLabelValue, "node2", true),
+		newPod("zfs-node-1", util.ZFSLocalPVcsiNodeLabelValue, "node1", true),
+		&zfsCSIDriver,
+		newSC("zfs-sc", util.ZFSCSIDriver, &waitForFirstConsumer),
+		newPVC("zfs-bound", "zfs-sc", "pvc-zfs", corev1.ClaimBound),
+		newPV("pvc-zfs", util.ZFSCSIDriver, corev1.VolumeBound),
+		newAppPod("app-1", "node1", "zfs-bound"),
+	}
+	passed := []util.CheckResult{
+		{Check: controlPlaneCheck, CasType: util.ZFSCasType, Status: StatusPass, Message: "all the 2 component pods are ready"},
+		{Check: csiDriverCheck, CasType: util.ZFSCasType, Status: StatusPass, Message: "csidriver zfs.csi.openebs.io is registered"},
+		{Check: csiNodePodsCheck, CasType: util.ZFSCasType, Status: StatusPass, Message: "all the 1 nodes with volumes have a ready openebs-zfs-node pod"},
+		{Check: engineNodesCheck, CasType: util.ZFSCasType, Status: StatusPass, Message: "all the 1 nodes of the engine have a ZFSNode"},
+		{Check: volumesCheck, CasType: util.ZFSCasType, Status: StatusPass, Message: "no volumes are pending or failed"},
+	}
+	// withResult returns the passed results with the result of its check replaced
+	withResult := func(r util.CheckResult) []util.CheckResult {
+		results := append([]util.CheckResult{}, passed...)
+		for i := range results {
+			if results[i].Check == r.Check {
+				results[i] = r
+			}
+		}
+		return results
+	}
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		zfsObjects []runtime.Object
+		casType    string
+		want       []util.CheckResult
+		wantStatus string
+		wantErr    bool
+	}{
+		{
+			"healthy zfs engine",
+			healthy,
+			[]runtime.Object{&zfsNode1, &zfsVol1},
+			"",
+			passed,
+			StatusPass,
+			false,
+		},
+		{
+			"no engines installed",
+			nil,
+			nil,
+			"",
+			nil,
+			"",
+			true,
+		},
+		{
+			"engine asked for isn't installed",
+			nil,
+			nil,
+			util.LVMCasType,
+			[]util.CheckResult{{Check: controlPlaneCheck, CasType: util.LVMCasType, Status: StatusFail, Message: "the components of the engine are not installed"}},
+			StatusFail,
+			false,
+		},
+		{
+			"unsupported cas-type",
+			healthy,
+			nil,
+			"cstor",
+			nil,
+			"",
+			true,
+		},
+		{
+			"csi driver not registered & node pod not ready",
+			[]runtime.Object{
+				newPod("zfs-controller-0", util.ZFSLocalPVcsiControllerLabelValue, "node2", true),
+				newPod("zfs-node-1", util.ZFSLocalPVcsiNodeLabelValue, "node1", false),
+				newPod("zfs-node-2", util.ZFSLocalPVcsiNodeLabelValue, "node2", true),
+				newSC("zfs-sc", util.ZFSCSIDriver, &waitForFirstConsumer),
+				newPVC("zfs-bound", "zfs-sc", "pvc-zfs", corev1.ClaimBound),
+				newPV("pvc-zfs", util.ZFSCSIDriver, corev1.VolumeBound),
+				newAppPod("app-1", "node1", "zfs-bound"),
+			},
+			[]runtime.Object{&zfsNode1, &zfsVol1},
+			util.ZFSCasType,
+			[]util.CheckResult{
+				{Check: controlPlaneCheck, CasType: util.ZFSCasType, Status: StatusWarn, Message: "openebs-zfs-node has 1/2 ready pods"},
+				{Check: csiDriverCheck, CasType: util.ZFSCasType, Status: StatusFail, Message: "csidriver zfs.csi.openebs.io is not registered"},
+				{Check: csiNodePodsCheck, CasType: util.ZFSCasType, Status: StatusFail, Message: "no ready openebs-zfs-node pod on the nodes with volumes: node1"},
+				{Check: engineNodesCheck, CasType: util.ZFSCasType, Status: StatusFail, Message: "no ZFSNode for the nodes: node2"},
+				{Check: volumesCheck, CasType: util.ZFSCasType, Status: StatusPass, Message: "no volumes are pending or failed"},
+			},
+			StatusFail,
+			false,
+		},
+		{
+			"pending pvc & failed volume",
+			append([]runtime.Object{
+				newSC("zfs-immediate", util.ZFSCSIDriver, &immediate),
+				newPVC("zfs-pending", "zfs-immediate", "", corev1.ClaimPending),
+				newPVC("zfs-waiting", "zfs-sc", "", corev1.ClaimPending),
+			}, healthy...),
+			[]runtime.Object{&zfsNode1, &zfsVol1, &failedZFSVol1},
+			"",
+			withResult(util.CheckResult{Check: volumesCheck, CasType: util.ZFSCasType, Status: StatusFail,
+				Message: "failed: zfsvolume/pvc-failed; pending: pvc/default/zfs-pending"}),
+			StatusFail,
+			false,
+		},
+		{
+			"pending pvc only warns",
+			append([]runtime.Object{
+				newSC("zfs-immediate", util.ZFSCSIDriver, &immediate),
+				newPVC("zfs-pending", "zfs-immediate", "", corev1.ClaimPending),
+			}, healthy...),
+			[]runtime.Object{&zfsNode1, &zfsVol1},
+			"",
+			withResult(util.CheckResult{Check: volumesCheck, CasType: util.ZFSCasType, Status: StatusWarn,
+				Message: "pending: pvc/default/zfs-pending"}),
+			StatusWarn,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				K8sCS: k8sfake.NewSimpleClientset(tt.objects...),
+				ZFCS:  zfsfake.NewSimpleClientset(tt.zfsObjects...),
+				LVMCS: lvmfake.NewSimpleClientset(),
+			}
+			got, err := runChecks(k, tt.casType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runChecks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("runChecks() = %+v, want %+v", got, tt.want)
+			}
+			if status := Status(got); !tt.wantErr && status != tt.wantStatus {
+				t.Errorf("Status() = %s, want %s", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := map[string]int{StatusPass: 0, StatusWarn: ExitCodeWarn, StatusFail: ExitCodeFail}
+	for status, want := range tests {
+		if got := ExitCode(status); got != want {
+			t.Errorf("ExitCode(%s) = %d, want %d", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+)
+
+// GetLVMState returns the nodes having a LVMNode & the LVMVolumes which are
+// Pending or Failed
+func GetLVMState(k *client.K8sClient) (*EngineState, error) {
+	lvmNodes, _, err := k.GetLVMNodes(nil, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	lvols, _, err := k.GetLVMvol(nil, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	state := &EngineState{nodeKind: "LVMNode", nodes: make(map[string]bool)}
+	for _, ln := range lvmNodes.Items {
+		state.nodes[ln.Name] = true
+	}
+	for _, lv := range lvols.Items {
+		switch lv.Status.State {
+		case volumePendingState:
+			state.pending = append(state.pending, "lvmvolume/"+lv.Name)
+		case volumeFailedState:
+			state.failed = append(state.failed, "lvmvolume/"+lv.Name)
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"github.com/openebs/openebsctl/pkg/util"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	waitForFirstConsumer = storagev1.VolumeBindingWaitForFirstConsumer
+	immediate            = storagev1.VolumeBindingImmediate
+)
+
+func newPod(name, component, node string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openebs", Labels: map[string]string{"openebs.io/component-name": component}},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+// newAppPod returns a pod of an application on the node using the PVC
+func newAppPod(name, node, pvc string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc},
+			}}},
+		},
+	}
+}
+
+func newSC(name, provisioner string, mode *storagev1.VolumeBindingMode) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: name},
+		Provisioner:       provisioner,
+		VolumeBindingMode: mode,
+	}
+}
+
+func newPVC(name, class, volume string, phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &class, VolumeName: volume},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func newPV(name, driver string, phase corev1.PersistentVolumePhase) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+			CSI: &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: name},
+		}},
+		Status: corev1.PersistentVolumeStatus{Phase: phase},
+	}
+}
+
+var zfsCSIDriver = storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: util.ZFSCSIDriver}}
+
+var zfsNode1 = zfs.ZFSNode{
+	ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+}
+
+var zfsVol1 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-zfs", Namespace: "openebs"},
+	Spec:       zfs.VolumeInfo{OwnerNodeID: "node1", PoolName: "zfspv", Capacity: "4294967296"},
+	Status:     zfs.VolStatus{State: "Ready"},
+}
+
+var failedZFSVol1 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-failed", Namespace: "openebs"},
+	Spec:       zfs.VolumeInfo{OwnerNodeID: "node1", PoolName: "zfspv", Capacity: "4294967296"},
+	Status:     zfs.VolStatus{State: "Failed"},
+}
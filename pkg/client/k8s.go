@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	snapshotclient "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned"
 	lvmclient "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset"
 	"github.com/openebs/openebsctl/pkg/util"
 	zfsclient "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset"
@@ -33,6 +34,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
@@ -57,6 +60,17 @@ type K8sClient struct {
 	LVMCS lvmclient.Interface
 	// ZFCS is the client for accessing OpenEBS ZFS components
 	ZFCS zfsclient.Interface
+	// SnapCS is the client for accessing the CSI VolumeSnapshots
+	SnapCS snapshotclient.Interface
+	// DynCS is the client for accessing the CRs without a clientset, i.e.
+	// the Mayastor DiskPools
+	DynCS dynamic.Interface
+	// MayastorREST is the client for accessing the Mayastor REST API
+	MayastorREST *MayastorRESTClient
+	// Kubelet is the client for accessing the stats summary of the kubelets
+	Kubelet *KubeletClient
+	// cache serves the reads of the watched resources, see Watch
+	cache *informerCache
 }
 
 /*
@@ -99,11 +113,19 @@ func newK8sClient(ns string) (*K8sClient, error) {
 	}
 	lv, _ := getLVMclient(config)
 	zf, _ := getZFSclient(config)
+	sn, _ := getSnapshotClient(config)
+	dyn, _ := getDynamicClient(config)
+	ms, _ := getMayastorRESTClient(config)
+	kl, _ := getKubeletClient(config)
 	return &K8sClient{
-		Ns:    ns,
-		K8sCS: k8sCS,
-		LVMCS: lv,
-		ZFCS:  zf,
+		Ns:           ns,
+		K8sCS:        k8sCS,
+		LVMCS:        lv,
+		ZFCS:         zf,
+		SnapCS:       sn,
+		DynCS:        dyn,
+		MayastorREST: ms,
+		Kubelet:      kl,
 	}, nil
 }
 
@@ -224,7 +246,7 @@ CORE RESOURCES
 */
 // GetPods returns the corev1 Pods based on the label and field selectors
 func (k K8sClient) GetPods(labelSelector string, fieldSelector string, namespace string) (*corev1.PodList, error) {
-	pods, err := k.K8sCS.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector})
+	pods, err := k.listPods(labelSelector, fieldSelector, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("error getting pods : %v", err)
 	}
@@ -249,12 +271,29 @@ func (k K8sClient) GetSC(scName string) (*v1.StorageClass, error) {
 	return sc, nil
 }
 
+// CreateSC creates the StorageClass
+func (k K8sClient) CreateSC(sc *v1.StorageClass) (*v1.StorageClass, error) {
+	created, err := k.K8sCS.StorageV1().StorageClasses().Create(context.TODO(), sc, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while creating storage class")
+	}
+	return created, nil
+}
+
+// GetCSIDriver returns the CSIDriver object of the driver name
+func (k K8sClient) GetCSIDriver(name string) (*v1.CSIDriver, error) {
+	return k.K8sCS.StorageV1().CSIDrivers().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// GetVolumeAttachments returns the VolumeAttachments of all the CSI drivers
+func (k K8sClient) GetVolumeAttachments() (*v1.VolumeAttachmentList, error) {
+	return k.K8sCS.StorageV1().VolumeAttachments().List(context.TODO(), metav1.ListOptions{})
+}
+
 // GetCSIControllerSTS returns the CSI controller sts with a specific
 // openebs-component-name label key
 func (k K8sClient) GetCSIControllerSTS(name string) (*appsv1.StatefulSet, error) {
-	if sts, err := k.K8sCS.AppsV1().StatefulSets("").List(context.TODO(), metav1.ListOptions{
-		LabelSelector: fmt.Sprintf("openebs.io/component-name=%s", name),
-	}); err == nil && len(sts.Items) == 1 {
+	if sts, err := k.listStatefulSets(fmt.Sprintf("openebs.io/component-name=%s", name)); err == nil && len(sts.Items) == 1 {
 		return &sts.Items[0], nil
 	} else if sts != nil {
 		return nil, fmt.Errorf("got %d statefulsets with the label openebs.io/component-name=%s", len(sts.Items), name)
@@ -272,6 +311,30 @@ func (k K8sClient) GetEvents(fieldSelector string) (*corev1.EventList, error) {
 	return events, nil
 }
 
+// GetObjectEvents returns the events of the objects, an object is matched by
+// its kind & name, and by its namespace if it isn't empty
+func (k K8sClient) GetObjectEvents(objects ...corev1.ObjectReference) ([]corev1.Event, error) {
+	var events []corev1.Event
+	for _, obj := range objects {
+		fieldSelector := fmt.Sprintf("involvedObject.kind=%s,involvedObject.name=%s", obj.Kind, obj.Name)
+		if obj.Namespace != "" {
+			fieldSelector += ",involvedObject.namespace=" + obj.Namespace
+		}
+		list, err := k.GetEvents(fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+		// the field selectors are not supported by all the clients, e.g. the fake ones
+		for _, e := range list.Items {
+			ref := e.InvolvedObject
+			if ref.Kind == obj.Kind && ref.Name == obj.Name && (obj.Namespace == "" || ref.Namespace == obj.Namespace) {
+				events = append(events, e)
+			}
+		}
+	}
+	return events, nil
+}
+
 /*
 	PERSISTENT VOLUMES AND CLAIMS
 */
@@ -285,12 +348,18 @@ func (k K8sClient) GetPV(name string) (*corev1.PersistentVolume, error) {
 	return pv, nil
 }
 
+// DeletePV deletes the PersistentVolume by its name
+func (k K8sClient) DeletePV(name string) error {
+	return k.K8sCS.CoreV1().PersistentVolumes().Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
 // GetPVs returns a list of PersistentVolumes based on the values of volNames slice.
 // volNames slice if is nil or empty, it returns all the PVs in the cluster.
 // volNames slice if is not nil or not empty, it return the PVs whose names are present in the slice.
 // labelselector takes the label(key+value) and makes an api call with this filter applied. Can be empty string if label filtering is not needed.
-func (k K8sClient) GetPVs(volNames []string, labelselector string) (*corev1.PersistentVolumeList, error) {
-	pvs, err := k.K8sCS.CoreV1().PersistentVolumes().List(context.TODO(), metav1.ListOptions{LabelSelector: labelselector})
+// fieldselector works the same way for the fields, i.e. metadata.name=pvc-1. Can be empty string if field filtering is not needed.
+func (k K8sClient) GetPVs(volNames []string, labelselector, fieldselector string) (*corev1.PersistentVolumeList, error) {
+	pvs, err := k.listPVs(labelselector, fieldselector)
 	if err != nil {
 		return nil, err
 	}
@@ -306,7 +375,7 @@ func (k K8sClient) GetPVs(volNames []string, labelselector string) (*corev1.Pers
 		if pool, ok := volMap[name]; ok {
 			list = append(list, pool)
 		} else {
-			fmt.Printf("Error from server (NotFound): PV %s not found\n", name)
+			_, _ = fmt.Fprintf(os.Stderr, "Error from server (NotFound): PV %s not found\n", name)
 		}
 	}
 	return &corev1.PersistentVolumeList{
@@ -360,6 +429,24 @@ func (k K8sClient) GetPVC(name string, namespace string) (*corev1.PersistentVolu
 	return pvc, nil
 }
 
+// CreatePVC creates the PersistentVolumeClaim in its namespace
+func (k K8sClient) CreatePVC(pvc *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	created, err := k.K8sCS.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(context.TODO(), pvc, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while creating persistent volume claim")
+	}
+	return created, nil
+}
+
+// PatchPVC applies the JSON merge patch to the PersistentVolumeClaim
+func (k K8sClient) PatchPVC(name, namespace string, patch []byte) (*corev1.PersistentVolumeClaim, error) {
+	pvc, err := k.K8sCS.CoreV1().PersistentVolumeClaims(namespace).Patch(context.TODO(), name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error while patching persistent volume claim")
+	}
+	return pvc, nil
+}
+
 // GetPVCs returns a list of PersistentVolumeClaims based on the values of pvcNames slice.
 // namespace takes the namespace in which PVCs are present.
 // pvcNames slice if is nil or empty, it returns all the PVCs in the cluster, in the namespace.
@@ -391,14 +478,17 @@ func (k K8sClient) GetPVCs(namespace string, pvcNames []string, labelselector st
 // GetDeploymentList returns the deployment-list with a specific
 // label selector query
 func (k K8sClient) GetDeploymentList(labelSelector string) (*appsv1.DeploymentList, error) {
-	if pv, err := k.K8sCS.AppsV1().Deployments("").List(context.TODO(), metav1.ListOptions{
-		LabelSelector: labelSelector,
-	}); err == nil && len(pv.Items) >= 1 {
+	if pv, err := k.listDeployments(labelSelector); err == nil && len(pv.Items) >= 1 {
 		return pv, nil
 	}
 	return nil, fmt.Errorf("got 0 deployments with label-Selector as %s", labelSelector)
 }
 
+// GetReplicaSet returns the ReplicaSet with the name in the namespace
+func (k K8sClient) GetReplicaSet(name, namespace string) (*appsv1.ReplicaSet, error) {
+	return k.K8sCS.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
 // GetNodes returns a list of nodes with the name of nodes
 func (k K8sClient) GetNodes(nodes []string, label, field string) (*corev1.NodeList, error) {
 	// 1. Get all nodes
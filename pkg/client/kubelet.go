@@ -0,0 +1,137 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// KubeletClient is the client of the stats summary of the kubelets, they are
+// reached through the node proxy of the apiserver
+type KubeletClient struct {
+	// Endpoint is the base URL of the apiserver, if it is empty the host of
+	// the kubeconfig is used
+	Endpoint string
+	// HTTPClient sends the requests, http.DefaultClient is used if it is nil
+	HTTPClient *http.Client
+	// config is the kubeconfig of the apiserver
+	config *rest.Config
+}
+
+// StatsSummary is the stats summary of a kubelet, only the volume stats of
+// the pods are decoded
+type StatsSummary struct {
+	Pods []PodStats `json:"pods"`
+}
+
+// PodStats has the stats of the volumes mounted by a pod
+type PodStats struct {
+	Volumes []VolumeStats `json:"volume,omitempty"`
+}
+
+// VolumeStats is the usage of the filesystem of a pod volume, these are the
+// values exposed as the kubelet_volume_stats_* metrics, PVCRef is only set
+// for the volumes backed by a PVC
+type VolumeStats struct {
+	Name           string  `json:"name"`
+	PVCRef         *PVCRef `json:"pvcRef,omitempty"`
+	UsedBytes      *int64  `json:"usedBytes,omitempty"`
+	AvailableBytes *int64  `json:"availableBytes,omitempty"`
+	CapacityBytes  *int64  `json:"capacityBytes,omitempty"`
+}
+
+// PVCRef is the PVC backing a pod volume
+type PVCRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// getKubeletClient returns the client of the kubelet stats by taking
+// kubeconfig as an argument
+func getKubeletClient(kubeconfig string) (*KubeletClient, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not build config from flags: %v", err)
+	}
+	return &KubeletClient{config: config}, nil
+}
+
+// GetVolumeStats returns the stats of the PVC backed volumes mounted by the
+// pods of the node, a PVC mounted by several pods of the node is only
+// returned once
+func (k K8sClient) GetVolumeStats(node string) ([]VolumeStats, error) {
+	if k.Kubelet == nil {
+		return nil, fmt.Errorf("the kubelet stats client is not configured")
+	}
+	if err := k.Kubelet.resolve(); err != nil {
+		return nil, err
+	}
+	httpClient := k.Kubelet.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	path := "/api/v1/nodes/" + node + "/proxy/stats/summary"
+	resp, err := httpClient.Get(strings.TrimSuffix(k.Kubelet.Endpoint, "/") + path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the kubelet of node %s: %v", node, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("kubelet stats of node %s returned %s: %s", node, resp.Status, strings.TrimSpace(string(body)))
+	}
+	var summary StatsSummary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, fmt.Errorf("invalid kubelet stats of node %s: %v", node, err)
+	}
+	var stats []VolumeStats
+	seen := make(map[PVCRef]bool)
+	for _, pod := range summary.Pods {
+		for _, vol := range pod.Volumes {
+			if vol.PVCRef == nil || seen[*vol.PVCRef] {
+				continue
+			}
+			seen[*vol.PVCRef] = true
+			stats = append(stats, vol)
+		}
+	}
+	return stats, nil
+}
+
+// resolve sets the endpoint & the authenticated http client of the
+// apiserver, unless the endpoint is set
+func (c *KubeletClient) resolve() error {
+	if c.Endpoint != "" {
+		return nil
+	}
+	if c.config == nil {
+		return fmt.Errorf("the kubelet stats endpoint is not configured")
+	}
+	httpClient, err := rest.HTTPClientFor(c.config)
+	if err != nil {
+		return err
+	}
+	c.Endpoint = c.config.Host
+	c.HTTPClient = httpClient
+	return nil
+}
@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"os"
 
 	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
 	lvmclient "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset"
@@ -46,9 +47,9 @@ func getLVMclient(kubeconfig string) (*lvmclient.Clientset, error) {
 }
 
 // GetLVMvol returns a list or a map of LVMVolume depending upon rType & options
-func (k K8sClient) GetLVMvol(lVols []string, rType util.ReturnType, labelSelector string, options util.MapOptions) (*lvm.LVMVolumeList, map[string]lvm.LVMVolume, error) {
+func (k K8sClient) GetLVMvol(lVols []string, rType util.ReturnType, labelSelector, fieldSelector string, options util.MapOptions) (*lvm.LVMVolumeList, map[string]lvm.LVMVolume, error) {
 	// NOTE: The resource name must be plural and the API-group should be present for getting CRs
-	lvs, err := k.LVMCS.LocalV1alpha1().LVMVolumes("").List(context.TODO(), v1.ListOptions{LabelSelector: labelSelector})
+	lvs, err := k.listLVMVolumes(labelSelector, fieldSelector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -64,7 +65,7 @@ func (k K8sClient) GetLVMvol(lVols []string, rType util.ReturnType, labelSelecto
 			if lv, ok := lvsMap[name]; ok {
 				list = append(list, lv)
 			} else {
-				fmt.Printf("Error from server (NotFound): lvmvolume %s not found\n", name)
+				_, _ = fmt.Fprintf(os.Stderr, "Error from server (NotFound): lvmvolume %s not found\n", name)
 			}
 		}
 	}
@@ -96,8 +97,8 @@ func (k K8sClient) GetLVMvol(lVols []string, rType util.ReturnType, labelSelecto
 }
 
 // GetLVMNodes return a list or map of LVMNodes or an error
-func (k K8sClient) GetLVMNodes(lVols []string, rType util.ReturnType, labelSelector string, options util.MapOptions) (*lvm.LVMNodeList, map[string]lvm.LVMNode, error) {
-	lvs, err := k.LVMCS.LocalV1alpha1().LVMNodes("").List(context.TODO(), v1.ListOptions{})
+func (k K8sClient) GetLVMNodes(lVols []string, rType util.ReturnType, labelSelector, fieldSelector string, options util.MapOptions) (*lvm.LVMNodeList, map[string]lvm.LVMNode, error) {
+	lvs, err := k.listLVMNodes(labelSelector, fieldSelector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -113,7 +114,7 @@ func (k K8sClient) GetLVMNodes(lVols []string, rType util.ReturnType, labelSelec
 			if lv, ok := lvsMap[name]; ok {
 				list = append(list, lv)
 			} else {
-				fmt.Printf("Error from server (NotFound): lvmnode %s not found\n", name)
+				_, _ = fmt.Fprintf(os.Stderr, "Error from server (NotFound): lvmnode %s not found\n", name)
 			}
 		}
 	}
@@ -141,3 +142,32 @@ func (k K8sClient) GetLVMNodes(lVols []string, rType util.ReturnType, labelSelec
 	}
 	return nil, nil, errors.New("invalid return type")
 }
+
+// GetLVMSnapshots returns the LVMSnapshots matching the label selector, if
+// snapNames is not empty only the LVMSnapshots with these names are returned
+func (k K8sClient) GetLVMSnapshots(snapNames []string, labelSelector string) (*lvm.LVMSnapshotList, error) {
+	snaps, err := k.LVMCS.LocalV1alpha1().LVMSnapshots("").List(context.TODO(), v1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	if len(snapNames) == 0 {
+		return snaps, nil
+	}
+	snapMap := make(map[string]lvm.LVMSnapshot)
+	for _, snap := range snaps.Items {
+		snapMap[snap.Name] = snap
+	}
+	var list []lvm.LVMSnapshot
+	for _, name := range snapNames {
+		if snap, ok := snapMap[name]; ok {
+			list = append(list, snap)
+		}
+	}
+	return &lvm.LVMSnapshotList{Items: list}, nil
+}
+
+// DeleteLVMVolume deletes the LVMVolume of the namespace by its name, the
+// LVM-LocalPV node agent removes its logical volume
+func (k K8sClient) DeleteLVMVolume(name, namespace string) error {
+	return k.LVMCS.LocalV1alpha1().LVMVolumes(namespace).Delete(context.TODO(), name, v1.DeleteOptions{})
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// mayastorRequestTimeout is the time a request of the Mayastor REST API has
+// to complete, including the reading of its response
+const mayastorRequestTimeout = 10 * time.Second
+
+// DiskPoolGVRs are the versions of the Mayastor DiskPool CRD from the
+// newest, the first one served by the cluster is used
+var DiskPoolGVRs = []schema.GroupVersionResource{
+	{Group: "openebs.io", Version: "v1beta2", Resource: "diskpools"},
+	{Group: "openebs.io", Version: "v1beta1", Resource: "diskpools"},
+}
+
+// DiskPool is a Mayastor pool of the disks of a node
+type DiskPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DiskPoolSpec   `json:"spec"`
+	Status            DiskPoolStatus `json:"status,omitempty"`
+}
+
+// DiskPoolSpec is the node & the disks of a DiskPool
+type DiskPoolSpec struct {
+	Node  string   `json:"node"`
+	Disks []string `json:"disks"`
+}
+
+// DiskPoolStatus is the state & the space of a DiskPool in bytes
+type DiskPoolStatus struct {
+	CRState    string `json:"cr_state,omitempty"`
+	PoolStatus string `json:"pool_status,omitempty"`
+	Capacity   int64  `json:"capacity,omitempty"`
+	Used       int64  `json:"used,omitempty"`
+	Available  int64  `json:"available,omitempty"`
+}
+
+// MayastorRESTClient is the client of the Mayastor REST API
+type MayastorRESTClient struct {
+	// Endpoint is the base URL of the REST API, if it is empty the api-rest
+	// service is reached through the apiserver proxy
+	Endpoint string
+	// Namespace is the namespace of the api-rest service
+	Namespace string
+	// HTTPClient sends the requests, http.DefaultClient is used if it is nil
+	HTTPClient *http.Client
+	// config is the kubeconfig of the apiserver proxy
+	config *rest.Config
+}
+
+// MayastorVolume is a volume of the Mayastor REST API, its UUID is the CSI
+// volume handle of its PV
+type MayastorVolume struct {
+	Spec  MayastorVolumeSpec  `json:"spec"`
+	State MayastorVolumeState `json:"state"`
+}
+
+// MayastorVolumeSpec is the desired state of a Mayastor volume
+type MayastorVolumeSpec struct {
+	UUID        string `json:"uuid"`
+	Size        int64  `json:"size"`
+	NumReplicas int    `json:"num_replicas"`
+	Status      string `json:"status"`
+	Thin        bool   `json:"thin"`
+}
+
+// MayastorVolumeState is the actual state of a Mayastor volume, Target is
+// the nexus of the volume & is nil while the volume isn't published
+type MayastorVolumeState struct {
+	UUID            string                     `json:"uuid"`
+	Size            int64                      `json:"size"`
+	Status          string                     `json:"status"`
+	Target          *MayastorNexus             `json:"target,omitempty"`
+	ReplicaTopology map[string]MayastorReplica `json:"replica_topology,omitempty"`
+}
+
+// MayastorNexus is the target of a Mayastor volume, it writes to the
+// replicas of the volume which are its children
+type MayastorNexus struct {
+	UUID      string               `json:"uuid"`
+	Node      string               `json:"node"`
+	Protocol  string               `json:"protocol"`
+	DeviceURI string               `json:"deviceUri"`
+	State     string               `json:"state"`
+	Rebuilds  int                  `json:"rebuilds"`
+	Children  []MayastorNexusChild `json:"children"`
+}
+
+// MayastorNexusChild is a replica of a nexus, RebuildProgress is only set
+// while the replica is rebuilt
+type MayastorNexusChild struct {
+	URI             string `json:"uri"`
+	State           string `json:"state"`
+	RebuildProgress *int   `json:"rebuildProgress,omitempty"`
+}
+
+// MayastorReplica is a replica of a Mayastor volume, RebuildProgress is only
+// set while the replica is rebuilt
+type MayastorReplica struct {
+	Node            string `json:"node"`
+	Pool            string `json:"pool"`
+	State           string `json:"state"`
+	ChildStatus     string `json:"child-status,omitempty"`
+	RebuildProgress *int   `json:"rebuild-progress,omitempty"`
+}
+
+// mayastorVolumes is a page of the volumes of the Mayastor REST API
+type mayastorVolumes struct {
+	Entries []MayastorVolume `json:"entries"`
+}
+
+// getDynamicClient returns the dynamic client by taking kubeconfig as an
+// argument
+func getDynamicClient(kubeconfig string) (dynamic.Interface, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not build config from flags: %v", err)
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not get new config: %v", err)
+	}
+	return client, nil
+}
+
+// getMayastorRESTClient returns the client of the Mayastor REST API reaching
+// it through the apiserver proxy by taking kubeconfig as an argument
+func getMayastorRESTClient(kubeconfig string) (*MayastorRESTClient, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not build config from flags: %v", err)
+	}
+	return &MayastorRESTClient{config: config}, nil
+}
+
+// GetDiskPools returns the Mayastor DiskPools matching the selectors, if
+// pools is not empty only the DiskPools with these names are returned
+func (k K8sClient) GetDiskPools(pools []string, labelSelector, fieldSelector string) ([]DiskPool, error) {
+	found, err := k.listDiskPools(labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	var list []DiskPool
+	for _, item := range found {
+		var dsp DiskPool
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &dsp); err != nil {
+			return nil, fmt.Errorf("invalid diskpool: %v", err)
+		}
+		list = append(list, dsp)
+	}
+	if len(pools) == 0 {
+		return list, nil
+	}
+	poolMap := make(map[string]DiskPool)
+	for _, dsp := range list {
+		poolMap[dsp.Name] = dsp
+	}
+	var items []DiskPool
+	for _, name := range pools {
+		if dsp, ok := poolMap[name]; ok {
+			items = append(items, dsp)
+		}
+	}
+	return items, nil
+}
+
+// GetMayastorVolumes returns the volumes of the Mayastor REST API
+func (k K8sClient) GetMayastorVolumes() ([]MayastorVolume, error) {
+	var volumes mayastorVolumes
+	// max_entries=0 returns all the volumes in a single page
+	if err := k.mayastorGet("/v0/volumes?max_entries=0", &volumes); err != nil {
+		return nil, err
+	}
+	return volumes.Entries, nil
+}
+
+// GetMayastorVolume returns the volume of the Mayastor REST API by its UUID
+func (k K8sClient) GetMayastorVolume(uuid string) (*MayastorVolume, error) {
+	var volume MayastorVolume
+	if err := k.mayastorGet("/v0/volumes/"+uuid, &volume); err != nil {
+		return nil, err
+	}
+	return &volume, nil
+}
+
+// GetMayastorNamespace returns the namespace of the Mayastor REST API service
+func (k K8sClient) GetMayastorNamespace() (string, error) {
+	if k.MayastorREST == nil {
+		return "", fmt.Errorf("the mayastor rest api client is not configured")
+	}
+	if err := k.MayastorREST.resolve(k); err != nil {
+		return "", err
+	}
+	return k.MayastorREST.Namespace, nil
+}
+
+// mayastorGet decodes the JSON response of the Mayastor REST API path to out
+func (k K8sClient) mayastorGet(path string, out interface{}) error {
+	if k.MayastorREST == nil {
+		return fmt.Errorf("the mayastor rest api client is not configured")
+	}
+	m := k.MayastorREST
+	if err := m.resolve(k); err != nil {
+		return err
+	}
+	httpClient := m.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	// a wedged api-rest pod mustn't hang the listings of all the engines
+	ctx, cancel := context.WithTimeout(context.TODO(), mayastorRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(m.Endpoint, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach the mayastor rest api: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("mayastor rest api %s returned %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response of the mayastor rest api %s: %v", path, err)
+	}
+	return nil
+}
+
+// resolve sets the endpoint of the api-rest service proxied by the apiserver
+// & its namespace, unless the endpoint is set
+func (m *MayastorRESTClient) resolve(k K8sClient) error {
+	if m.Endpoint != "" {
+		return nil
+	}
+	if m.config == nil {
+		return fmt.Errorf("the mayastor rest api endpoint is not configured")
+	}
+	svcs, err := k.K8sCS.CoreV1().Services("").List(context.TODO(), metav1.ListOptions{
+		LabelSelector: util.MayastorComponentNameKey + "=" + util.MayastorRESTComponent})
+	if err != nil {
+		return err
+	}
+	if len(svcs.Items) == 0 || len(svcs.Items[0].Spec.Ports) == 0 {
+		return fmt.Errorf("the %s service of mayastor is not found", util.MayastorRESTComponent)
+	}
+	svc := svcs.Items[0]
+	httpClient, err := rest.HTTPClientFor(m.config)
+	if err != nil {
+		return err
+	}
+	m.Endpoint = fmt.Sprintf("%s/api/v1/namespaces/%s/services/%s:%d/proxy",
+		strings.TrimSuffix(m.config.Host, "/"), svc.Namespace, svc.Name, svc.Spec.Ports[0].Port)
+	m.Namespace = svc.Namespace
+	m.HTTPClient = httpClient
+	return nil
+}
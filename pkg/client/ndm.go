@@ -0,0 +1,158 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// BlockDeviceGVR is the resource of the NDM BlockDevices
+	BlockDeviceGVR = schema.GroupVersionResource{Group: "openebs.io", Version: "v1alpha1", Resource: "blockdevices"}
+	// BlockDeviceClaimGVR is the resource of the NDM BlockDeviceClaims
+	BlockDeviceClaimGVR = schema.GroupVersionResource{Group: "openebs.io", Version: "v1alpha1", Resource: "blockdeviceclaims"}
+)
+
+// BlockDevice is a disk of a node discovered by NDM
+type BlockDevice struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BlockDeviceSpec   `json:"spec"`
+	Status            BlockDeviceStatus `json:"status,omitempty"`
+}
+
+// BlockDeviceSpec is the path, the size & the details of a BlockDevice,
+// ClaimRef refers to the BlockDeviceClaim of the BlockDevice if it is claimed
+type BlockDeviceSpec struct {
+	Path           string                    `json:"path"`
+	Capacity       BlockDeviceCapacity       `json:"capacity"`
+	Details        BlockDeviceDetails        `json:"details,omitempty"`
+	NodeAttributes BlockDeviceNodeAttributes `json:"nodeAttributes"`
+	ClaimRef       *BlockDeviceClaimRef      `json:"claimRef,omitempty"`
+	FileSystem     BlockDeviceFileSystem     `json:"filesystem,omitempty"`
+}
+
+// BlockDeviceCapacity is the size of a BlockDevice in bytes
+type BlockDeviceCapacity struct {
+	Storage int64 `json:"storage"`
+}
+
+// BlockDeviceDetails are the properties of the disk of a BlockDevice
+type BlockDeviceDetails struct {
+	DeviceType string `json:"deviceType,omitempty"`
+	Model      string `json:"model,omitempty"`
+	Serial     string `json:"serial,omitempty"`
+	Vendor     string `json:"vendor,omitempty"`
+}
+
+// BlockDeviceNodeAttributes has the node of a BlockDevice
+type BlockDeviceNodeAttributes struct {
+	NodeName string `json:"nodeName"`
+}
+
+// BlockDeviceClaimRef refers to the BlockDeviceClaim claiming a BlockDevice
+type BlockDeviceClaimRef struct {
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BlockDeviceFileSystem is the filesystem of a BlockDevice if it is formatted
+type BlockDeviceFileSystem struct {
+	Type       string `json:"fsType,omitempty"`
+	Mountpoint string `json:"mountPoint,omitempty"`
+}
+
+// BlockDeviceStatus has the claim state & the state of a BlockDevice
+type BlockDeviceStatus struct {
+	ClaimState string `json:"claimState,omitempty"`
+	State      string `json:"state,omitempty"`
+}
+
+// BlockDeviceClaim is a claim of a BlockDevice, the localpv-device
+// provisioner creates one named bdc-<pv name> for every volume
+type BlockDeviceClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              BlockDeviceClaimSpec   `json:"spec"`
+	Status            BlockDeviceClaimStatus `json:"status,omitempty"`
+}
+
+// BlockDeviceClaimSpec has the name of the claimed BlockDevice
+type BlockDeviceClaimSpec struct {
+	BlockDeviceName string `json:"blockDeviceName,omitempty"`
+}
+
+// BlockDeviceClaimStatus has the phase of a BlockDeviceClaim
+type BlockDeviceClaimStatus struct {
+	Phase string `json:"phase,omitempty"`
+}
+
+// GetBlockDevices returns the BlockDevices of all the namespaces matching the
+// selectors, if bds is not empty only the BlockDevices with these names are
+// returned
+func (k K8sClient) GetBlockDevices(bds []string, labelSelector, fieldSelector string) ([]BlockDevice, error) {
+	if k.DynCS == nil {
+		return nil, fmt.Errorf("the dynamic client is not configured")
+	}
+	found, err := k.DynCS.Resource(BlockDeviceGVR).Namespace("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]BlockDevice, 0, len(found.Items))
+	for _, item := range found.Items {
+		var bd BlockDevice
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &bd); err != nil {
+			return nil, fmt.Errorf("invalid blockdevice: %v", err)
+		}
+		list = append(list, bd)
+	}
+	if len(bds) == 0 {
+		return list, nil
+	}
+	bdMap := make(map[string]BlockDevice)
+	for _, bd := range list {
+		bdMap[bd.Name] = bd
+	}
+	var items []BlockDevice
+	for _, name := range bds {
+		if bd, ok := bdMap[name]; ok {
+			items = append(items, bd)
+		}
+	}
+	return items, nil
+}
+
+// GetBlockDeviceClaim returns the BlockDeviceClaim by its name & namespace
+func (k K8sClient) GetBlockDeviceClaim(name, namespace string) (*BlockDeviceClaim, error) {
+	if k.DynCS == nil {
+		return nil, fmt.Errorf("the dynamic client is not configured")
+	}
+	item, err := k.DynCS.Resource(BlockDeviceClaimGVR).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var bdc BlockDeviceClaim
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &bdc); err != nil {
+		return nil, fmt.Errorf("invalid blockdeviceclaim: %v", err)
+	}
+	return &bdc, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	snapshotclient "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// getSnapshotClient returns the CSI external-snapshotter clientset by taking
+// kubeconfig as an argument
+func getSnapshotClient(kubeconfig string) (*snapshotclient.Clientset, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not build config from flags: %v", err)
+	}
+	client, err := snapshotclient.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("could not get new config: %v", err)
+	}
+	return client, nil
+}
+
+// GetVolumeSnapshots returns the CSI VolumeSnapshots of all the namespaces
+func (k K8sClient) GetVolumeSnapshots() (*snapshotv1.VolumeSnapshotList, error) {
+	return k.SnapCS.SnapshotV1().VolumeSnapshots("").List(context.TODO(), metav1.ListOptions{})
+}
+
+// GetVolumeSnapshotContents returns the CSI VolumeSnapshotContents
+func (k K8sClient) GetVolumeSnapshotContents() (*snapshotv1.VolumeSnapshotContentList, error) {
+	return k.SnapCS.SnapshotV1().VolumeSnapshotContents().List(context.TODO(), metav1.ListOptions{})
+}
+
+// GetVolumeSnapshot returns the CSI VolumeSnapshot of the namespace by its name
+func (k K8sClient) GetVolumeSnapshot(name, namespace string) (*snapshotv1.VolumeSnapshot, error) {
+	return k.SnapCS.SnapshotV1().VolumeSnapshots(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// CreateVolumeSnapshot creates the CSI VolumeSnapshot in its namespace
+func (k K8sClient) CreateVolumeSnapshot(vs *snapshotv1.VolumeSnapshot) (*snapshotv1.VolumeSnapshot, error) {
+	return k.SnapCS.SnapshotV1().VolumeSnapshots(vs.Namespace).Create(context.TODO(), vs, metav1.CreateOptions{})
+}
+
+// DeleteVolumeSnapshot deletes the CSI VolumeSnapshot of the namespace by its name
+func (k K8sClient) DeleteVolumeSnapshot(name, namespace string) error {
+	return k.SnapCS.SnapshotV1().VolumeSnapshots(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
+// GetVolumeSnapshotContent returns the CSI VolumeSnapshotContent by its name
+func (k K8sClient) GetVolumeSnapshotContent(name string) (*snapshotv1.VolumeSnapshotContent, error) {
+	return k.SnapCS.SnapshotV1().VolumeSnapshotContents().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// GetVolumeSnapshotClasses returns the CSI VolumeSnapshotClasses
+func (k K8sClient) GetVolumeSnapshotClasses() (*snapshotv1.VolumeSnapshotClassList, error) {
+	return k.SnapCS.SnapshotV1().VolumeSnapshotClasses().List(context.TODO(), metav1.ListOptions{})
+}
@@ -0,0 +1,586 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	lvminformers "github.com/openebs/lvm-localpv/pkg/generated/informer/externalversions"
+	lvmlisters "github.com/openebs/lvm-localpv/pkg/generated/lister/lvm/v1alpha1"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	zfsinformers "github.com/openebs/zfs-localpv/pkg/generated/informer/externalversions"
+	zfslisters "github.com/openebs/zfs-localpv/pkg/generated/lister/zfs/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Resource is a kind of resource which can be watched by the K8sClient
+type Resource string
+
+const (
+	// PVResource watches the PersistentVolumes
+	PVResource Resource = "persistentvolumes"
+	// PodResource watches the pods of the OpenEBS components
+	PodResource Resource = "pods"
+	// ControllerResource watches the statefulsets & deployments of the
+	// OpenEBS components, these are used to get the engine versions
+	ControllerResource Resource = "controllers"
+	// ZFSVolumeResource watches the ZFSVolumes
+	ZFSVolumeResource Resource = "zfsvolumes"
+	// ZFSNodeResource watches the ZFSNodes
+	ZFSNodeResource Resource = "zfsnodes"
+	// LVMVolumeResource watches the LVMVolumes
+	LVMVolumeResource Resource = "lvmvolumes"
+	// LVMNodeResource watches the LVMNodes
+	LVMNodeResource Resource = "lvmnodes"
+	// DiskPoolResource watches the Mayastor DiskPools
+	DiskPoolResource Resource = "diskpools"
+)
+
+// componentLabel is present on the OpenEBS component pods, statefulsets &
+// deployments but the Mayastor ones, the component informers only cache the
+// objects having it or the mayastorComponentSelector
+const componentLabel = "openebs.io/component-name"
+
+// mayastorComponentSelector selects the Mayastor component pods, statefulsets
+// & deployments by the component names of their own label
+var mayastorComponentSelector = fmt.Sprintf("%s in (%s)",
+	util.ComponentLabelKey(util.MayastorCasType), util.CasTypeToComponentNamesMap[util.MayastorCasType])
+
+// informerCache holds the listers of the watched resources, the reads of
+// the K8sClient are served from it instead of the api-server
+type informerCache struct {
+	pvs corelisters.PersistentVolumeLister
+	// the component listers are the ones of the componentLabel & of the
+	// mayastorComponentSelector
+	pods         []corelisters.PodLister
+	statefulSets []appslisters.StatefulSetLister
+	deployments  []appslisters.DeploymentLister
+	zfsVolumes   zfslisters.ZFSVolumeLister
+	zfsNodes     zfslisters.ZFSNodeLister
+	lvmVolumes   lvmlisters.LVMVolumeLister
+	lvmNodes     lvmlisters.LVMNodeLister
+	diskPools    cache.GenericLister
+	// errs has the errors of the resources which couldn't be listed when
+	// the watch started, i.e. the CRDs of an engine which isn't installed
+	errs map[Resource]error
+	// watched has the resources whose reads are served from the cache
+	watched map[Resource]bool
+}
+
+// watches returns true if the reads of the resource are served from the cache
+func (c *informerCache) watches(r Resource) bool {
+	return c != nil && c.watched[r]
+}
+
+// Watch starts informers on the resources & serves the reads of the client
+// from their caches once they are synced. A value is sent on the returned
+// channel whenever a watched resource is added, updated or deleted, the
+// events are coalesced until the value is received. The informers are
+// stopped when the stopCh is closed.
+func (k *K8sClient) Watch(stopCh <-chan struct{}, resources ...Resource) (<-chan struct{}, error) {
+	changed := make(chan struct{}, 1)
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { notify(changed) },
+		UpdateFunc: func(interface{}, interface{}) { notify(changed) },
+		DeleteFunc: func(interface{}) { notify(changed) },
+	}
+	c := &informerCache{errs: make(map[Resource]error), watched: make(map[Resource]bool)}
+	coreFactory := informers.NewSharedInformerFactory(k.K8sCS, 0)
+	componentFactories := []informers.SharedInformerFactory{
+		informers.NewSharedInformerFactoryWithOptions(k.K8sCS, 0,
+			informers.WithTweakListOptions(func(o *metav1.ListOptions) { o.LabelSelector = componentLabel })),
+		informers.NewSharedInformerFactoryWithOptions(k.K8sCS, 0,
+			informers.WithTweakListOptions(func(o *metav1.ListOptions) { o.LabelSelector = mayastorComponentSelector })),
+	}
+	zfsFactory := zfsinformers.NewSharedInformerFactory(k.ZFCS, 0)
+	lvmFactory := lvminformers.NewSharedInformerFactory(k.LVMCS, 0)
+	var dynFactory dynamicinformer.DynamicSharedInformerFactory
+	if k.DynCS != nil {
+		dynFactory = dynamicinformer.NewDynamicSharedInformerFactory(k.DynCS, 0)
+	}
+	var synced []cache.InformerSynced
+	for _, r := range resources {
+		// 1. A resource which can't be listed would never sync, i.e. the
+		// CRDs of an engine which isn't installed, its error is returned
+		// by the reads instead
+		if err := k.probe(r); err != nil {
+			c.errs[r] = err
+			c.watched[r] = true
+			continue
+		}
+		// 2. Create the informers of the resource & its listers
+		var infs []cache.SharedIndexInformer
+		switch r {
+		case PVResource:
+			inf := coreFactory.Core().V1().PersistentVolumes()
+			c.pvs = inf.Lister()
+			infs = append(infs, inf.Informer())
+		case PodResource:
+			for _, f := range componentFactories {
+				inf := f.Core().V1().Pods()
+				c.pods = append(c.pods, inf.Lister())
+				infs = append(infs, inf.Informer())
+			}
+		case ControllerResource:
+			for _, f := range componentFactories {
+				sts := f.Apps().V1().StatefulSets()
+				deploy := f.Apps().V1().Deployments()
+				c.statefulSets = append(c.statefulSets, sts.Lister())
+				c.deployments = append(c.deployments, deploy.Lister())
+				infs = append(infs, sts.Informer(), deploy.Informer())
+			}
+		case ZFSVolumeResource:
+			inf := zfsFactory.Zfs().V1().ZFSVolumes()
+			c.zfsVolumes = inf.Lister()
+			infs = append(infs, inf.Informer())
+		case ZFSNodeResource:
+			inf := zfsFactory.Zfs().V1().ZFSNodes()
+			c.zfsNodes = inf.Lister()
+			infs = append(infs, inf.Informer())
+		case LVMVolumeResource:
+			inf := lvmFactory.Local().V1alpha1().LVMVolumes()
+			c.lvmVolumes = inf.Lister()
+			infs = append(infs, inf.Informer())
+		case LVMNodeResource:
+			inf := lvmFactory.Local().V1alpha1().LVMNodes()
+			c.lvmNodes = inf.Lister()
+			infs = append(infs, inf.Informer())
+		case DiskPoolResource:
+			gvr, err := k.diskPoolGVR()
+			if err != nil {
+				return nil, err
+			}
+			inf := dynFactory.ForResource(gvr)
+			c.diskPools = inf.Lister()
+			infs = append(infs, inf.Informer())
+		default:
+			return nil, fmt.Errorf("resource %s can't be watched", r)
+		}
+		for _, inf := range infs {
+			if _, err := inf.AddEventHandler(handler); err != nil {
+				return nil, err
+			}
+			synced = append(synced, inf.HasSynced)
+		}
+		c.watched[r] = true
+	}
+	// 3. Start the informers & wait for the initial listing
+	coreFactory.Start(stopCh)
+	for _, f := range componentFactories {
+		f.Start(stopCh)
+	}
+	zfsFactory.Start(stopCh)
+	lvmFactory.Start(stopCh)
+	if dynFactory != nil {
+		dynFactory.Start(stopCh)
+	}
+	if !cache.WaitForCacheSync(stopCh, synced...) {
+		return nil, fmt.Errorf("failed to sync the caches of %v", resources)
+	}
+	k.cache = c
+	return changed, nil
+}
+
+// notify sends a value on the channel unless one is already pending
+func notify(changed chan struct{}) {
+	select {
+	case changed <- struct{}{}:
+	default:
+	}
+}
+
+// probe lists a single object of the resource to check if it can be watched
+func (k K8sClient) probe(r Resource) error {
+	opts := metav1.ListOptions{Limit: 1}
+	var err error
+	switch r {
+	case PVResource:
+		_, err = k.K8sCS.CoreV1().PersistentVolumes().List(context.TODO(), opts)
+	case PodResource:
+		opts.LabelSelector = componentLabel
+		_, err = k.K8sCS.CoreV1().Pods("").List(context.TODO(), opts)
+	case ControllerResource:
+		opts.LabelSelector = componentLabel
+		if _, err = k.K8sCS.AppsV1().StatefulSets("").List(context.TODO(), opts); err == nil {
+			_, err = k.K8sCS.AppsV1().Deployments("").List(context.TODO(), opts)
+		}
+	case DiskPoolResource:
+		_, err = k.diskPoolGVR()
+	case ZFSVolumeResource:
+		_, err = k.ZFCS.ZfsV1().ZFSVolumes("").List(context.TODO(), opts)
+	case ZFSNodeResource:
+		_, err = k.ZFCS.ZfsV1().ZFSNodes("").List(context.TODO(), opts)
+	case LVMVolumeResource:
+		_, err = k.LVMCS.LocalV1alpha1().LVMVolumes("").List(context.TODO(), opts)
+	case LVMNodeResource:
+		_, err = k.LVMCS.LocalV1alpha1().LVMNodes("").List(context.TODO(), opts)
+	}
+	return err
+}
+
+// selector matches the objects of the cache by the label & field selectors,
+// only the metadata fields & the ones given by the resource can be matched
+type selector struct {
+	labels labels.Selector
+	fields fields.Selector
+}
+
+// newSelector parses the label & field selectors
+func newSelector(labelSelector, fieldSelector string) (selector, error) {
+	l, err := labels.Parse(labelSelector)
+	if err != nil {
+		return selector{}, err
+	}
+	f, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return selector{}, err
+	}
+	return selector{labels: l, fields: f}, nil
+}
+
+// matches returns true if the object fields match the field selector
+func (s selector) matches(meta metav1.ObjectMeta, extra fields.Set) bool {
+	set := fields.Set{"metadata.name": meta.Name, "metadata.namespace": meta.Namespace}
+	for k, v := range extra {
+		set[k] = v
+	}
+	return s.fields.Matches(set)
+}
+
+// sortByName sorts the objects of the cache by namespace & name, the way the
+// api-server lists them
+func sortByName[T metav1.Object](objs []T) {
+	sort.Slice(objs, func(i, j int) bool {
+		if objs[i].GetNamespace() != objs[j].GetNamespace() {
+			return objs[i].GetNamespace() < objs[j].GetNamespace()
+		}
+		return objs[i].GetName() < objs[j].GetName()
+	})
+}
+
+// uniqueByName sorts the objects of the caches by namespace & name & drops
+// the ones cached by more than one informer, i.e. the Mayastor components
+// having both the component labels
+func uniqueByName[T metav1.Object](objs []T) []T {
+	sortByName(objs)
+	unique := objs[:0]
+	for i, obj := range objs {
+		if i > 0 && obj.GetNamespace() == objs[i-1].GetNamespace() && obj.GetName() == objs[i-1].GetName() {
+			continue
+		}
+		unique = append(unique, obj)
+	}
+	return unique
+}
+
+// listPVs lists the PersistentVolumes from the cache if they are watched
+func (k K8sClient) listPVs(labelSelector, fieldSelector string) (*corev1.PersistentVolumeList, error) {
+	if !k.cache.watches(PVResource) {
+		return k.K8sCS.CoreV1().PersistentVolumes().List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector})
+	}
+	if err := k.cache.errs[PVResource]; err != nil {
+		return nil, err
+	}
+	sel, err := newSelector(labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	pvs, err := k.cache.pvs.List(sel.labels)
+	if err != nil {
+		return nil, err
+	}
+	sortByName(pvs)
+	list := &corev1.PersistentVolumeList{}
+	for _, pv := range pvs {
+		if sel.matches(pv.ObjectMeta, nil) {
+			list.Items = append(list.Items, *pv)
+		}
+	}
+	return list, nil
+}
+
+// listPods lists the pods from the cache if they are watched, the cache only
+// has the pods of the OpenEBS & Mayastor components
+func (k K8sClient) listPods(labelSelector, fieldSelector, namespace string) (*corev1.PodList, error) {
+	if !k.cache.watches(PodResource) {
+		return k.K8sCS.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector})
+	}
+	if err := k.cache.errs[PodResource]; err != nil {
+		return nil, err
+	}
+	sel, err := newSelector(labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	var pods []*corev1.Pod
+	for _, l := range k.cache.pods {
+		found, err := l.Pods(namespace).List(sel.labels)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, found...)
+	}
+	pods = uniqueByName(pods)
+	list := &corev1.PodList{}
+	for _, pod := range pods {
+		if sel.matches(pod.ObjectMeta, fields.Set{"spec.nodeName": pod.Spec.NodeName, "status.phase": string(pod.Status.Phase)}) {
+			list.Items = append(list.Items, *pod)
+		}
+	}
+	return list, nil
+}
+
+// listStatefulSets lists the statefulsets from the cache if they are watched,
+// the cache only has the statefulsets of the OpenEBS & Mayastor components
+func (k K8sClient) listStatefulSets(labelSelector string) (*appsv1.StatefulSetList, error) {
+	if !k.cache.watches(ControllerResource) {
+		return k.K8sCS.AppsV1().StatefulSets("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	}
+	if err := k.cache.errs[ControllerResource]; err != nil {
+		return nil, err
+	}
+	sel, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	var sts []*appsv1.StatefulSet
+	for _, l := range k.cache.statefulSets {
+		found, err := l.List(sel)
+		if err != nil {
+			return nil, err
+		}
+		sts = append(sts, found...)
+	}
+	sts = uniqueByName(sts)
+	list := &appsv1.StatefulSetList{}
+	for _, s := range sts {
+		list.Items = append(list.Items, *s)
+	}
+	return list, nil
+}
+
+// listDeployments lists the deployments from the cache if they are watched,
+// the cache only has the deployments of the OpenEBS & Mayastor components
+func (k K8sClient) listDeployments(labelSelector string) (*appsv1.DeploymentList, error) {
+	if !k.cache.watches(ControllerResource) {
+		return k.K8sCS.AppsV1().Deployments("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	}
+	if err := k.cache.errs[ControllerResource]; err != nil {
+		return nil, err
+	}
+	sel, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	var deploys []*appsv1.Deployment
+	for _, l := range k.cache.deployments {
+		found, err := l.List(sel)
+		if err != nil {
+			return nil, err
+		}
+		deploys = append(deploys, found...)
+	}
+	deploys = uniqueByName(deploys)
+	list := &appsv1.DeploymentList{}
+	for _, d := range deploys {
+		list.Items = append(list.Items, *d)
+	}
+	return list, nil
+}
+
+// listZFSVolumes lists the ZFSVolumes from the cache if they are watched
+func (k K8sClient) listZFSVolumes(labelSelector, fieldSelector string) (*zfs.ZFSVolumeList, error) {
+	if !k.cache.watches(ZFSVolumeResource) {
+		return k.ZFCS.ZfsV1().ZFSVolumes("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector})
+	}
+	if err := k.cache.errs[ZFSVolumeResource]; err != nil {
+		return nil, err
+	}
+	sel, err := newSelector(labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	zvols, err := k.cache.zfsVolumes.List(sel.labels)
+	if err != nil {
+		return nil, err
+	}
+	sortByName(zvols)
+	list := &zfs.ZFSVolumeList{}
+	for _, zv := range zvols {
+		if sel.matches(zv.ObjectMeta, nil) {
+			list.Items = append(list.Items, *zv)
+		}
+	}
+	return list, nil
+}
+
+// listZFSNodes lists the ZFSNodes from the cache if they are watched
+func (k K8sClient) listZFSNodes(labelSelector, fieldSelector string) (*zfs.ZFSNodeList, error) {
+	if !k.cache.watches(ZFSNodeResource) {
+		return k.ZFCS.ZfsV1().ZFSNodes("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector})
+	}
+	if err := k.cache.errs[ZFSNodeResource]; err != nil {
+		return nil, err
+	}
+	sel, err := newSelector(labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := k.cache.zfsNodes.List(sel.labels)
+	if err != nil {
+		return nil, err
+	}
+	sortByName(nodes)
+	list := &zfs.ZFSNodeList{}
+	for _, zn := range nodes {
+		if sel.matches(zn.ObjectMeta, nil) {
+			list.Items = append(list.Items, *zn)
+		}
+	}
+	return list, nil
+}
+
+// listLVMVolumes lists the LVMVolumes from the cache if they are watched
+func (k K8sClient) listLVMVolumes(labelSelector, fieldSelector string) (*lvm.LVMVolumeList, error) {
+	if !k.cache.watches(LVMVolumeResource) {
+		return k.LVMCS.LocalV1alpha1().LVMVolumes("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector})
+	}
+	if err := k.cache.errs[LVMVolumeResource]; err != nil {
+		return nil, err
+	}
+	sel, err := newSelector(labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	lvs, err := k.cache.lvmVolumes.List(sel.labels)
+	if err != nil {
+		return nil, err
+	}
+	sortByName(lvs)
+	list := &lvm.LVMVolumeList{}
+	for _, lv := range lvs {
+		if sel.matches(lv.ObjectMeta, nil) {
+			list.Items = append(list.Items, *lv)
+		}
+	}
+	return list, nil
+}
+
+// listLVMNodes lists the LVMNodes from the cache if they are watched
+func (k K8sClient) listLVMNodes(labelSelector, fieldSelector string) (*lvm.LVMNodeList, error) {
+	if !k.cache.watches(LVMNodeResource) {
+		return k.LVMCS.LocalV1alpha1().LVMNodes("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector})
+	}
+	if err := k.cache.errs[LVMNodeResource]; err != nil {
+		return nil, err
+	}
+	sel, err := newSelector(labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := k.cache.lvmNodes.List(sel.labels)
+	if err != nil {
+		return nil, err
+	}
+	sortByName(nodes)
+	list := &lvm.LVMNodeList{}
+	for _, ln := range nodes {
+		if sel.matches(ln.ObjectMeta, nil) {
+			list.Items = append(list.Items, *ln)
+		}
+	}
+	return list, nil
+}
+
+// listDiskPools lists the Mayastor DiskPools from the cache if they are
+// watched, else from the newest version served by the cluster
+func (k K8sClient) listDiskPools(labelSelector, fieldSelector string) ([]unstructured.Unstructured, error) {
+	if !k.cache.watches(DiskPoolResource) {
+		if k.DynCS == nil {
+			return nil, fmt.Errorf("the dynamic client is not configured")
+		}
+		var err error
+		for _, gvr := range DiskPoolGVRs {
+			var found *unstructured.UnstructuredList
+			found, err = k.DynCS.Resource(gvr).Namespace("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector, FieldSelector: fieldSelector})
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			return found.Items, nil
+		}
+		return nil, err
+	}
+	if err := k.cache.errs[DiskPoolResource]; err != nil {
+		return nil, err
+	}
+	sel, err := newSelector(labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	objs, err := k.cache.diskPools.List(sel.labels)
+	if err != nil {
+		return nil, err
+	}
+	dsps := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			dsps = append(dsps, u)
+		}
+	}
+	sortByName(dsps)
+	var list []unstructured.Unstructured
+	for _, dsp := range dsps {
+		if sel.matches(metav1.ObjectMeta{Name: dsp.GetName(), Namespace: dsp.GetNamespace()}, nil) {
+			list = append(list, *dsp)
+		}
+	}
+	return list, nil
+}
+
+// diskPoolGVR returns the newest version of the DiskPools served by the cluster
+func (k K8sClient) diskPoolGVR() (schema.GroupVersionResource, error) {
+	if k.DynCS == nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("the dynamic client is not configured")
+	}
+	var err error
+	for _, gvr := range DiskPoolGVRs {
+		_, err = k.DynCS.Resource(gvr).Namespace("").List(context.TODO(), metav1.ListOptions{Limit: 1})
+		if !k8serrors.IsNotFound(err) {
+			return gvr, err
+		}
+	}
+	return schema.GroupVersionResource{}, err
+}
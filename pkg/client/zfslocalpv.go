@@ -45,8 +45,8 @@ func getZFSclient(kubeconfig string) (*zvolclient.Clientset, error) {
 }
 
 // GetZFSVols returns a list or a map of ZFSVolume depending upon rType & options
-func (k K8sClient) GetZFSVols(volNames []string, rType util.ReturnType, labelSelector string, options util.MapOptions) (*zfs.ZFSVolumeList, map[string]zfs.ZFSVolume, error) {
-	zvols, err := k.ZFCS.ZfsV1().ZFSVolumes("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+func (k K8sClient) GetZFSVols(volNames []string, rType util.ReturnType, labelSelector, fieldSelector string, options util.MapOptions) (*zfs.ZFSVolumeList, map[string]zfs.ZFSVolume, error) {
+	zvols, err := k.listZFSVolumes(labelSelector, fieldSelector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -92,8 +92,8 @@ func (k K8sClient) GetZFSVols(volNames []string, rType util.ReturnType, labelSel
 }
 
 // GetZFSNodes return a list of ZFSNodes
-func (k K8sClient) GetZFSNodes(volNames []string, rType util.ReturnType, labelSelector string, options util.MapOptions) (*zfs.ZFSNodeList, map[string]zfs.ZFSNode, error) {
-	zfsNode, err := k.ZFCS.ZfsV1().ZFSNodes("").List(context.TODO(), metav1.ListOptions{})
+func (k K8sClient) GetZFSNodes(volNames []string, rType util.ReturnType, labelSelector, fieldSelector string, options util.MapOptions) (*zfs.ZFSNodeList, map[string]zfs.ZFSNode, error) {
+	zfsNode, err := k.listZFSNodes(labelSelector, fieldSelector)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -137,3 +137,78 @@ func (k K8sClient) GetZFSNodes(volNames []string, rType util.ReturnType, labelSe
 	}
 	return nil, nil, fmt.Errorf("invalid return type")
 }
+
+// GetZFSSnapshots returns the ZFSSnapshots matching the label selector, if
+// snapNames is not empty only the ZFSSnapshots with these names are returned
+func (k K8sClient) GetZFSSnapshots(snapNames []string, labelSelector string) (*zfs.ZFSSnapshotList, error) {
+	snaps, err := k.ZFCS.ZfsV1().ZFSSnapshots("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	if len(snapNames) == 0 {
+		return snaps, nil
+	}
+	snapMap := make(map[string]zfs.ZFSSnapshot)
+	for _, snap := range snaps.Items {
+		snapMap[snap.Name] = snap
+	}
+	var list []zfs.ZFSSnapshot
+	for _, name := range snapNames {
+		if snap, ok := snapMap[name]; ok {
+			list = append(list, snap)
+		}
+	}
+	return &zfs.ZFSSnapshotList{Items: list}, nil
+}
+
+// DeleteZFSVolume deletes the ZFSVolume of the namespace by its name, the
+// ZFS-LocalPV node agent destroys its dataset
+func (k K8sClient) DeleteZFSVolume(name, namespace string) error {
+	return k.ZFCS.ZfsV1().ZFSVolumes(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
+// GetZFSBackups returns the ZFSBackups matching the label selector, if
+// backupNames is not empty only the ZFSBackups with these names are returned
+func (k K8sClient) GetZFSBackups(backupNames []string, labelSelector string) (*zfs.ZFSBackupList, error) {
+	backups, err := k.ZFCS.ZfsV1().ZFSBackups("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	if len(backupNames) == 0 {
+		return backups, nil
+	}
+	backupMap := make(map[string]zfs.ZFSBackup)
+	for _, backup := range backups.Items {
+		backupMap[backup.Name] = backup
+	}
+	var list []zfs.ZFSBackup
+	for _, name := range backupNames {
+		if backup, ok := backupMap[name]; ok {
+			list = append(list, backup)
+		}
+	}
+	return &zfs.ZFSBackupList{Items: list}, nil
+}
+
+// GetZFSRestores returns the ZFSRestores matching the label selector, if
+// restoreNames is not empty only the ZFSRestores with these names are returned
+func (k K8sClient) GetZFSRestores(restoreNames []string, labelSelector string) (*zfs.ZFSRestoreList, error) {
+	restores, err := k.ZFCS.ZfsV1().ZFSRestores("").List(context.TODO(), metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	if len(restoreNames) == 0 {
+		return restores, nil
+	}
+	restoreMap := make(map[string]zfs.ZFSRestore)
+	for _, restore := range restores.Items {
+		restoreMap[restore.Name] = restore
+	}
+	var list []zfs.ZFSRestore
+	for _, name := range restoreNames {
+		if restore, ok := restoreMap[name]; ok {
+			list = append(list, restore)
+		}
+	}
+	return &zfs.ZFSRestoreList{Items: list}, nil
+}
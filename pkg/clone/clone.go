@@ -0,0 +1,220 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// snapshotAPIGroup is the API group of the CSI VolumeSnapshots
+	snapshotAPIGroup = "snapshot.storage.k8s.io"
+	// pollInterval is the interval between the checks of the clone's phase
+	pollInterval = 2 * time.Second
+)
+
+// source is the PVC a clone is created from along with the data source &
+// the size of the clone
+type source struct {
+	pvc        *corev1.PersistentVolumeClaim
+	size       resource.Quantity
+	dataSource *corev1.TypedLocalObjectReference
+}
+
+// Clone creates the PVC name from the source PVC or VolumeSnapshot, src is
+// of the form pvc/<name> or volumesnapshot/<name>, & waits till it's bound
+func Clone(src, namespace, name, class string, timeout time.Duration) error {
+	k := client.NewK8sClient()
+	pvc, sc, err := createClone(k, src, namespace, name, class)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("pvc %s/%s created\n", pvc.Namespace, pvc.Name)
+	if sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+		fmt.Printf("storageclass %s waits for the first consumer, pvc %s/%s will be bound once a pod uses it\n", sc.Name, pvc.Namespace, pvc.Name)
+		return nil
+	}
+	err = wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+		return pvcBound(k, pvc.Name, pvc.Namespace)
+	})
+	if err != nil {
+		return fmt.Errorf("pvc %s/%s is not bound: %v", pvc.Namespace, pvc.Name, err)
+	}
+	fmt.Printf("pvc %s/%s is bound\n", pvc.Namespace, pvc.Name)
+	return nil
+}
+
+// createClone checks the limits of the engine of the source & creates the
+// clone, it returns the clone along with its StorageClass
+func createClone(k *client.K8sClient, src, namespace, name, class string) (*corev1.PersistentVolumeClaim, *storagev1.StorageClass, error) {
+	// 1. Get the source PVC & the data source of the clone
+	s, err := getSource(k, src, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.pvc.Spec.VolumeName == "" {
+		return nil, nil, fmt.Errorf("pvc %s/%s is not bound to a volume", namespace, s.pvc.Name)
+	}
+	pv, err := k.GetPV(s.pvc.Spec.VolumeName)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 2. Only the engines with clone support are allowed
+	casType := util.NormalizeCasType(util.GetCasTypeFromPV(pv))
+	check, ok := CasListMap()[casType]
+	if !ok {
+		return nil, nil, fmt.Errorf("clones are not supported for the pvc %s/%s of cas-type %s", namespace, s.pvc.Name, casType)
+	}
+	// 3. Get the StorageClass of the clone, it must be of the same driver
+	if class == "" {
+		if s.pvc.Spec.StorageClassName == nil {
+			return nil, nil, fmt.Errorf("pvc %s/%s has no storageclass, use --storage-class to pick one", namespace, s.pvc.Name)
+		}
+		class = *s.pvc.Spec.StorageClassName
+	}
+	sc, err := k.GetSC(class)
+	if err != nil {
+		return nil, nil, err
+	}
+	if driver := util.CasTypeToCSIProvisionerMap[casType]; sc.Provisioner != driver {
+		return nil, nil, fmt.Errorf("storageclass %s is of the provisioner %s, the clone must be of the driver %s", sc.Name, sc.Provisioner, driver)
+	}
+	// 4. Check the limits of the engine
+	if err = check(k, pv.Name, sc); err != nil {
+		return nil, nil, err
+	}
+	// 5. Create the clone
+	if name == "" {
+		name = s.pvc.Name + "-clone-" + time.Now().UTC().Format("20060102150405")
+	}
+	clone := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      s.pvc.Spec.AccessModes,
+			StorageClassName: &sc.Name,
+			VolumeMode:       s.pvc.Spec.VolumeMode,
+			DataSource:       s.dataSource,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: s.size},
+			},
+		},
+	}
+	clone, err = k.CreatePVC(clone)
+	return clone, sc, err
+}
+
+// getSource returns the source PVC of src, either the PVC itself or the
+// source PVC of the VolumeSnapshot
+func getSource(k *client.K8sClient, src, namespace string) (*source, error) {
+	kind, name := "pvc", src
+	if parts := strings.SplitN(src, "/", 2); len(parts) == 2 {
+		kind, name = parts[0], parts[1]
+	}
+	switch strings.ToLower(kind) {
+	case "pvc", "persistentvolumeclaim":
+		pvc, err := k.GetPVC(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		return &source{
+			pvc:        pvc,
+			size:       pvc.Spec.Resources.Requests[corev1.ResourceStorage],
+			dataSource: &corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: name},
+		}, nil
+	case "snapshot", "volumesnapshot", "vs":
+		vs, err := k.GetVolumeSnapshot(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if vs.Status == nil || vs.Status.ReadyToUse == nil || !*vs.Status.ReadyToUse {
+			return nil, fmt.Errorf("volumesnapshot %s/%s is not ready to use", namespace, name)
+		}
+		if vs.Spec.Source.PersistentVolumeClaimName == nil {
+			return nil, fmt.Errorf("volumesnapshot %s/%s has no source pvc", namespace, name)
+		}
+		pvc, err := k.GetPVC(*vs.Spec.Source.PersistentVolumeClaimName, namespace)
+		if err != nil {
+			return nil, err
+		}
+		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if vs.Status.RestoreSize != nil && vs.Status.RestoreSize.Cmp(size) > 0 {
+			size = *vs.Status.RestoreSize
+		}
+		group := snapshotAPIGroup
+		return &source{
+			pvc:        pvc,
+			size:       size,
+			dataSource: &corev1.TypedLocalObjectReference{APIGroup: &group, Kind: "VolumeSnapshot", Name: name},
+		}, nil
+	default:
+		return nil, fmt.Errorf("the source %s must be a pvc or a volumesnapshot", src)
+	}
+}
+
+// checkNode returns an error if the allowed topologies of the StorageClass
+// don't have the node of the source volume, a clone is always on that node
+func checkNode(k *client.K8sClient, sc *storagev1.StorageClass, nodeName string) error {
+	if len(sc.AllowedTopologies) == 0 {
+		return nil
+	}
+	nodes, err := k.GetNodes([]string{nodeName}, "", "")
+	if err != nil {
+		return err
+	}
+	if len(nodes.Items) == 0 {
+		return fmt.Errorf("node %s of the source volume not found", nodeName)
+	}
+	if util.TopologyMatches(sc.AllowedTopologies, nodes.Items[0].Labels) {
+		return nil
+	}
+	return fmt.Errorf("the clone must be on the node %s of the source volume, it is not in the allowed topologies of storageclass %s", nodeName, sc.Name)
+}
+
+// pvcBound returns true once the PVC is bound, a lost PVC is an error
+func pvcBound(k *client.K8sClient, name, namespace string) (bool, error) {
+	pvc, err := k.GetPVC(name, namespace)
+	if err != nil {
+		return false, err
+	}
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return true, nil
+	case corev1.ClaimLost:
+		return false, fmt.Errorf("pvc %s/%s is lost", namespace, name)
+	}
+	return false, nil
+}
+
+// CasListMap returns a map of cas-types to the functions checking the limits
+// of their clones
+func CasListMap() map[string]func(*client.K8sClient, string, *storagev1.StorageClass) error {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient, string, *storagev1.StorageClass) error{
+		util.ZFSCasType: CheckZFSClone,
+		util.LVMCasType: CheckLVMClone,
+	}
+}
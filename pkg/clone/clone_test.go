@@ -0,0 +1,146 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"testing"
+
+	snapfake "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned/fake"
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateClone(t *testing.T) {
+	zfsParams := map[string]string{util.ZFSPoolNameParam: "zfspv"}
+	thinParams := map[string]string{util.LVMThinProvisionParam: "yes", util.LVMVolGroupParam: "lvmvg"}
+	objects := []runtime.Object{
+		&node1,
+		newPV("pvc-zfs", util.ZFSCSIDriver),
+		newPV("pvc-lvm-thin", util.LocalPVLVMCSIDriver),
+		newPV("pvc-lvm-thick", util.LocalPVLVMCSIDriver),
+		newPV("pvc-hostpath", "openebs.io/local"),
+		newPVC(zfsPVCName, "pvc-zfs", &zfsSCName),
+		newPVC("lvm-thin-data", "pvc-lvm-thin", &lvmSCName),
+		newPVC("lvm-thick-data", "pvc-lvm-thick", &lvmSCName),
+		newPVC("hostpath-data", "pvc-hostpath", &hostpathSCName),
+		newPVC("pending-data", "", &zfsSCName),
+		newSC(zfsSCName, util.ZFSCSIDriver, zfsParams),
+		newSC("zfs-other-pool", util.ZFSCSIDriver, map[string]string{util.ZFSPoolNameParam: "zfspv-2"}),
+		newSC("zfs-node2", util.ZFSCSIDriver, zfsParams, hostnameTerm("node2")),
+		newSC("zfs-node1", util.ZFSCSIDriver, zfsParams, hostnameTerm("node2"), hostnameTerm("node1")),
+		newSC(lvmSCName, util.LocalPVLVMCSIDriver, thinParams),
+		newSC("lvm-thick", util.LocalPVLVMCSIDriver, map[string]string{util.LVMVolGroupParam: "lvmvg"}),
+		newSC("lvm-other-vg", util.LocalPVLVMCSIDriver, map[string]string{util.LVMThinProvisionParam: "yes", util.LVMVgPatternParam: "^data.*$"}),
+		newSC(hostpathSCName, "openebs.io/local", nil),
+	}
+	tests := []struct {
+		name           string
+		src            string
+		class          string
+		wantClass      string
+		wantSourceKind string
+		wantSize       string
+		wantErr        bool
+	}{
+		{"zfs pvc", "pvc/" + zfsPVCName, "", zfsSCName, "PersistentVolumeClaim", "4Gi", false},
+		{"zfs pvc without kind", zfsPVCName, "", zfsSCName, "PersistentVolumeClaim", "4Gi", false},
+		{"zfs snapshot", "volumesnapshot/zfs-snap", "", zfsSCName, "VolumeSnapshot", "8Gi", false},
+		{"snapshot not ready", "volumesnapshot/zfs-snap-pending", "", "", "", "", true},
+		{"zfs pool mismatch", "pvc/" + zfsPVCName, "zfs-other-pool", "", "", "", true},
+		{"zfs node not allowed", "pvc/" + zfsPVCName, "zfs-node2", "", "", "", true},
+		{"zfs node allowed", "pvc/" + zfsPVCName, "zfs-node1", "zfs-node1", "PersistentVolumeClaim", "4Gi", false},
+		{"storageclass of another driver", "pvc/" + zfsPVCName, lvmSCName, "", "", "", true},
+		{"lvm thin pvc", "pvc/lvm-thin-data", "", lvmSCName, "PersistentVolumeClaim", "4Gi", false},
+		{"lvm thick source", "pvc/lvm-thick-data", "", "", "", "", true},
+		{"lvm thick storageclass", "pvc/lvm-thin-data", "lvm-thick", "", "", "", true},
+		{"lvm volume group mismatch", "pvc/lvm-thin-data", "lvm-other-vg", "", "", "", true},
+		{"no clone support", "pvc/hostpath-data", "", "", "", "", true},
+		{"pvc not bound", "pvc/pending-data", "", "", "", "", true},
+		{"unknown kind", "pod/" + zfsPVCName, "", "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				K8sCS: k8sfake.NewSimpleClientset(objects...),
+				ZFCS:  zfsfake.NewSimpleClientset(&zfsVol1),
+				LVMCS: lvmfake.NewSimpleClientset(&lvmThinVol1, &lvmThickVol1),
+				SnapCS: snapfake.NewSimpleClientset(
+					newVolumeSnapshot("zfs-snap", &ready, "8Gi"),
+					newVolumeSnapshot("zfs-snap-pending", &notReady, "4Gi"),
+				),
+			}
+			got, _, err := createClone(k, tt.src, "default", "clone", tt.class)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("createClone() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got.Spec.StorageClassName != tt.wantClass {
+				t.Errorf("createClone() storageclass = %s, want %s", *got.Spec.StorageClassName, tt.wantClass)
+			}
+			if got.Spec.DataSource == nil || got.Spec.DataSource.Kind != tt.wantSourceKind {
+				t.Errorf("createClone() dataSource = %+v, want kind %s", got.Spec.DataSource, tt.wantSourceKind)
+			}
+			size := got.Spec.Resources.Requests[corev1.ResourceStorage]
+			if size.Cmp(resource.MustParse(tt.wantSize)) != 0 {
+				t.Errorf("createClone() size = %s, want %s", size.String(), tt.wantSize)
+			}
+			if _, err := k.GetPVC("clone", "default"); err != nil {
+				t.Errorf("createClone() pvc not created: %v", err)
+			}
+		})
+	}
+}
+
+func TestPVCBound(t *testing.T) {
+	bound := newPVC("bound", "pvc-1", &zfsSCName)
+	bound.Status.Phase = corev1.ClaimBound
+	lost := newPVC("lost", "pvc-2", &zfsSCName)
+	lost.Status.Phase = corev1.ClaimLost
+	pending := newPVC("pending", "", &zfsSCName)
+	pending.Status.Phase = corev1.ClaimPending
+	tests := []struct {
+		name    string
+		pvc     string
+		want    bool
+		wantErr bool
+	}{
+		{"bound", "bound", true, false},
+		{"pending", "pending", false, false},
+		{"lost", "lost", false, true},
+		{"not found", "missing", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(bound, lost, pending)}
+			got, err := pvcBound(k, tt.pvc, "default")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pvcBound() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("pvcBound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// CheckLVMClone returns an error if a clone of the LVM volume can't be created
+// with the StorageClass, a LVM clone is a thin snapshot so both the source
+// volume & the StorageClass must be thin provisioned on the same volume group
+func CheckLVMClone(k *client.K8sClient, volume string, sc *storagev1.StorageClass) error {
+	lvols, _, err := k.GetLVMvol([]string{volume}, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return err
+	}
+	if len(lvols.Items) == 0 {
+		return fmt.Errorf("lvmvolume %s not found", volume)
+	}
+	lv := lvols.Items[0]
+	if lv.Spec.ThinProvision != "yes" {
+		return fmt.Errorf("LVM clones need thin provisioning, the source volume %s is thick provisioned", volume)
+	}
+	if sc.Parameters[util.LVMThinProvisionParam] != "yes" {
+		return fmt.Errorf("LVM clones need thin provisioning, storageclass %s doesn't set %s to \"yes\"", sc.Name, util.LVMThinProvisionParam)
+	}
+	if vg, ok := sc.Parameters[util.LVMVolGroupParam]; ok && vg != lv.Spec.VolGroup {
+		return fmt.Errorf("a LVM clone must be on the volume group %s of its source volume, storageclass %s uses the volume group %s", lv.Spec.VolGroup, sc.Name, vg)
+	}
+	if pattern, ok := sc.Parameters[util.LVMVgPatternParam]; ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid %s %s of storageclass %s: %v", util.LVMVgPatternParam, pattern, sc.Name, err)
+		}
+		if !re.MatchString(lv.Spec.VolGroup) {
+			return fmt.Errorf("a LVM clone must be on the volume group %s of its source volume, it doesn't match the %s %s of storageclass %s", lv.Spec.VolGroup, util.LVMVgPatternParam, pattern, sc.Name)
+		}
+	}
+	return checkNode(k, sc, lv.Spec.OwnerNodeID)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	zfsSCName      = "zfs-sc"
+	lvmSCName      = "lvm-sc"
+	hostpathSCName = "openebs-hostpath"
+	zfsPVCName     = "zfs-data"
+	ready          = true
+	notReady       = false
+)
+
+func newPV(name, driver string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: name},
+			},
+		},
+	}
+}
+
+func newPVC(name, volume string, class *string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: class,
+			VolumeName:       volume,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("4Gi")},
+			},
+		},
+	}
+}
+
+func newSC(name, provisioner string, params map[string]string, topologies ...corev1.TopologySelectorTerm) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: name},
+		Provisioner:       provisioner,
+		Parameters:        params,
+		AllowedTopologies: topologies,
+	}
+}
+
+func newVolumeSnapshot(name string, readyToUse *bool, restoreSize string) *snapshotv1.VolumeSnapshot {
+	size := resource.MustParse(restoreSize)
+	return &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: snapshotv1.VolumeSnapshotSpec{
+			Source: snapshotv1.VolumeSnapshotSource{PersistentVolumeClaimName: &zfsPVCName},
+		},
+		Status: &snapshotv1.VolumeSnapshotStatus{ReadyToUse: readyToUse, RestoreSize: &size},
+	}
+}
+
+var hostnameTerm = func(nodes ...string) corev1.TopologySelectorTerm {
+	return corev1.TopologySelectorTerm{
+		MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{
+			{Key: "kubernetes.io/hostname", Values: nodes},
+		},
+	}
+}
+
+var node1 = corev1.Node{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:   "node1",
+		Labels: map[string]string{"kubernetes.io/hostname": "node1"},
+	},
+}
+
+var zfsVol1 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-zfs", Namespace: "openebs"},
+	Spec: zfs.VolumeInfo{
+		OwnerNodeID: "node1",
+		PoolName:    "zfspv",
+		Capacity:    "4294967296",
+	},
+}
+
+var lvmThinVol1 = lvm.LVMVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-lvm-thin", Namespace: "openebs"},
+	Spec: lvm.VolumeInfo{
+		OwnerNodeID:   "node1",
+		VolGroup:      "lvmvg",
+		Capacity:      "4294967296",
+		ThinProvision: "yes",
+	},
+}
+
+var lvmThickVol1 = lvm.LVMVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-lvm-thick", Namespace: "openebs"},
+	Spec: lvm.VolumeInfo{
+		OwnerNodeID: "node1",
+		VolGroup:    "lvmvg",
+		Capacity:    "4294967296",
+	},
+}
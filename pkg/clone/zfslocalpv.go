@@ -0,0 +1,43 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// CheckZFSClone returns an error if a clone of the ZFS volume can't be created
+// with the StorageClass, a ZFS clone must be on the node & the pool of its
+// source volume
+func CheckZFSClone(k *client.K8sClient, volume string, sc *storagev1.StorageClass) error {
+	zvols, _, err := k.GetZFSVols([]string{volume}, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return err
+	}
+	if len(zvols.Items) == 0 {
+		return fmt.Errorf("zfsvolume %s not found", volume)
+	}
+	zv := zvols.Items[0]
+	if pool := sc.Parameters[util.ZFSPoolNameParam]; pool != zv.Spec.PoolName {
+		return fmt.Errorf("a ZFS clone must be on the pool %s of its source volume, storageclass %s uses the pool %s", zv.Spec.PoolName, sc.Name, pool)
+	}
+	return checkNode(k, sc, zv.Spec.OwnerNodeID)
+}
@@ -18,6 +18,8 @@ package clusterinfo
 
 import (
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/openebs/openebsctl/pkg/client"
@@ -28,44 +30,158 @@ import (
 	"k8s.io/cli-runtime/pkg/printers"
 )
 
-// ShowClusterInfo shows the openebs components and their status and versions
-func ShowClusterInfo() error {
+// clusterInfoKind is the kind of the machine-readable cluster-info
+const clusterInfoKind = "ClusterInfo"
+
+// EngineStatus is the status of the components of an installed engine
+type EngineStatus struct {
+	CasType    string               `json:"casType"`
+	Namespace  string               `json:"namespace"`
+	Version    string               `json:"version"`
+	Working    string               `json:"working"`
+	Status     string               `json:"status"`
+	Components []util.ComponentData `json:"components"`
+}
+
+// ResourceName returns the resource/name reference of the EngineStatus
+func (e EngineStatus) ResourceName() string {
+	return "engine/" + e.CasType
+}
+
+// ShowClusterInfo shows the openebs components and their status and versions,
+// with watch the rows of the engines are reprinted as their components change
+func ShowClusterInfo(output string, watch bool) error {
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
+	if watch {
+		if err := util.CheckWatchOutputFormat(output); err != nil {
+			return err
+		}
+	}
 	k := client.NewK8sClient()
-	err := compute(k)
+	if watch {
+		return watchClusterInfo(k, output)
+	}
+	err := compute(k, output)
 	return err
 }
 
-func compute(k *client.K8sClient) error {
-	var clusterInfoRows []metav1.TableRow
+func compute(k *client.K8sClient, output string) error {
+	engines := GetEngineStatuses(k)
+	if len(engines) == 0 {
+		if util.IsStructuredOutput(output) {
+			return util.PrintList(output, clusterInfoKind, nil)
+		}
+		return fmt.Errorf("none Of the OpenEBS Storage Engines are installed in this cluster")
+	}
+	var items []interface{}
+	for _, e := range engines {
+		items = append(items, e)
+	}
+	switch output {
+	case "":
+		util.TablePrinter(util.ClusterInfoColumnDefinitions, clusterInfoRows(engines, output), printers.PrintOptions{})
+	case util.WideOutput:
+		util.TablePrinter(util.ClusterInfoWideColumnDefinitions, clusterInfoRows(engines, output), printers.PrintOptions{Wide: true})
+	default:
+		return util.PrintList(output, clusterInfoKind, items)
+	}
+	return nil
+}
+
+// watchClusterInfo prints the engines & then reprints the rows of the engines
+// which change, the component pods are read from an informer cache
+func watchClusterInfo(k *client.K8sClient, output string) error {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	changed, err := k.Watch(stopCh, client.PodResource)
+	if err != nil {
+		return err
+	}
+	// the rows are identified by the cas-type cell
+	printer := util.NewWatchPrinter(util.ClusterInfoColumnDefinitions, printers.PrintOptions{}, 1)
+	if output == util.WideOutput {
+		printer = util.NewWatchPrinter(util.ClusterInfoWideColumnDefinitions, printers.PrintOptions{Wide: true}, 1)
+	}
+	for first := true; ; first = false {
+		engines := GetEngineStatuses(k)
+		if first && len(engines) == 0 {
+			_, _ = fmt.Fprintln(os.Stderr, "none Of the OpenEBS Storage Engines are installed in this cluster")
+		}
+		printer.Print(clusterInfoRows(engines, output))
+		<-changed
+	}
+}
+
+// clusterInfoRows returns the table rows of the engines, the wide output
+// has the status of every component too
+func clusterInfoRows(engines []EngineStatus, output string) []metav1.TableRow {
+	var rows []metav1.TableRow
+	for _, e := range engines {
+		cells := []interface{}{e.CasType, e.Namespace, e.Version, e.Working, util.ColorStringOnStatus(e.Status)}
+		if output == util.WideOutput {
+			var components []string
+			for _, c := range e.Components {
+				status := c.Status
+				if status == "" {
+					status = util.NotAvailable
+				}
+				components = append(components, fmt.Sprintf("%s(%s)", c.Name, status))
+			}
+			cells = append(cells, strings.Join(components, ","))
+		}
+		rows = append(rows, metav1.TableRow{Cells: cells})
+	}
+	return rows
+}
+
+// GetEngineStatuses returns the status of every installed engine sorted by
+// the cas-type
+func GetEngineStatuses(k *client.K8sClient) []EngineStatus {
+	var engines []EngineStatus
 	for casType, componentNames := range util.CasTypeToComponentNamesMap {
 		componentDataMap, err := getComponentDataByComponents(k, componentNames, casType)
 		if err == nil && len(componentDataMap) != 0 {
 			status, working := getStatus(componentDataMap)
-			version := getVersion(componentDataMap)
-			namespace := getNamespace(componentDataMap)
-			clusterInfoRows = append(
-				clusterInfoRows,
-				metav1.TableRow{Cells: []interface{}{casType, namespace, version, working, util.ColorStringOnStatus(status)}},
-			)
+			var components []util.ComponentData
+			for _, c := range componentDataMap {
+				components = append(components, c)
+			}
+			sort.Slice(components, func(i, j int) bool {
+				return components[i].Name < components[j].Name
+			})
+			engines = append(engines, EngineStatus{
+				CasType:    casType,
+				Namespace:  getNamespace(componentDataMap),
+				Version:    getVersion(componentDataMap),
+				Working:    working,
+				Status:     status,
+				Components: components,
+			})
 		}
 	}
-	if len(clusterInfoRows) == 0 {
-		return fmt.Errorf("none Of the OpenEBS Storage Engines are installed in this cluster")
-	}
-	util.TablePrinter(util.ClusterInfoColumnDefinitions, clusterInfoRows, printers.PrintOptions{})
-	return nil
+	sort.Slice(engines, func(i, j int) bool {
+		return engines[i].CasType < engines[j].CasType
+	})
+	return engines
 }
 
 func getComponentDataByComponents(k *client.K8sClient, componentNames string, casType string) (map[string]util.ComponentData, error) {
 	var podList *corev1.PodList
 	componentDataMap := make(map[string]util.ComponentData)
-	podList, _ = k.GetPods(fmt.Sprintf("openebs.io/component-name in (%s)", componentNames), "", "")
+	labelKey := util.ComponentLabelKey(casType)
+	podList, err := k.GetPods(fmt.Sprintf("%s in (%s)", labelKey, componentNames), "", "")
+	if err != nil {
+		return nil, err
+	}
 	if len(podList.Items) != 0 {
 		for _, item := range podList.Items {
-			if val, ok := componentDataMap[item.Labels["openebs.io/component-name"]]; ok {
+			if val, ok := componentDataMap[item.Labels[labelKey]]; ok {
 				// Update only if the status of the component is not running.
 				if val.Status != string(v1.PodRunning) {
-					componentDataMap[item.Labels["openebs.io/component-name"]] = util.ComponentData{
+					componentDataMap[item.Labels[labelKey]] = util.ComponentData{
+						Name:      item.Labels[labelKey],
 						Namespace: item.Namespace,
 						Status:    string(item.Status.Phase),
 						Version:   item.Labels["openebs.io/version"],
@@ -73,7 +189,8 @@ func getComponentDataByComponents(k *client.K8sClient, componentNames string, ca
 					}
 				}
 			} else {
-				componentDataMap[item.Labels["openebs.io/component-name"]] = util.ComponentData{
+				componentDataMap[item.Labels[labelKey]] = util.ComponentData{
+					Name:      item.Labels[labelKey],
 					Namespace: item.Namespace,
 					Status:    string(item.Status.Phase),
 					Version:   item.Labels["openebs.io/version"],
@@ -84,7 +201,7 @@ func getComponentDataByComponents(k *client.K8sClient, componentNames string, ca
 
 		for _, item := range strings.Split(componentNames, ",") {
 			if _, ok := componentDataMap[item]; !ok {
-				componentDataMap[item] = util.ComponentData{}
+				componentDataMap[item] = util.ComponentData{Name: item}
 			}
 		}
 
@@ -0,0 +1,276 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dashboard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+)
+
+// DefaultInterval is the default interval between two refreshes of the
+// active tab
+const DefaultInterval = 5 * time.Second
+
+const (
+	// enterAltScreen switches to the alternate screen & hides the cursor,
+	// exitAltScreen restores the screen & the cursor
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	exitAltScreen  = "\x1b[?25h\x1b[?1049l"
+)
+
+// job is a load of the rows of a tab, or the describe of a row if describe
+// is set
+type job struct {
+	tab      int
+	title    string
+	describe func() error
+}
+
+// result is the outcome of a job, output has what was printed by the job
+type result struct {
+	job
+	entries []entry
+	output  string
+	err     error
+	at      time.Time
+}
+
+// Run shows the dashboard on the terminal till the user quits, the active
+// tab is refreshed every interval
+func Run(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval %s, it must be positive", interval)
+	}
+	in, out := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(in) || !term.IsTerminal(out) {
+		return errors.New("the dashboard needs a terminal")
+	}
+	k := client.NewK8sClient()
+	state, err := term.MakeRaw(in)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up the terminal")
+	}
+	defer func() { _ = term.Restore(in, state) }()
+	screen := os.Stdout
+	_, _ = fmt.Fprint(screen, enterAltScreen)
+	defer func() { _, _ = fmt.Fprint(screen, exitAltScreen) }()
+
+	keys := make(chan key)
+	go readKeys(bufio.NewReader(os.Stdin), keys)
+	jobs := make(chan job, 16)
+	results := make(chan result)
+	go worker(k, jobs, results)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m := newModel(tabs(), interval)
+	// loading stops queueing another load of a tab being loaded & describing
+	// another describe while one runs, so at most a job per tab & a describe
+	// are queued & sending a job never blocks while the worker sends a result
+	loading := make(map[int]bool)
+	describing := false
+	refresh := func() {
+		if !loading[m.active] {
+			loading[m.active] = true
+			jobs <- job{tab: m.active}
+		}
+	}
+	refresh()
+	for {
+		width, height, err := term.GetSize(out)
+		if err != nil || width <= 0 || height <= 0 {
+			width, height = 80, 24
+		}
+		draw(screen, m.view(width, height))
+		select {
+		case pressed := <-keys:
+			switch m.handleKey(pressed, height-4) {
+			case actionQuit:
+				return nil
+			case actionRefresh:
+				refresh()
+			case actionDescribe:
+				if e, ok := m.selectedEntry(); ok && !describing {
+					describing = true
+					jobs <- job{tab: m.active, title: fmt.Sprintf("%s %v", m.tabs[m.active].name, e.cells[0]), describe: e.describe}
+				}
+			}
+		case <-ticker.C:
+			// the describe views aren't refreshed as the user is reading them
+			if m.detail == nil {
+				refresh()
+			}
+		case r := <-results:
+			if r.describe != nil {
+				describing = false
+				m.setDetail(r.title, r.output, r.err)
+				continue
+			}
+			loading[r.tab] = false
+			m.setEntries(r.tab, r.entries, r.err, r.output, r.at)
+		}
+	}
+}
+
+// worker runs the jobs one by one, the describe views & the warnings of the
+// loads are printed to stdout & stderr by the engine packages, so these are
+// captured while a job runs
+func worker(k *client.K8sClient, jobs <-chan job, results chan<- result) {
+	tabs := tabs()
+	for j := range jobs {
+		r := result{job: j}
+		if j.describe != nil {
+			r.output, r.err = capture(j.describe)
+		} else {
+			r.output, r.err = capture(func() error {
+				var err error
+				r.entries, err = tabs[j.tab].load(k)
+				return err
+			})
+		}
+		r.at = time.Now()
+		results <- r
+	}
+}
+
+// capture returns what f prints to stdout & stderr
+func capture(f func() error) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, w
+	done := make(chan string)
+	// the pipe is read while f runs so that f doesn't block on a full pipe
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+	ferr := f()
+	os.Stdout, os.Stderr = stdout, stderr
+	_ = w.Close()
+	output := <-done
+	_ = r.Close()
+	return output, ferr
+}
+
+// draw redraws the screen with the lines
+func draw(w io.Writer, lines []string) {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		if i > 0 {
+			// the raw mode doesn't translate \n to \r\n
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line + "\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+	_, _ = io.WriteString(w, b.String())
+}
+
+// readKeys sends the keys read from the terminal till it is closed
+func readKeys(r *bufio.Reader, keys chan<- key) {
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, k := range parseKeys(buf[:n]) {
+			keys <- k
+		}
+	}
+}
+
+// escapeKeys maps the escape sequences of the terminal to the keys
+var escapeKeys = map[string]keyCode{
+	"\x1b[A":  keyUp,
+	"\x1b[B":  keyDown,
+	"\x1b[C":  keyRight,
+	"\x1b[D":  keyLeft,
+	"\x1bOA":  keyUp,
+	"\x1bOB":  keyDown,
+	"\x1bOC":  keyRight,
+	"\x1bOD":  keyLeft,
+	"\x1b[5~": keyPageUp,
+	"\x1b[6~": keyPageDown,
+	"\x1b[Z":  keyBackTab,
+}
+
+// parseKeys returns the keys of the bytes read from the terminal, the
+// unknown escape sequences are skipped
+func parseKeys(b []byte) []key {
+	var keys []key
+	for len(b) > 0 {
+		if b[0] == 0x1b {
+			if len(b) == 1 {
+				keys = append(keys, key{code: keyEsc})
+				return keys
+			}
+			// an escape sequence ends with a letter or a ~
+			end := 2
+			for end < len(b) && !isSequenceEnd(b[end-1], end) {
+				end++
+			}
+			if code, ok := escapeKeys[string(b[:end])]; ok {
+				keys = append(keys, key{code: code})
+			} else if b[1] != '[' && b[1] != 'O' {
+				// an esc followed by another key
+				keys = append(keys, key{code: keyEsc})
+				end = 1
+			}
+			b = b[end:]
+			continue
+		}
+		r, size := utf8.DecodeRune(b)
+		b = b[size:]
+		switch r {
+		case '\r', '\n':
+			keys = append(keys, key{code: keyEnter})
+		case '\t':
+			keys = append(keys, key{code: keyTab})
+		case 0x7f, 0x08:
+			keys = append(keys, key{code: keyBackspace})
+		case 0x03:
+			keys = append(keys, key{code: keyCtrlC})
+		default:
+			if r >= ' ' && r != utf8.RuneError {
+				keys = append(keys, key{code: keyRune, r: r})
+			}
+		}
+	}
+	return keys
+}
+
+// isSequenceEnd returns true if the byte at index i-1 of an escape sequence
+// ends it, the first two bytes are the esc & the [ or O
+func isSequenceEnd(c byte, i int) bool {
+	if i <= 2 {
+		return false
+	}
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || c == '~'
+}
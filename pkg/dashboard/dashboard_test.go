@@ -0,0 +1,257 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dashboard
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []key
+	}{
+		{"runes", "/zfs", []key{{code: keyRune, r: '/'}, {code: keyRune, r: 'z'}, {code: keyRune, r: 'f'}, {code: keyRune, r: 's'}}},
+		{"arrows", "\x1b[A\x1b[B\x1bOC\x1b[D", []key{{code: keyUp}, {code: keyDown}, {code: keyRight}, {code: keyLeft}}},
+		{"pages & back tab", "\x1b[5~\x1b[6~\x1b[Z", []key{{code: keyPageUp}, {code: keyPageDown}, {code: keyBackTab}}},
+		{"esc", "\x1b", []key{{code: keyEsc}}},
+		{"esc followed by a key", "\x1bq", []key{{code: keyEsc}, {code: keyRune, r: 'q'}}},
+		{"unknown sequence", "\x1b[1;5Aj", []key{{code: keyRune, r: 'j'}}},
+		{"controls", "\r\t\x7f\x03", []key{{code: keyEnter}, {code: keyTab}, {code: keyBackspace}, {code: keyCtrlC}}},
+		{"unicode", "ü", []key{{code: keyRune, r: 'ü'}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKeys([]byte(tt.input)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKeys(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestModel returns a model with a tab of the volumes & an empty tab
+func newTestModel(names ...string) *model {
+	columns := []metav1.TableColumnDefinition{{Name: "Name", Type: "string"}, {Name: "Cas Type", Type: "string"}}
+	m := newModel([]tab{{name: "Volumes", columns: columns}, {name: "PVCs", columns: columns}}, time.Second)
+	var entries []entry
+	for _, name := range names {
+		name := name
+		entries = append(entries, entry{cells: []interface{}{name, util.ZFSCasType}, describe: func() error {
+			fmt.Printf("\x1b[32m%s\x1b[0m Details :\nNAME : %s\n", name, name)
+			return nil
+		}})
+	}
+	m.setEntries(0, entries, nil, "", time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC))
+	return m
+}
+
+func TestHandleKey(t *testing.T) {
+	m := newTestModel("pvc-1", "pvc-2", "pvc-12", "pvc-3")
+	press := func(s string) action {
+		var last action
+		for _, k := range parseKeys([]byte(s)) {
+			last = m.handleKey(k, 10)
+		}
+		return last
+	}
+	if press("jj") != actionNone || m.selected != 2 {
+		t.Errorf("moving down selected %d, want 2", m.selected)
+	}
+	if press("jjjj"); m.selected != 3 {
+		t.Errorf("moving past the last row selected %d, want 3", m.selected)
+	}
+	// filter as you type
+	press("/pvc-1")
+	if got := len(m.visible()); !m.filtering || got != 2 || m.selected != 0 {
+		t.Errorf("filtering /pvc-1 shows %d rows & selected %d, want 2 & 0", got, m.selected)
+	}
+	press("2\x7f\x7f")
+	if m.filter != "pvc-" || len(m.visible()) != 4 {
+		t.Errorf("editing the filter got %q & %d rows, want pvc- & 4", m.filter, len(m.visible()))
+	}
+	// q is a part of the filter while typing it
+	if press("q") != actionNone || m.filter != "pvc-q" {
+		t.Errorf("typing q in the filter got %q", m.filter)
+	}
+	press("\x1b")
+	if m.filtering || m.filter != "" {
+		t.Errorf("esc left the filter %q, filtering %v", m.filter, m.filtering)
+	}
+	press("/12\r")
+	if m.filtering || m.filter != "12" || len(m.visible()) != 1 {
+		t.Errorf("enter didn't keep the filter %q", m.filter)
+	}
+	// drill-down
+	if got := press("\r"); got != actionDescribe {
+		t.Fatalf("enter on a row got %v, want describe", got)
+	}
+	e, _ := m.selectedEntry()
+	output, err := capture(e.describe)
+	m.setDetail("Volumes pvc-12", output, err)
+	want := []string{"pvc-12 Details :", "NAME : pvc-12"}
+	if !reflect.DeepEqual(m.detail, want) {
+		t.Errorf("describe view = %q, want %q", m.detail, want)
+	}
+	if press("q") != actionNone || m.detail != nil {
+		t.Errorf("q didn't close the describe view")
+	}
+	// tabs
+	if got := press("\t"); got != actionRefresh || m.active != 1 || m.filter != "" {
+		t.Errorf("tab got %v, active %d & filter %q, want refresh, 1 & no filter", got, m.active, m.filter)
+	}
+	if press("1"); m.active != 0 {
+		t.Errorf("1 activated tab %d, want 0", m.active)
+	}
+	if press("\x1b[D"); m.active != 1 {
+		t.Errorf("left activated tab %d, want 1", m.active)
+	}
+	if press("\r") != actionNone {
+		t.Errorf("enter on an empty tab described a row")
+	}
+	if press("q") != actionQuit || press("\x03") != actionQuit {
+		t.Errorf("q & ctrl-c didn't quit")
+	}
+}
+
+func TestView(t *testing.T) {
+	m := newTestModel("pvc-1", "pvc-2", "pvc-3", "pvc-4", "pvc-5")
+	m.selected = 4
+	got := m.view(60, 8)
+	want := []string{
+		highlight(" 1 Volumes ") + " 2 PVCs ",
+		"5/5 rows  refreshed 10:30:00, every 1s",
+		"NAME    CAS TYPE",
+		"pvc-2   localpv-zfs",
+		"pvc-3   localpv-zfs",
+		"pvc-4   localpv-zfs",
+		highlight("pvc-5   localpv-zfs"),
+		"←/→ tab  ↑/↓ select  / filter  enter describe  r refresh  q ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("view() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	m.setEntries(1, nil, fmt.Errorf("connection refused"), "", time.Now())
+	m.switchTab(1)
+	if got := m.view(80, 5)[1]; !strings.HasPrefix(got, "0/0 rows  refreshed ") || !strings.HasSuffix(got, "error: connection refused") {
+		t.Errorf("status line of a failed tab = %q", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate(highlight("pvc-123"), 3); got != highlight("pvc") {
+		t.Errorf("truncate() = %q, want %q", got, highlight("pvc"))
+	}
+	if got := truncate("├─zfspv", 4); got != "├─zf" {
+		t.Errorf("truncate() = %q, want ├─zf", got)
+	}
+}
+
+func TestLoadStorage(t *testing.T) {
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(),
+		ZFCS: zfsfake.NewSimpleClientset(&zfs.ZFSNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+			Pools:      []zfs.Pool{{Name: "zfspv", Free: resource.MustParse("6Gi")}},
+		}),
+		LVMCS: lvmfake.NewSimpleClientset(&lvm.LVMNode{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+			VolumeGroups: []lvm.VolumeGroup{
+				{Name: "lvmvg", Size: resource.MustParse("20Gi"), Free: resource.MustParse("12Gi")},
+				{Name: "data", Size: resource.MustParse("10Gi"), Free: resource.MustParse("10Gi")},
+			},
+		}),
+	}
+	entries, err := loadStorage(k)
+	if err != nil {
+		t.Fatalf("loadStorage() error = %v", err)
+	}
+	var got [][]interface{}
+	for _, e := range entries {
+		got = append(got, e.cells)
+		if e.describe == nil {
+			t.Errorf("loadStorage() row %v has no describe view", e.cells)
+		}
+	}
+	want := [][]interface{}{
+		{"node1", util.ZFSCasType + "," + util.LVMCasType, "28.0GiB", "36.0GiB"},
+		{"├─zfspv", util.ZFSCasType, "6.0GiB", "6.0GiB"},
+		{"├─lvmvg", util.LVMCasType, "12.0GiB", "20.0GiB"},
+		{"└─data", util.LVMCasType, "10.0GiB", "10.0GiB"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadStorage() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadPVCs(t *testing.T) {
+	zfsSC, otherSC := "zfs-sc", "gp2"
+	newPVC := func(namespace, name, volume string, sc *string, phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: volume, StorageClassName: sc},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+		if phase == corev1.ClaimBound {
+			pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("4Gi")}
+		}
+		return pvc
+	}
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(
+			&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: zfsSC}, Provisioner: util.ZFSCSIDriver},
+			&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: otherSC}, Provisioner: "ebs.csi.aws.com"},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+				Spec:       corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: util.ZFSCSIDriver}}},
+			},
+			newPVC("db", "data-pg-0", "pvc-1", &zfsSC, corev1.ClaimBound),
+			newPVC("default", "pending", "", &zfsSC, corev1.ClaimPending),
+			newPVC("default", "ebs", "", &otherSC, corev1.ClaimPending),
+			newPVC("default", "no-class", "", nil, corev1.ClaimPending)),
+	}
+	entries, err := loadPVCs(k)
+	if err != nil {
+		t.Fatalf("loadPVCs() error = %v", err)
+	}
+	var got [][]interface{}
+	for _, e := range entries {
+		got = append(got, e.cells)
+	}
+	want := [][]interface{}{
+		{"db", "data-pg-0", "Bound", "pvc-1", "4.0GiB", zfsSC, util.ZFSCasType},
+		{"default", "pending", "Pending", util.NotAvailable, util.NotAvailable, zfsSC, util.ZFSCasType},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadPVCs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,437 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dashboard
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// entry is a row of a tab, describe prints the describe view of the row to
+// stdout & is nil for the rows without one
+type entry struct {
+	cells    []interface{}
+	describe func() error
+}
+
+// tab is a tab of the dashboard listing the entries returned by load
+type tab struct {
+	name    string
+	columns []metav1.TableColumnDefinition
+	load    func(k *client.K8sClient) ([]entry, error)
+}
+
+// action is what the dashboard does after a key is handled
+type action int
+
+const (
+	actionNone action = iota
+	actionQuit
+	actionRefresh
+	actionDescribe
+)
+
+// keyCode identifies the special keys, the printable keys are keyRune
+type keyCode int
+
+const (
+	keyRune keyCode = iota
+	keyEnter
+	keyEsc
+	keyBackspace
+	keyTab
+	keyBackTab
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyPageUp
+	keyPageDown
+	keyCtrlC
+)
+
+// key is a key pressed by the user
+type key struct {
+	code keyCode
+	r    rune
+}
+
+// ansiRegex matches the color escape sequences of the describe views
+var ansiRegex = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// model is the state of the dashboard, it is only changed by the event loop
+type model struct {
+	tabs    []tab
+	entries [][]entry
+	errs    []error
+	// warnings are the messages printed while the tabs were loaded
+	warnings  []string
+	refreshed []time.Time
+	interval  time.Duration
+	active    int
+	// selected is the index of the selected row in the filtered rows &
+	// offset is the index of the first row shown
+	selected  int
+	offset    int
+	filter    string
+	filtering bool
+	// detail has the lines of the describe view being shown, it is nil
+	// while the rows are listed
+	detail      []string
+	detailTitle string
+}
+
+// newModel returns the model of the tabs refreshed every interval
+func newModel(tabs []tab, interval time.Duration) *model {
+	return &model{
+		tabs:      tabs,
+		entries:   make([][]entry, len(tabs)),
+		errs:      make([]error, len(tabs)),
+		warnings:  make([]string, len(tabs)),
+		refreshed: make([]time.Time, len(tabs)),
+		interval:  interval,
+	}
+}
+
+// visible returns the rows of the active tab matching the filter, a row
+// matches if any of its cells contains the filter ignoring the case
+func (m *model) visible() []entry {
+	if m.filter == "" {
+		return m.entries[m.active]
+	}
+	filter := strings.ToLower(m.filter)
+	var found []entry
+	for _, e := range m.entries[m.active] {
+		for _, cell := range e.cells {
+			if strings.Contains(strings.ToLower(fmt.Sprint(cell)), filter) {
+				found = append(found, e)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// setEntries sets the rows of the tab loaded at t, the selection is kept
+// within the rows
+func (m *model) setEntries(tab int, entries []entry, err error, warnings string, t time.Time) {
+	m.entries[tab], m.errs[tab] = entries, err
+	m.warnings[tab], m.refreshed[tab] = warnings, t
+	if tab == m.active {
+		m.clampSelection()
+	}
+}
+
+// setDetail shows the describe view of the selected row
+func (m *model) setDetail(title, text string, err error) {
+	text = ansiRegex.ReplaceAllString(text, "")
+	if err != nil {
+		text += "\n" + err.Error()
+	}
+	m.detailTitle = title
+	m.detail = strings.Split(strings.TrimRight(text, "\n"), "\n")
+	m.offset = 0
+}
+
+// selectedEntry returns the selected row, false if no rows are shown
+func (m *model) selectedEntry() (entry, bool) {
+	rows := m.visible()
+	if m.selected < 0 || m.selected >= len(rows) {
+		return entry{}, false
+	}
+	return rows[m.selected], true
+}
+
+// clampSelection keeps the selected row within the filtered rows
+func (m *model) clampSelection() {
+	if n := len(m.visible()); m.selected >= n {
+		m.selected = n - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+}
+
+// switchTab activates the tab at the offset from the active one
+func (m *model) switchTab(delta int) {
+	m.active = (m.active + delta + len(m.tabs)) % len(m.tabs)
+	m.selected, m.offset = 0, 0
+	m.filter, m.filtering = "", false
+}
+
+// handleKey updates the model for the key & returns what the dashboard has
+// to do next, page is the number of rows shown at once
+func (m *model) handleKey(k key, page int) action {
+	if k.code == keyCtrlC {
+		return actionQuit
+	}
+	if m.detail != nil {
+		return m.handleDetailKey(k, page)
+	}
+	if m.filtering {
+		switch k.code {
+		case keyRune:
+			m.filter += string(k.r)
+		case keyBackspace:
+			if r := []rune(m.filter); len(r) > 0 {
+				m.filter = string(r[:len(r)-1])
+			}
+		case keyEsc:
+			m.filter, m.filtering = "", false
+		case keyEnter:
+			m.filtering = false
+		default:
+			return m.handleListKey(k, page)
+		}
+		m.selected, m.offset = 0, 0
+		return actionNone
+	}
+	if k.code == keyRune {
+		switch k.r {
+		case 'q':
+			return actionQuit
+		case 'r':
+			return actionRefresh
+		case '/':
+			m.filtering = true
+			return actionNone
+		case 'j':
+			k.code = keyDown
+		case 'k':
+			k.code = keyUp
+		case 'h':
+			k.code = keyLeft
+		case 'l':
+			k.code = keyRight
+		default:
+			if k.r >= '1' && int(k.r-'1') < len(m.tabs) {
+				m.switchTab(int(k.r-'1') - m.active)
+				return actionRefresh
+			}
+		}
+	}
+	if k.code == keyEsc {
+		m.filter = ""
+		m.clampSelection()
+		return actionNone
+	}
+	return m.handleListKey(k, page)
+}
+
+// handleListKey handles the keys moving through the rows & the tabs
+func (m *model) handleListKey(k key, page int) action {
+	switch k.code {
+	case keyTab, keyRight:
+		m.switchTab(1)
+		return actionRefresh
+	case keyBackTab, keyLeft:
+		m.switchTab(-1)
+		return actionRefresh
+	case keyUp:
+		m.selected--
+	case keyDown:
+		m.selected++
+	case keyPageUp:
+		m.selected -= page
+	case keyPageDown:
+		m.selected += page
+	case keyEnter:
+		if e, ok := m.selectedEntry(); ok && e.describe != nil {
+			return actionDescribe
+		}
+	}
+	m.clampSelection()
+	return actionNone
+}
+
+// handleDetailKey handles the keys scrolling & closing the describe view
+func (m *model) handleDetailKey(k key, page int) action {
+	switch {
+	case k.code == keyEsc || k.code == keyBackspace || (k.code == keyRune && k.r == 'q'):
+		m.detail, m.offset = nil, 0
+		return actionNone
+	case k.code == keyUp || (k.code == keyRune && k.r == 'k'):
+		m.offset--
+	case k.code == keyDown || (k.code == keyRune && k.r == 'j'):
+		m.offset++
+	case k.code == keyPageUp:
+		m.offset -= page
+	case k.code == keyPageDown:
+		m.offset += page
+	}
+	if last := len(m.detail) - page; m.offset > last {
+		m.offset = last
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+	return actionNone
+}
+
+// view returns the lines of the screen of the width & height, the first two
+// lines are the tabs & the status line, the last one is the help line
+func (m *model) view(width, height int) []string {
+	page := height - 3
+	lines := []string{m.tabBar(), m.statusLine()}
+	var body []string
+	if m.detail != nil {
+		body = m.detailLines(page)
+	} else {
+		body = m.listLines(page)
+	}
+	lines = append(lines, body...)
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, m.helpLine())
+	for i := range lines {
+		lines[i] = truncate(lines[i], width)
+	}
+	return lines
+}
+
+// tabBar returns the line of the tab names, the active one is highlighted
+func (m *model) tabBar() string {
+	var b strings.Builder
+	for i, t := range m.tabs {
+		name := fmt.Sprintf(" %d %s ", i+1, t.name)
+		if i == m.active {
+			name = highlight(name)
+		}
+		b.WriteString(name)
+	}
+	return b.String()
+}
+
+// statusLine returns the filter, the number of rows & the refresh time of
+// the active tab, or the error & the warnings of its last refresh
+func (m *model) statusLine() string {
+	if m.detail != nil {
+		return m.detailTitle
+	}
+	var parts []string
+	if m.filtering || m.filter != "" {
+		parts = append(parts, "filter: /"+m.filter)
+	}
+	parts = append(parts, fmt.Sprintf("%d/%d rows", len(m.visible()), len(m.entries[m.active])))
+	if refreshed := m.refreshed[m.active]; !refreshed.IsZero() {
+		parts = append(parts, fmt.Sprintf("refreshed %s, every %s", refreshed.Format("15:04:05"), m.interval))
+	}
+	if err := m.errs[m.active]; err != nil {
+		parts = append(parts, "error: "+err.Error())
+	} else if warnings := strings.TrimSpace(m.warnings[m.active]); warnings != "" {
+		parts = append(parts, "warning: "+strings.SplitN(warnings, "\n", 2)[0])
+	}
+	return strings.Join(parts, "  ")
+}
+
+// helpLine returns the keys of the current view
+func (m *model) helpLine() string {
+	switch {
+	case m.detail != nil:
+		return "↑/↓ scroll  pgup/pgdn page  esc back  ctrl-c quit"
+	case m.filtering:
+		return "type to filter  enter keep filter  esc clear filter"
+	default:
+		return "←/→ tab  ↑/↓ select  / filter  enter describe  r refresh  q quit"
+	}
+}
+
+// listLines returns the table of the filtered rows scrolled to the selected
+// row, the selected row is highlighted
+func (m *model) listLines(page int) []string {
+	rows := m.visible()
+	if len(rows) == 0 {
+		if m.errs[m.active] != nil {
+			return nil
+		}
+		return []string{"No resources found"}
+	}
+	// the header takes a line of the page
+	page--
+	if page < 1 {
+		page = 1
+	}
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+page {
+		m.offset = m.selected - page + 1
+	}
+	// the table of all the rows is printed so that the columns don't change
+	// their widths while scrolling
+	tableRows := make([]metav1.TableRow, len(rows))
+	for i, e := range rows {
+		tableRows[i] = metav1.TableRow{Cells: e.cells}
+	}
+	var buf bytes.Buffer
+	util.FprintTable(&buf, m.tabs[m.active].columns, tableRows, printers.PrintOptions{})
+	table := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	lines := []string{table[0]}
+	for i := m.offset; i < len(rows) && i < m.offset+page && i+1 < len(table); i++ {
+		line := table[i+1]
+		if i == m.selected {
+			line = highlight(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// detailLines returns the page of the describe view at the offset
+func (m *model) detailLines(page int) []string {
+	end := m.offset + page
+	if end > len(m.detail) {
+		end = len(m.detail)
+	}
+	return m.detail[m.offset:end]
+}
+
+// highlight shows the text in reverse video
+func highlight(s string) string {
+	return "\x1b[7m" + s + "\x1b[0m"
+}
+
+// truncate cuts the line to the width, the escape sequences aren't counted
+func truncate(line string, width int) string {
+	var b strings.Builder
+	visible := 0
+	escaped := false
+	for _, r := range line {
+		switch {
+		case r == '\x1b':
+			escaped = true
+		case escaped:
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				escaped = false
+			}
+		case visible >= width:
+			continue
+		default:
+			visible++
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/capacity"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/clusterinfo"
+	"github.com/openebs/openebsctl/pkg/persistentvolumeclaim"
+	"github.com/openebs/openebsctl/pkg/storage"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/openebs/openebsctl/pkg/volume"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// firstElemPrefix & lastElemPrefix draw the pools of a node as a tree
+	firstElemPrefix = `├─`
+	lastElemPrefix  = `└─`
+)
+
+var (
+	// storageColumnDefinitions stores the table headers of the storage tab
+	storageColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Free", Type: "string"},
+		{Name: "Total", Type: "string"},
+	}
+	// volumeColumnDefinitions stores the table headers of the volumes tab
+	volumeColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Status", Type: "string"},
+		{Name: "Capacity", Type: "string"},
+		{Name: "Storage Class", Type: "string"},
+		{Name: "PVC", Type: "string"},
+		{Name: "Node", Type: "string"},
+	}
+	// pvcColumnDefinitions stores the table headers of the PVCs tab
+	pvcColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Namespace", Type: "string"},
+		{Name: "Name", Type: "string"},
+		{Name: "Status", Type: "string"},
+		{Name: "Volume", Type: "string"},
+		{Name: "Capacity", Type: "string"},
+		{Name: "Storage Class", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+	}
+)
+
+// tabs returns the tabs of the dashboard in the order they are shown
+func tabs() []tab {
+	return []tab{
+		{name: "Engines", columns: util.ClusterInfoColumnDefinitions, load: loadEngines},
+		{name: "Storage", columns: storageColumnDefinitions, load: loadStorage},
+		{name: "Volumes", columns: volumeColumnDefinitions, load: loadVolumes},
+		{name: "PVCs", columns: pvcColumnDefinitions, load: loadPVCs},
+	}
+}
+
+// loadEngines returns the rows of the installed engines like cluster-info,
+// their describe view has the status of their components
+func loadEngines(k *client.K8sClient) ([]entry, error) {
+	var entries []entry
+	for _, e := range clusterinfo.GetEngineStatuses(k) {
+		e := e
+		entries = append(entries, entry{
+			cells:    []interface{}{e.CasType, e.Namespace, e.Version, e.Working, e.Status},
+			describe: func() error { return printComponents(e) },
+		})
+	}
+	return entries, nil
+}
+
+// printComponents prints the status & version of the components of the engine
+func printComponents(e clusterinfo.EngineStatus) error {
+	fmt.Printf("%s Components :\n\n", e.CasType)
+	var rows []metav1.TableRow
+	for _, c := range e.Components {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{c.Name, orNotAvailable(c.Namespace), orNotAvailable(c.Status), orNotAvailable(c.Version)}})
+	}
+	util.TablePrinter([]metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Namespace", Type: "string"},
+		{Name: "Status", Type: "string"},
+		{Name: "Version", Type: "string"},
+	}, rows, printers.PrintOptions{})
+	return nil
+}
+
+// loadStorage returns the nodes & their ZFS pools, LVM volume groups &
+// Mayastor DiskPools as a tree, the engines which aren't installed are
+// skipped
+func loadStorage(k *client.K8sClient) ([]entry, error) {
+	type pool struct {
+		name, casType string
+		free, total   int64
+		describe      func() error
+	}
+	nodePools := make(map[string][]pool)
+	nodeCasTypes := make(map[string][]string)
+	for _, f := range capacity.CasList() {
+		usages, err := f(k, nil)
+		if err != nil {
+			continue
+		}
+		for _, n := range usages {
+			node, casType := n.Node(), n.CasType()
+			nodeCasTypes[node] = append(nodeCasTypes[node], casType)
+			for _, p := range n.Pools() {
+				nodePools[node] = append(nodePools[node], pool{name: p.Name(), casType: casType, free: p.Free(), total: p.Total(),
+					describe: func() error { return storage.Describe([]string{node}, "", casType, "") }})
+			}
+		}
+	}
+	if dsps, err := k.GetDiskPools(nil, "", ""); err == nil {
+		for _, dsp := range dsps {
+			name := dsp.Name
+			nodePools[dsp.Spec.Node] = append(nodePools[dsp.Spec.Node], pool{name: name, casType: util.MayastorCasType,
+				free: dsp.Status.Available, total: dsp.Status.Capacity,
+				describe: func() error { return storage.Describe([]string{name}, "", util.MayastorCasType, "") }})
+		}
+	}
+	nodes := make([]string, 0, len(nodePools))
+	for node := range nodePools {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	var entries []entry
+	for _, node := range nodes {
+		node := node
+		var free, total int64
+		for _, p := range nodePools[node] {
+			free += p.free
+			total += p.total
+		}
+		casTypes := nodeCasTypes[node]
+		e := entry{cells: []interface{}{node, strings.Join(casTypes, ","), iBytes(free), iBytes(total)}}
+		if len(casTypes) != 0 {
+			// the node is described by the describe of all the engines
+			e.describe = func() error { return storage.Describe([]string{node}, "", "", "") }
+		}
+		entries = append(entries, e)
+		for i, p := range nodePools[node] {
+			prefix := firstElemPrefix
+			if i == len(nodePools[node])-1 {
+				prefix = lastElemPrefix
+			}
+			entries = append(entries, entry{cells: []interface{}{prefix + p.name, p.casType, iBytes(p.free), iBytes(p.total)}, describe: p.describe})
+		}
+	}
+	return entries, nil
+}
+
+// loadVolumes returns the volumes of all the engines, described like
+// describe volume
+func loadVolumes(k *client.K8sClient) ([]entry, error) {
+	volumes, err := volume.List(k, util.VolumeFilter{})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+	entries := make([]entry, 0, len(volumes))
+	for _, vol := range volumes {
+		name := vol.Name
+		pvc := util.NotAvailable
+		if vol.PVC != "" {
+			pvc = vol.PVCNamespace + "/" + vol.PVC
+		}
+		entries = append(entries, entry{
+			cells:    []interface{}{vol.Name, vol.CasType, orNotAvailable(vol.Status), vol.Capacity, vol.StorageClass, pvc, orNotAvailable(volume.NodeOf(vol))},
+			describe: func() error { return volume.Describe([]string{name}, "", "") },
+		})
+	}
+	return entries, nil
+}
+
+// loadPVCs returns the PVCs of all the namespaces bound to OpenEBS volumes
+// or pending on OpenEBS storage classes, described like describe pvc
+func loadPVCs(k *client.K8sClient) ([]entry, error) {
+	pvcs, err := k.GetPVCs("", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	pvs, err := k.GetPVs(nil, "", "")
+	if err != nil {
+		return nil, err
+	}
+	pvCasTypes := make(map[string]string)
+	for i := range pvs.Items {
+		pvCasTypes[pvs.Items[i].Name] = util.NormalizeCasType(util.GetCasTypeFromPV(&pvs.Items[i]))
+	}
+	// the storage classes of the pending PVCs are read once
+	scCasTypes := make(map[string]string)
+	scCasType := func(name string) string {
+		if casType, ok := scCasTypes[name]; ok {
+			return casType
+		}
+		casType := util.Unknown
+		if sc, err := k.GetSC(name); err == nil {
+			casType = util.NormalizeCasType(util.GetCasTypeFromSC(sc))
+		}
+		scCasTypes[name] = casType
+		return casType
+	}
+	var entries []entry
+	for _, pvc := range pvcs.Items {
+		name, namespace := pvc.Name, pvc.Namespace
+		sc := ""
+		if pvc.Spec.StorageClassName != nil {
+			sc = *pvc.Spec.StorageClassName
+		}
+		casType, ok := pvCasTypes[pvc.Spec.VolumeName]
+		if !ok {
+			casType = scCasType(sc)
+		}
+		if !util.IsValidCasType(casType) {
+			continue
+		}
+		capacity := util.NotAvailable
+		if q, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			capacity = util.ConvertToIBytes(q.String())
+		}
+		entries = append(entries, entry{
+			cells:    []interface{}{namespace, name, string(pvc.Status.Phase), orNotAvailable(pvc.Spec.VolumeName), capacity, orNotAvailable(sc), casType},
+			describe: func() error { return persistentvolumeclaim.Describe([]string{name}, namespace, "", "") },
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].cells, entries[j].cells
+		if a[0] != b[0] {
+			return a[0].(string) < b[0].(string)
+		}
+		return a[1].(string) < b[1].(string)
+	})
+	return entries, nil
+}
+
+// orNotAvailable returns N/A for the empty values
+func orNotAvailable(s string) string {
+	if s == "" {
+		return util.NotAvailable
+	}
+	return s
+}
+
+// iBytes humanizes the bytes to IBytes format
+func iBytes(bytes int64) string {
+	return util.ConvertToIBytes(strconv.FormatInt(bytes, 10))
+}
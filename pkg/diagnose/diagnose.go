@@ -0,0 +1,329 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// diagnosisKind is the kind of the machine-readable diagnosis
+	diagnosisKind = "DiagnosisList"
+
+	// volumeReadyState is the state of a ready ZFSVolume or LVMVolume
+	volumeReadyState = "Ready"
+	// volumeFailedState is the state of a ZFSVolume or LVMVolume which failed to be created
+	volumeFailedState = "Failed"
+)
+
+// severityRank ranks the causes, the most likely ones come first
+var severityRank = map[string]int{util.SeverityError: 0, util.SeverityWarning: 1, util.SeverityInfo: 2}
+
+const diagnosisTemplate = `
+{{.PVC}} Diagnosis :
+-----------------
+PVC            : {{.PVC}}
+NAMESPACE      : {{.Namespace}}
+PHASE          : {{.Phase}}
+STORAGE CLASS  : {{.StorageClass}}
+PROVISIONER    : {{.Provisioner}}
+CAS TYPE       : {{.CasType}}
+PV             : {{.PV}}
+VOLUME         : {{.Volume}}
+VOLUME STATE   : {{.VolumeState}}
+NODE           : {{.Node}}
+`
+
+// addCause adds a likely cause of the PVC being stuck to the diagnosis
+func addCause(d *util.Diagnosis, severity, resource, format string, args ...interface{}) {
+	d.Causes = append(d.Causes, util.Cause{Severity: severity, Resource: resource, Message: fmt.Sprintf(format, args...)})
+}
+
+// Diagnose walks the chain of the PVC, i.e. its StorageClass, PV, the volume
+// of its engine & the CSI pods, & shows the ranked likely causes of it being
+// stuck along with its events
+func Diagnose(pvcName, namespace, output string) error {
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	d, err := diagnosePVC(k, pvcName, namespace)
+	if err != nil {
+		return err
+	}
+	if util.IsStructuredOutput(output) {
+		return util.PrintList(output, diagnosisKind, []interface{}{*d})
+	}
+	if err = util.PrintByTemplate("diagnosis", diagnosisTemplate, d); err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Println("Likely causes")
+	fmt.Println("-------------")
+	if len(d.Causes) == 0 {
+		fmt.Println("No problems found")
+	} else {
+		var rows []metav1.TableRow
+		for i, c := range d.Causes {
+			rows = append(rows, metav1.TableRow{Cells: []interface{}{i + 1, c.Severity, c.Resource, c.Message}})
+		}
+		util.TablePrinter(util.CauseColumnDefinitions, rows, printers.PrintOptions{})
+	}
+	if len(d.Events) > 0 {
+		fmt.Println()
+		fmt.Println("Events")
+		fmt.Println("------")
+		util.TablePrinter(util.EventColumnDefinitions, util.EventRows(d.Events), printers.PrintOptions{})
+	}
+	return nil
+}
+
+// diagnosePVC walks the chain PVC → StorageClass → PV → ZFSVolume/LVMVolume →
+// CSI pods & returns the likely causes of the PVC being stuck, ranked by
+// their severity
+func diagnosePVC(k *client.K8sClient, pvcName, namespace string) (*util.Diagnosis, error) {
+	// 1. Get the PVC
+	pvc, err := k.GetPVC(pvcName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	d := &util.Diagnosis{
+		PVC:          pvc.Name,
+		Namespace:    pvc.Namespace,
+		Phase:        string(pvc.Status.Phase),
+		StorageClass: util.NotAvailable,
+		Provisioner:  util.NotAvailable,
+		CasType:      util.Unknown,
+		PV:           util.NotAvailable,
+		Volume:       util.NotAvailable,
+		VolumeState:  util.NotAvailable,
+		Node:         util.NotAvailable,
+	}
+	pvcRef := "pvc/" + pvc.Name
+	if pvc.Status.Phase == corev1.ClaimLost {
+		addCause(d, util.SeverityError, pvcRef, "the pvc is lost, its pv %s is deleted", pvc.Spec.VolumeName)
+	}
+	// 2. Check the StorageClass & its provisioner
+	sc := checkStorageClass(k, d, pvc)
+	// 3. Check the PV
+	var pv *corev1.PersistentVolume
+	if pvc.Spec.VolumeName != "" {
+		d.PV = pvc.Spec.VolumeName
+		if pv, err = k.GetPV(pvc.Spec.VolumeName); err != nil {
+			addCause(d, util.SeverityError, "pv/"+pvc.Spec.VolumeName, "the pv of the pvc is not found")
+		}
+	}
+	if d.CasType = util.GetCasType(pv, sc); d.CasType == util.Unknown && sc != nil && sc.Provisioner == util.LocalPVHostpathProvisioner {
+		d.CasType = util.LocalPvHostpathCasType
+	}
+	// 4. Check the controller of the engine
+	if component, ok := util.CasTypeAndComponentNameMap[d.CasType]; ok {
+		checkPods(k, d, component, "")
+	}
+	// 5. Check the volume of the engine, its node & the node's pool
+	if check, ok := CasListMap()[d.CasType]; ok && sc != nil {
+		check(k, d, pvc, sc)
+	} else if d.Node == util.NotAvailable && pvc.Annotations[util.SelectedNodeKey] != "" {
+		d.Node = pvc.Annotations[util.SelectedNodeKey]
+	}
+	if sc != nil && d.Node != util.NotAvailable {
+		checkTopology(k, d, sc, d.Node)
+	}
+	if pvc.Status.Phase == corev1.ClaimPending && d.Node == util.NotAvailable && sc != nil &&
+		sc.VolumeBindingMode != nil && *sc.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+		addCause(d, util.SeverityInfo, pvcRef, "storageclass %s waits for the first consumer, the pvc is bound once a pod using it is scheduled", sc.Name)
+	}
+	// 6. Add the events of the PVC & the PV, the warnings are likely causes too
+	addEvents(k, d, pvc)
+	// 7. Rank the causes
+	sort.SliceStable(d.Causes, func(i, j int) bool {
+		return severityRank[d.Causes[i].Severity] < severityRank[d.Causes[j].Severity]
+	})
+	return d, nil
+}
+
+// checkStorageClass checks the StorageClass of the PVC & its provisioner, it
+// returns nil if the StorageClass can't be found
+func checkStorageClass(k *client.K8sClient, d *util.Diagnosis, pvc *corev1.PersistentVolumeClaim) *storagev1.StorageClass {
+	if pvc.Spec.StorageClassName == nil {
+		addCause(d, util.SeverityError, "pvc/"+pvc.Name, "the pvc has no storageclass & there is no default storageclass to set it")
+		return nil
+	}
+	if *pvc.Spec.StorageClassName == "" {
+		// the empty storageclass turns off the dynamic provisioning
+		checkStaticPV(k, d, pvc)
+		return nil
+	}
+	d.StorageClass = *pvc.Spec.StorageClassName
+	sc, err := k.GetSC(*pvc.Spec.StorageClassName)
+	if err != nil {
+		addCause(d, util.SeverityError, "storageclass/"+d.StorageClass, "the storageclass of the pvc is not found")
+		return nil
+	}
+	d.Provisioner = sc.Provisioner
+	if _, ok := util.ProvsionerAndCasTypeMap[sc.Provisioner]; ok || sc.Provisioner == util.LocalPVHostpathProvisioner {
+		return sc
+	}
+	known := []string{util.LocalPVHostpathProvisioner}
+	for provisioner := range util.ProvsionerAndCasTypeMap {
+		known = append(known, provisioner)
+	}
+	sort.Strings(known)
+	if strings.Contains(sc.Provisioner, "openebs") {
+		addCause(d, util.SeverityError, "storageclass/"+sc.Name, "wrong provisioner name %s, the OpenEBS provisioners are %s", sc.Provisioner, strings.Join(known, ", "))
+	} else {
+		addCause(d, util.SeverityWarning, "storageclass/"+sc.Name, "the provisioner %s is not an OpenEBS provisioner", sc.Provisioner)
+	}
+	return sc
+}
+
+// checkStaticPV checks that a pending PVC with the empty storageclass, which
+// is only bound to a pre-provisioned PV, has an available PV matching it
+func checkStaticPV(k *client.K8sClient, d *util.Diagnosis, pvc *corev1.PersistentVolumeClaim) {
+	if pvc.Spec.VolumeName != "" || pvc.Status.Phase != corev1.ClaimPending {
+		return
+	}
+	pvs, err := k.GetPVs(nil, "", "")
+	if err != nil {
+		addCause(d, util.SeverityWarning, "pvc/"+pvc.Name, "failed to get the pvs: %v", err)
+		return
+	}
+	for i := range pvs.Items {
+		if staticPVMatches(&pvs.Items[i], pvc) {
+			return
+		}
+	}
+	addCause(d, util.SeverityError, "pvc/"+pvc.Name, "the pvc has an empty storageclass & expects a pre-provisioned pv, no available pv without a storageclass matches its size, access modes & selector")
+}
+
+// staticPVMatches returns true if the PV without a storageclass can be bound
+// to the PVC with the empty storageclass
+func staticPVMatches(pv *corev1.PersistentVolume, pvc *corev1.PersistentVolumeClaim) bool {
+	if pv.Spec.StorageClassName != "" || pv.Status.Phase != corev1.VolumeAvailable {
+		return false
+	}
+	if ref := pv.Spec.ClaimRef; ref != nil && (ref.Namespace != pvc.Namespace || ref.Name != pvc.Name) {
+		return false
+	}
+	if pv.Spec.Capacity.Storage().Cmp(*pvc.Spec.Resources.Requests.Storage()) < 0 {
+		return false
+	}
+	for _, mode := range pvc.Spec.AccessModes {
+		found := false
+		for _, m := range pv.Spec.AccessModes {
+			found = found || m == mode
+		}
+		if !found {
+			return false
+		}
+	}
+	if pvc.Spec.Selector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(pvc.Spec.Selector)
+		if err != nil || !sel.Matches(labels.Set(pv.Labels)) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPods checks that the pods of the component are running, only on the
+// node if it isn't empty
+func checkPods(k *client.K8sClient, d *util.Diagnosis, component, node string) {
+	var fieldSelector, where string
+	if node != "" {
+		fieldSelector = "spec.nodeName=" + node
+		where = " on node " + node
+	}
+	pods, err := k.GetPods("openebs.io/component-name="+component, fieldSelector, "")
+	if err != nil {
+		addCause(d, util.SeverityWarning, component, "failed to get the %s pods: %v", component, err)
+		return
+	}
+	var running int
+	for _, pod := range pods.Items {
+		if node != "" && pod.Spec.NodeName != node {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodRunning {
+			running++
+		} else {
+			addCause(d, util.SeverityError, "pod/"+pod.Name, "the %s pod%s is %s", component, where, pod.Status.Phase)
+		}
+	}
+	if running == 0 {
+		addCause(d, util.SeverityError, component, "the %s is not running%s", component, where)
+	}
+}
+
+// checkTopology checks that the node is in the allowed topologies of the
+// StorageClass
+func checkTopology(k *client.K8sClient, d *util.Diagnosis, sc *storagev1.StorageClass, node string) {
+	if len(sc.AllowedTopologies) == 0 {
+		return
+	}
+	nodes, err := k.GetNodes([]string{node}, "", "")
+	if err != nil || len(nodes.Items) == 0 {
+		addCause(d, util.SeverityError, "node/"+node, "the node of the volume is not found")
+		return
+	}
+	if !util.TopologyMatches(sc.AllowedTopologies, nodes.Items[0].Labels) {
+		addCause(d, util.SeverityError, "storageclass/"+sc.Name, "topology mismatch, node %s is not in the allowed topologies of the storageclass", node)
+	}
+}
+
+// addEvents adds the events of the PVC & of its PV, the warnings are added
+// as likely causes too
+func addEvents(k *client.K8sClient, d *util.Diagnosis, pvc *corev1.PersistentVolumeClaim) {
+	objects := []corev1.ObjectReference{{Kind: "PersistentVolumeClaim", Name: pvc.Name, Namespace: pvc.Namespace}}
+	if pvc.Spec.VolumeName != "" {
+		objects = append(objects, corev1.ObjectReference{Kind: "PersistentVolume", Name: pvc.Spec.VolumeName})
+	}
+	events, err := k.GetObjectEvents(objects...)
+	if err != nil {
+		addCause(d, util.SeverityWarning, "pvc/"+pvc.Name, "failed to get the events: %v", err)
+		return
+	}
+	d.Events = util.NewEvents(events)
+	seen := make(map[string]bool)
+	for _, e := range d.Events {
+		if e.Type != corev1.EventTypeWarning || seen[e.Reason+e.Message] {
+			continue
+		}
+		seen[e.Reason+e.Message] = true
+		addCause(d, util.SeverityWarning, strings.ToLower(e.Object), "%s: %s", e.Reason, e.Message)
+	}
+}
+
+// CasListMap returns a map of cas-types to the functions checking the volumes
+// of their engines
+func CasListMap() map[string]func(*client.K8sClient, *util.Diagnosis, *corev1.PersistentVolumeClaim, *storagev1.StorageClass) {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient, *util.Diagnosis, *corev1.PersistentVolumeClaim, *storagev1.StorageClass){
+		util.ZFSCasType: DiagnoseZFSVolume,
+		util.LVMCasType: DiagnoseLVMVolume,
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"reflect"
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDiagnosePVC(t *testing.T) {
+	zfsParams := map[string]string{util.ZFSPoolNameParam: "zfspv"}
+	runningPods := []runtime.Object{
+		newPod("zfs-controller-0", util.ZFSLocalPVcsiControllerLabelValue, "node2", corev1.PodRunning),
+		newPod("zfs-node-1", util.ZFSLocalPVcsiNodeLabelValue, "node1", corev1.PodRunning),
+		newPod("lvm-controller-0", util.LVMLocalPVcsiControllerLabelValue, "node2", corev1.PodRunning),
+		newPod("lvm-node-1", util.LVMLocalPVcsiNodeLabelValue, "node1", corev1.PodRunning),
+	}
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		pvc     string
+		want    []util.Cause
+	}{
+		{
+			"healthy bound pvc",
+			[]runtime.Object{newSC("zfs-sc", util.ZFSCSIDriver, zfsParams), newPVC("zfs-bound", "zfs-sc", "pvc-zfs", "", corev1.ClaimBound), &zfsPV1},
+			"zfs-bound",
+			nil,
+		},
+		{
+			"missing pool on the selected node",
+			[]runtime.Object{newSC("zfs-sc", util.ZFSCSIDriver, map[string]string{util.ZFSPoolNameParam: "tank"}), newPVC("zfs-pending", "zfs-sc", "", "node1", corev1.ClaimPending)},
+			"zfs-pending",
+			[]util.Cause{{Severity: util.SeverityError, Resource: "zfsnode/node1", Message: "missing pool tank on node node1, the pools of the node are: zfspv"}},
+		},
+		{
+			"pool too small with a warning event",
+			[]runtime.Object{newSC("zfs-sc", util.ZFSCSIDriver, zfsParams), newPVC("zfs-pending", "zfs-sc", "", "node1", corev1.ClaimPending), &provisioningFailedEvent},
+			"zfs-pending",
+			[]util.Cause{
+				{Severity: util.SeverityError, Resource: "zfsnode/node1", Message: "pool zfspv on node node1 is too small, it has 2.0GiB free & the pvc requests 4.0GiB"},
+				{Severity: util.SeverityWarning, Resource: "persistentvolumeclaim/zfs-pending", Message: "ProvisioningFailed: failed to provision volume"},
+			},
+		},
+		{
+			"thin pool doesn't need room",
+			[]runtime.Object{newSC("zfs-sc", util.ZFSCSIDriver, map[string]string{util.ZFSPoolNameParam: "zfspv", util.ZFSThinProvisionParam: "yes"}), newPVC("zfs-pending", "zfs-sc", "", "node1", corev1.ClaimPending)},
+			"zfs-pending",
+			nil,
+		},
+		{
+			"topology mismatch",
+			[]runtime.Object{
+				newSC("zfs-sc", util.ZFSCSIDriver, zfsParams, corev1.TopologySelectorTerm{
+					MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{Key: "kubernetes.io/hostname", Values: []string{"node2"}}},
+				}),
+				newPVC("zfs-bound", "zfs-sc", "pvc-zfs", "", corev1.ClaimBound), &zfsPV1,
+			},
+			"zfs-bound",
+			[]util.Cause{{Severity: util.SeverityError, Resource: "storageclass/zfs-sc", Message: "topology mismatch, node node1 is not in the allowed topologies of the storageclass"}},
+		},
+		{
+			"volume group too small",
+			[]runtime.Object{newSC("lvm-sc", util.LocalPVLVMCSIDriver, map[string]string{util.LVMVolGroupParam: "lvmvg"}), newPVC("lvm-pending", "lvm-sc", "", "node1", corev1.ClaimPending)},
+			"lvm-pending",
+			[]util.Cause{{Severity: util.SeverityError, Resource: "lvmnode/node1", Message: "volume group lvmvg on node node1 is too small, it has at most 1.0GiB free & the pvc requests 4.0GiB"}},
+		},
+		{
+			"failed lvm volume",
+			[]runtime.Object{newSC("lvm-sc", util.LocalPVLVMCSIDriver, map[string]string{util.LVMVolGroupParam: "lvmvg"}), newPVC("lvm-failed", "lvm-sc", "", "", corev1.ClaimPending)},
+			"lvm-failed",
+			[]util.Cause{{Severity: util.SeverityError, Resource: "lvmvolume/pvc-lvm-failed-uid", Message: "the volume failed to be created on node node1: insufficient space"}},
+		},
+		{
+			"wrong provisioner name",
+			[]runtime.Object{newSC("zfs-sc", "zfs.csi.openebs", zfsParams), newPVC("zfs-pending", "zfs-sc", "", "", corev1.ClaimPending)},
+			"zfs-pending",
+			[]util.Cause{
+				{Severity: util.SeverityError, Resource: "storageclass/zfs-sc", Message: "wrong provisioner name zfs.csi.openebs, the OpenEBS provisioners are io.openebs.csi-mayastor, local.csi.openebs.io, openebs.io/local, zfs.csi.openebs.io"},
+				{Severity: util.SeverityInfo, Resource: "pvc/zfs-pending", Message: "storageclass zfs-sc waits for the first consumer, the pvc is bound once a pod using it is scheduled"},
+			},
+		},
+		{
+			"storageclass not found",
+			[]runtime.Object{newPVC("zfs-pending", "missing-sc", "", "", corev1.ClaimPending)},
+			"zfs-pending",
+			[]util.Cause{{Severity: util.SeverityError, Resource: "storageclass/missing-sc", Message: "the storageclass of the pvc is not found"}},
+		},
+		{
+			"no storageclass & no default storageclass",
+			[]runtime.Object{noClassPVC},
+			"no-class",
+			[]util.Cause{{Severity: util.SeverityError, Resource: "pvc/no-class", Message: "the pvc has no storageclass & there is no default storageclass to set it"}},
+		},
+		{
+			"empty storageclass without a matching pv",
+			[]runtime.Object{newPVC("static", "", "", "", corev1.ClaimPending), newStaticPV("static-small", "1Gi")},
+			"static",
+			[]util.Cause{{Severity: util.SeverityError, Resource: "pvc/static", Message: "the pvc has an empty storageclass & expects a pre-provisioned pv, no available pv without a storageclass matches its size, access modes & selector"}},
+		},
+		{
+			"empty storageclass with a matching pv",
+			[]runtime.Object{newPVC("static", "", "", "", corev1.ClaimPending), newStaticPV("static-small", "1Gi"), newStaticPV("static-big", "8Gi")},
+			"static",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				K8sCS: k8sfake.NewSimpleClientset(append(append([]runtime.Object{&node1}, runningPods...), tt.objects...)...),
+				ZFCS:  zfsfake.NewSimpleClientset(&zfsVol1, &zfsNode1),
+				LVMCS: lvmfake.NewSimpleClientset(&lvmNode1, &failedLVMVol1),
+			}
+			got, err := diagnosePVC(k, tt.pvc, "default")
+			if err != nil {
+				t.Fatalf("diagnosePVC() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Causes, tt.want) {
+				t.Errorf("diagnosePVC() causes = %+v, want %+v", got.Causes, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagnosePVCControllerNotRunning(t *testing.T) {
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(
+			newSC("zfs-sc", util.ZFSCSIDriver, map[string]string{util.ZFSPoolNameParam: "zfspv"}),
+			newPVC("zfs-bound", "zfs-sc", "pvc-zfs", "", corev1.ClaimBound), &zfsPV1,
+			newPod("zfs-controller-0", util.ZFSLocalPVcsiControllerLabelValue, "node2", corev1.PodPending),
+			newPod("zfs-node-1", util.ZFSLocalPVcsiNodeLabelValue, "node1", corev1.PodRunning),
+		),
+		ZFCS: zfsfake.NewSimpleClientset(&zfsVol1, &zfsNode1),
+	}
+	got, err := diagnosePVC(k, "zfs-bound", "default")
+	if err != nil {
+		t.Fatalf("diagnosePVC() error = %v", err)
+	}
+	want := []util.Cause{
+		{Severity: util.SeverityError, Resource: "pod/zfs-controller-0", Message: "the openebs-zfs-controller pod is Pending"},
+		{Severity: util.SeverityError, Resource: util.ZFSLocalPVcsiControllerLabelValue, Message: "the openebs-zfs-controller is not running"},
+	}
+	if !reflect.DeepEqual(got.Causes, want) {
+		t.Errorf("diagnosePVC() causes = %+v, want %+v", got.Causes, want)
+	}
+	if got.Node != "node1" || got.VolumeState != "Ready" || got.CasType != util.ZFSCasType {
+		t.Errorf("diagnosePVC() = %+v, want the ready ZFS volume on node1", got)
+	}
+}
+
+func TestDiagnosePVCNotFound(t *testing.T) {
+	k := &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset()}
+	if _, err := diagnosePVC(k, "missing", "default"); err == nil {
+		t.Errorf("diagnosePVC() error = nil, want an error")
+	}
+}
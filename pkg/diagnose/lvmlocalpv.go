@@ -0,0 +1,104 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"regexp"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// DiagnoseLVMVolume checks the LVMVolume of the PVC, the LVM node pod on its
+// node & the volume groups of the StorageClass on that node
+func DiagnoseLVMVolume(k *client.K8sClient, d *util.Diagnosis, pvc *corev1.PersistentVolumeClaim, sc *storagev1.StorageClass) {
+	// 1. Check the LVMVolume, it is created by the controller as pvc-<uid>
+	name := volumeName(pvc)
+	lvols, _, err := k.GetLVMvol([]string{name}, util.List, "", "", util.MapOptions{})
+	created := err == nil && len(lvols.Items) > 0
+	switch {
+	case err != nil:
+		addCause(d, util.SeverityWarning, "lvmvolume/"+name, "failed to get the lvmvolume: %v", err)
+	case created:
+		lv := lvols.Items[0]
+		d.Volume, d.VolumeState, d.Node = lv.Name, lv.Status.State, lv.Spec.OwnerNodeID
+		if lv.Status.State == volumeFailedState {
+			msg := "unknown error"
+			if lv.Status.Error != nil {
+				msg = lv.Status.Error.Message
+			}
+			addCause(d, util.SeverityError, "lvmvolume/"+lv.Name, "the volume failed to be created on node %s: %s", lv.Spec.OwnerNodeID, msg)
+		}
+	case pvc.Spec.VolumeName != "":
+		addCause(d, util.SeverityError, "lvmvolume/"+name, "the lvmvolume of the pv is not found")
+	}
+	if d.Node == util.NotAvailable && pvc.Annotations[util.SelectedNodeKey] != "" {
+		d.Node = pvc.Annotations[util.SelectedNodeKey]
+	}
+	if d.Node == util.NotAvailable {
+		return
+	}
+	// 2. Check the LVM node pod on the node
+	checkPods(k, d, util.LVMLocalPVcsiNodeLabelValue, d.Node)
+	if d.VolumeState == volumeReadyState {
+		return
+	}
+	// 3. Check the volume groups on the node, one of them must have room for
+	// a thick volume
+	pattern := sc.Parameters[util.LVMVgPatternParam]
+	if pattern == "" && sc.Parameters[util.LVMVolGroupParam] != "" {
+		pattern = "^" + regexp.QuoteMeta(sc.Parameters[util.LVMVolGroupParam]) + "$"
+	}
+	if pattern == "" {
+		addCause(d, util.SeverityError, "storageclass/"+sc.Name, "the storageclass has no %s or %s parameter", util.LVMVolGroupParam, util.LVMVgPatternParam)
+		return
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		addCause(d, util.SeverityError, "storageclass/"+sc.Name, "invalid %s %s: %v", util.LVMVgPatternParam, pattern, err)
+		return
+	}
+	nodes, _, err := k.GetLVMNodes([]string{d.Node}, util.List, "", "", util.MapOptions{})
+	if err != nil || len(nodes.Items) == 0 {
+		addCause(d, util.SeverityWarning, "lvmnode/"+d.Node, "no lvmnode found, the volume groups of node %s are not known", d.Node)
+		return
+	}
+	var vgs, matching []string
+	var maxFree resource.Quantity
+	for _, vg := range nodes.Items[0].VolumeGroups {
+		vgs = append(vgs, vg.Name)
+		if !re.MatchString(vg.Name) {
+			continue
+		}
+		matching = append(matching, vg.Name)
+		if vg.Free.Cmp(maxFree) > 0 {
+			maxFree = vg.Free
+		}
+	}
+	if len(matching) == 0 {
+		addCause(d, util.SeverityError, "lvmnode/"+d.Node, "missing volume group %s on node %s, the volume groups of the node are: %s", pattern, d.Node, orNone(vgs))
+		return
+	}
+	request := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !created && sc.Parameters[util.LVMThinProvisionParam] != "yes" && maxFree.Cmp(request) < 0 {
+		addCause(d, util.SeverityError, "lvmnode/"+d.Node, "volume group %s on node %s is too small, it has at most %s free & the pvc requests %s",
+			orNone(matching), d.Node, util.ConvertToIBytes(maxFree.String()), util.ConvertToIBytes(request.String()))
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var waitForFirstConsumer = storagev1.VolumeBindingWaitForFirstConsumer
+
+func newSC(name, provisioner string, params map[string]string, topologies ...corev1.TopologySelectorTerm) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: name},
+		Provisioner:       provisioner,
+		Parameters:        params,
+		VolumeBindingMode: &waitForFirstConsumer,
+		AllowedTopologies: topologies,
+	}
+}
+
+func newPVC(name, class, volume, node string, phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name + "-uid")},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &class,
+			VolumeName:       volume,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("4Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+	if node != "" {
+		pvc.Annotations = map[string]string{"volume.kubernetes.io/selected-node": node}
+	}
+	return pvc
+}
+
+// noClassPVC has no storageclass as there was no default storageclass when
+// it was created
+var noClassPVC = func() *corev1.PersistentVolumeClaim {
+	pvc := newPVC("no-class", "", "", "", corev1.ClaimPending)
+	pvc.Spec.StorageClassName = nil
+	return pvc
+}()
+
+// newStaticPV returns an available pre-provisioned PV without a storageclass
+func newStaticPV(name, size string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: "/mnt/" + name},
+			},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeAvailable},
+	}
+}
+
+func newPod(name, component, node string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openebs",
+			Labels:    map[string]string{"openebs.io/component-name": component},
+		},
+		Spec:   corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+var zfsPV1 = corev1.PersistentVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-zfs"},
+	Spec: corev1.PersistentVolumeSpec{
+		PersistentVolumeSource: corev1.PersistentVolumeSource{
+			CSI: &corev1.CSIPersistentVolumeSource{Driver: "zfs.csi.openebs.io", VolumeHandle: "pvc-zfs"},
+		},
+	},
+}
+
+var node1 = corev1.Node{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:   "node1",
+		Labels: map[string]string{"kubernetes.io/hostname": "node1"},
+	},
+}
+
+var zfsVol1 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-zfs", Namespace: "openebs"},
+	Spec: zfs.VolumeInfo{
+		OwnerNodeID: "node1",
+		PoolName:    "zfspv",
+		Capacity:    "4294967296",
+	},
+	Status: zfs.VolStatus{State: "Ready"},
+}
+
+var zfsNode1 = zfs.ZFSNode{
+	ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+	Pools:      []zfs.Pool{{Name: "zfspv", Free: resource.MustParse("2Gi")}},
+}
+
+var lvmNode1 = lvm.LVMNode{
+	ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+	VolumeGroups: []lvm.VolumeGroup{
+		{Name: "lvmvg", Size: resource.MustParse("10Gi"), Free: resource.MustParse("1Gi")},
+	},
+}
+
+var failedLVMVol1 = lvm.LVMVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-lvm-failed-uid", Namespace: "openebs"},
+	Spec: lvm.VolumeInfo{
+		OwnerNodeID: "node1",
+		VolGroup:    "lvmvg",
+		Capacity:    "4294967296",
+	},
+	Status: lvm.VolStatus{
+		State: "Failed",
+		Error: &lvm.VolumeError{Code: "Internal", Message: "insufficient space"},
+	},
+}
+
+var provisioningFailedEvent = corev1.Event{
+	ObjectMeta: metav1.ObjectMeta{Name: "zfs-pending.1", Namespace: "default"},
+	InvolvedObject: corev1.ObjectReference{
+		Kind:      "PersistentVolumeClaim",
+		Name:      "zfs-pending",
+		Namespace: "default",
+	},
+	Type:    corev1.EventTypeWarning,
+	Reason:  "ProvisioningFailed",
+	Message: "failed to provision volume",
+	Count:   3,
+}
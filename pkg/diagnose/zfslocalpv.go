@@ -0,0 +1,100 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// DiagnoseZFSVolume checks the ZFSVolume of the PVC, the ZFS node pod on its
+// node & the pool of the StorageClass on that node
+func DiagnoseZFSVolume(k *client.K8sClient, d *util.Diagnosis, pvc *corev1.PersistentVolumeClaim, sc *storagev1.StorageClass) {
+	// 1. Check the ZFSVolume, it is created by the controller as pvc-<uid>
+	name := volumeName(pvc)
+	zvols, _, err := k.GetZFSVols([]string{name}, util.List, "", "", util.MapOptions{})
+	created := err == nil && len(zvols.Items) > 0
+	switch {
+	case err != nil:
+		addCause(d, util.SeverityWarning, "zfsvolume/"+name, "failed to get the zfsvolume: %v", err)
+	case created:
+		zv := zvols.Items[0]
+		d.Volume, d.VolumeState, d.Node = zv.Name, zv.Status.State, zv.Spec.OwnerNodeID
+		if zv.Status.State == volumeFailedState {
+			addCause(d, util.SeverityError, "zfsvolume/"+zv.Name, "the volume failed to be created on pool %s of node %s", zv.Spec.PoolName, zv.Spec.OwnerNodeID)
+		}
+	case pvc.Spec.VolumeName != "":
+		addCause(d, util.SeverityError, "zfsvolume/"+name, "the zfsvolume of the pv is not found")
+	}
+	if d.Node == util.NotAvailable && pvc.Annotations[util.SelectedNodeKey] != "" {
+		d.Node = pvc.Annotations[util.SelectedNodeKey]
+	}
+	if d.Node == util.NotAvailable {
+		return
+	}
+	// 2. Check the ZFS node pod on the node
+	checkPods(k, d, util.ZFSLocalPVcsiNodeLabelValue, d.Node)
+	if d.VolumeState == volumeReadyState {
+		return
+	}
+	// 3. Check the pool on the node, it must have room for a thick volume
+	pool := sc.Parameters[util.ZFSPoolNameParam]
+	if pool == "" {
+		addCause(d, util.SeverityError, "storageclass/"+sc.Name, "the storageclass has no %s parameter", util.ZFSPoolNameParam)
+		return
+	}
+	nodes, _, err := k.GetZFSNodes([]string{d.Node}, util.List, "", "", util.MapOptions{})
+	if err != nil || len(nodes.Items) == 0 {
+		addCause(d, util.SeverityWarning, "zfsnode/"+d.Node, "no zfsnode found, the pools of node %s are not known", d.Node)
+		return
+	}
+	var pools []string
+	for _, p := range nodes.Items[0].Pools {
+		pools = append(pools, p.Name)
+		if p.Name != pool {
+			continue
+		}
+		request := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if !created && sc.Parameters[util.ZFSThinProvisionParam] != "yes" && p.Free.Cmp(request) < 0 {
+			addCause(d, util.SeverityError, "zfsnode/"+d.Node, "pool %s on node %s is too small, it has %s free & the pvc requests %s",
+				pool, d.Node, util.ConvertToIBytes(p.Free.String()), util.ConvertToIBytes(request.String()))
+		}
+		return
+	}
+	addCause(d, util.SeverityError, "zfsnode/"+d.Node, "missing pool %s on node %s, the pools of the node are: %s", pool, d.Node, orNone(pools))
+}
+
+// volumeName returns the name of the volume of the PVC, the CSI provisioner
+// names it pvc-<uid of the pvc>
+func volumeName(pvc *corev1.PersistentVolumeClaim) string {
+	if pvc.Spec.VolumeName != "" {
+		return pvc.Spec.VolumeName
+	}
+	return "pvc-" + string(pvc.UID)
+}
+
+// orNone joins the names or returns "none" if there aren't any
+func orNone(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package exporter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/capacity"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/clusterinfo"
+	"github.com/openebs/openebsctl/pkg/orphan"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/openebs/openebsctl/pkg/volume"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultListenAddress is the address the metrics are served on by default
+	DefaultListenAddress = ":9500"
+	// DefaultInterval is the default interval between two collections
+	DefaultInterval = 30 * time.Second
+	// metricsPath is the path the metrics are served on
+	metricsPath = "/metrics"
+	// contentType is the content type of the Prometheus text format
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+	// readHeaderTimeout is the time the scrapers have to send their request
+	// headers, the slow clients can't hold the connections open
+	readHeaderTimeout = 10 * time.Second
+)
+
+// engineStatuses are the statuses of the engines given by cluster-info, the
+// openebs_engine_status metric has a series for each of them
+var engineStatuses = []string{"Healthy", "Degraded", "Unhealthy"}
+
+// label is a label of a sample
+type label struct {
+	name, value string
+}
+
+// sample is a value of a metric along with its labels
+type sample struct {
+	labels []label
+	value  float64
+}
+
+// family is a metric with all its samples, the samples are written in the
+// order of their labels
+type family struct {
+	name, help string
+	samples    []sample
+}
+
+// add adds a sample of the metric with the labels as name, value pairs
+func (f *family) add(value float64, labels ...string) {
+	s := sample{value: value}
+	for i := 0; i+1 < len(labels); i += 2 {
+		s.labels = append(s.labels, label{name: labels[i], value: labels[i+1]})
+	}
+	f.samples = append(f.samples, s)
+}
+
+// collector reads a group of the metrics, the metrics of the failed
+// collectors are left out
+type collector struct {
+	name    string
+	collect func(k *client.K8sClient) ([]*family, error)
+}
+
+// collectors returns the collectors of the metrics in the order they are
+// written
+func collectors() []collector {
+	return []collector{
+		{name: "pools", collect: collectPools},
+		{name: "volumes", collect: collectVolumes},
+		{name: "engines", collect: collectEngines},
+		{name: "orphans", collect: collectOrphans},
+	}
+}
+
+// Run collects the metrics every interval & serves the latest ones in the
+// Prometheus text format on the listen address till the server fails
+func Run(listen string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval %s, it must be positive", interval)
+	}
+	k := client.NewK8sClient()
+	var mu sync.RWMutex
+	var latest []byte
+	update := func() {
+		var buf bytes.Buffer
+		if err := write(&buf, collect(k)); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		mu.Lock()
+		latest = buf.Bytes()
+		mu.Unlock()
+	}
+	update()
+	go func() {
+		for range time.Tick(interval) {
+			update()
+		}
+	}()
+	mux := http.NewServeMux()
+	mux.HandleFunc(metricsPath, func(w http.ResponseWriter, r *http.Request) {
+		mu.RLock()
+		defer mu.RUnlock()
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(latest)
+	})
+	fmt.Printf("Serving the OpenEBS metrics on %s%s, collected every %s\n", listen, metricsPath, interval)
+	server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+	return server.ListenAndServe()
+}
+
+// collect runs all the collectors, the success of every collector is added
+// as the openebs_exporter_collector_success metric
+func collect(k *client.K8sClient) []*family {
+	var families []*family
+	success := &family{name: "openebs_exporter_collector_success", help: "Whether the last collection of the group of metrics succeeded."}
+	for _, c := range collectors() {
+		found, err := c.collect(k)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "collector %s failed: %v\n", c.name, err)
+			success.add(0, "collector", c.name)
+			continue
+		}
+		families = append(families, found...)
+		success.add(1, "collector", c.name)
+	}
+	return append(families, success)
+}
+
+// collectPools returns the free & total space of the ZFS pools, the LVM
+// volume groups & the Mayastor DiskPools of every node, the engines which
+// aren't installed are skipped
+func collectPools(k *client.K8sClient) ([]*family, error) {
+	free := &family{name: "openebs_pool_free_bytes", help: "Free space of the pool or volume group."}
+	total := &family{name: "openebs_pool_total_bytes", help: "Total space of the pool or volume group."}
+	for _, f := range capacity.CasList() {
+		usages, err := f(k, nil)
+		if err != nil {
+			continue
+		}
+		for _, n := range usages {
+			for _, p := range n.Pools() {
+				labels := []string{"cas_type", n.CasType(), "node", n.Node(), "pool", p.Name()}
+				free.add(float64(p.Free()), labels...)
+				total.add(float64(p.Total()), labels...)
+			}
+		}
+	}
+	if pools, err := k.GetDiskPools(nil, "", ""); err == nil {
+		for _, dsp := range pools {
+			labels := []string{"cas_type", util.MayastorCasType, "node", dsp.Spec.Node, "pool", dsp.Name}
+			free.add(float64(dsp.Status.Available), labels...)
+			total.add(float64(dsp.Status.Capacity), labels...)
+		}
+	}
+	return []*family{free, total}, nil
+}
+
+// collectVolumes returns the number of volumes of every engine by their
+// state
+func collectVolumes(k *client.K8sClient) ([]*family, error) {
+	volumes, err := volume.List(k, util.VolumeFilter{})
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[[2]string]int)
+	for _, vol := range volumes {
+		status := vol.Status
+		if status == "" {
+			status = util.NotAvailable
+		}
+		counts[[2]string{vol.CasType, status}]++
+	}
+	f := &family{name: "openebs_volumes", help: "Number of volumes of the engine in the state."}
+	for key, count := range counts {
+		f.add(float64(count), "cas_type", key[0], "state", key[1])
+	}
+	return []*family{f}, nil
+}
+
+// collectEngines returns the health & the version of the installed engines
+// & of their components
+func collectEngines(k *client.K8sClient) ([]*family, error) {
+	engine := &family{name: "openebs_engine_info", help: "Version of the installed engine, always 1."}
+	status := &family{name: "openebs_engine_status", help: "Whether the installed engine is in the status."}
+	up := &family{name: "openebs_component_up", help: "Whether the pods of the component of the engine are running."}
+	for _, e := range clusterinfo.GetEngineStatuses(k) {
+		engine.add(1, "cas_type", e.CasType, "namespace", e.Namespace, "version", e.Version)
+		for _, s := range engineStatuses {
+			current := 0.0
+			if e.Status == s {
+				current = 1
+			}
+			status.add(current, "cas_type", e.CasType, "status", s)
+		}
+		for _, c := range e.Components {
+			running := 0.0
+			if c.Status == string(corev1.PodRunning) {
+				running = 1
+			}
+			up.add(running, "cas_type", e.CasType, "component", c.Name, "namespace", c.Namespace, "version", c.Version)
+		}
+	}
+	return []*family{engine, status, up}, nil
+}
+
+// collectOrphans returns the number of leftover resources of every engine
+// by their kind
+func collectOrphans(k *client.K8sClient) ([]*family, error) {
+	orphans, err := orphan.List(k, "")
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[[2]string]int)
+	for _, o := range orphans {
+		counts[[2]string{o.CasType, o.Kind}]++
+	}
+	f := &family{name: "openebs_orphans", help: "Number of leftover resources of the engine by their kind."}
+	for key, count := range counts {
+		f.add(float64(count), "cas_type", key[0], "kind", key[1])
+	}
+	return []*family{f}, nil
+}
+
+// write writes the metrics in the Prometheus text format, all the metrics
+// are gauges
+func write(w io.Writer, families []*family) error {
+	var b strings.Builder
+	for _, f := range families {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", f.name, f.help, f.name)
+		lines := make([]string, 0, len(f.samples))
+		for _, s := range f.samples {
+			lines = append(lines, f.name+formatLabels(s.labels)+" "+strconv.FormatFloat(s.value, 'f', -1, 64))
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			b.WriteString(line + "\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatLabels returns the labels of a sample in the text format, the
+// backslashes, quotes & newlines of their values are escaped
+func formatLabels(labels []label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	parts := make([]string, 0, len(labels))
+	for _, l := range labels {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, l.name, escaper.Replace(l.value)))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
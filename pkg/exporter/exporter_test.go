@@ -0,0 +1,101 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package exporter
+
+import (
+	"bytes"
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCollect(t *testing.T) {
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(zfsControllerPod, zfsNodePod,
+			newZFSPV("pvc-1", corev1.VolumeBound, corev1.PersistentVolumeReclaimDelete),
+			newZFSPV("pvc-2", corev1.VolumeBound, corev1.PersistentVolumeReclaimDelete),
+			newZFSPV("pvc-retained", corev1.VolumeReleased, corev1.PersistentVolumeReclaimRetain)),
+		ZFCS: zfsfake.NewSimpleClientset(zfsNode1, newZFSVol("pvc-1", "Ready"), newZFSVol("pvc-2", "Pending"),
+			newZFSVol("pvc-retained", "Ready"), newZFSVol("pvc-gone", "Ready")),
+		LVMCS: lvmfake.NewSimpleClientset(lvmNode2),
+	}
+	var buf bytes.Buffer
+	if err := write(&buf, collect(k)); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+	want := `# HELP openebs_pool_free_bytes Free space of the pool or volume group.
+# TYPE openebs_pool_free_bytes gauge
+openebs_pool_free_bytes{cas_type="localpv-lvm",node="node2",pool="lvmvg"} 12884901888
+openebs_pool_free_bytes{cas_type="localpv-zfs",node="node1",pool="zfspv"} 6442450944
+# HELP openebs_pool_total_bytes Total space of the pool or volume group.
+# TYPE openebs_pool_total_bytes gauge
+openebs_pool_total_bytes{cas_type="localpv-lvm",node="node2",pool="lvmvg"} 21474836480
+openebs_pool_total_bytes{cas_type="localpv-zfs",node="node1",pool="zfspv"} 23622320128
+# HELP openebs_volumes Number of volumes of the engine in the state.
+# TYPE openebs_volumes gauge
+openebs_volumes{cas_type="localpv-zfs",state="Pending"} 1
+openebs_volumes{cas_type="localpv-zfs",state="Ready"} 2
+# HELP openebs_engine_info Version of the installed engine, always 1.
+# TYPE openebs_engine_info gauge
+openebs_engine_info{cas_type="localpv-zfs",namespace="openebs",version="2.1.0"} 1
+# HELP openebs_engine_status Whether the installed engine is in the status.
+# TYPE openebs_engine_status gauge
+openebs_engine_status{cas_type="localpv-zfs",status="Degraded"} 1
+openebs_engine_status{cas_type="localpv-zfs",status="Healthy"} 0
+openebs_engine_status{cas_type="localpv-zfs",status="Unhealthy"} 0
+# HELP openebs_component_up Whether the pods of the component of the engine are running.
+# TYPE openebs_component_up gauge
+openebs_component_up{cas_type="localpv-zfs",component="openebs-zfs-controller",namespace="openebs",version="2.1.0"} 1
+openebs_component_up{cas_type="localpv-zfs",component="openebs-zfs-node",namespace="openebs",version="2.1.0"} 0
+# HELP openebs_orphans Number of leftover resources of the engine by their kind.
+# TYPE openebs_orphans gauge
+openebs_orphans{cas_type="localpv-zfs",kind="PersistentVolume"} 1
+openebs_orphans{cas_type="localpv-zfs",kind="ZFSVolume"} 1
+# HELP openebs_exporter_collector_success Whether the last collection of the group of metrics succeeded.
+# TYPE openebs_exporter_collector_success gauge
+openebs_exporter_collector_success{collector="engines"} 1
+openebs_exporter_collector_success{collector="orphans"} 1
+openebs_exporter_collector_success{collector="pools"} 1
+openebs_exporter_collector_success{collector="volumes"} 1
+`
+	if got := buf.String(); got != want {
+		t.Errorf("write() got =\n%s\nwant =\n%s", got, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	f := &family{name: "openebs_test", help: "Test metric."}
+	f.add(0.5, "path", `C:\data`, "reason", "a \"quoted\"\nreason")
+	f.add(2)
+	var buf bytes.Buffer
+	if err := write(&buf, []*family{f, {name: "openebs_empty", help: "Metric without samples."}}); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+	want := `# HELP openebs_test Test metric.
+# TYPE openebs_test gauge
+openebs_test 2
+openebs_test{path="C:\\data",reason="a \"quoted\"\nreason"} 0.5
+# HELP openebs_empty Metric without samples.
+# TYPE openebs_empty gauge
+`
+	if got := buf.String(); got != want {
+		t.Errorf("write() got =\n%s\nwant =\n%s", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package exporter
+
+import (
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newPod(name, component string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openebs",
+			Labels: map[string]string{util.ComponentNameKey: component, "openebs.io/version": "2.1.0"}},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func newZFSPV(name string, phase corev1.PersistentVolumePhase, policy corev1.PersistentVolumeReclaimPolicy) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("4Gi")},
+			PersistentVolumeSource:        corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: util.ZFSCSIDriver, VolumeHandle: name}},
+			AccessModes:                   []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			ClaimRef:                      &corev1.ObjectReference{Namespace: "default", Name: name + "-claim"},
+			PersistentVolumeReclaimPolicy: policy,
+			StorageClassName:              "zfs-sc",
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: phase},
+	}
+}
+
+func newZFSVol(name, state string) *zfs.ZFSVolume {
+	return &zfs.ZFSVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openebs"},
+		Spec:       zfs.VolumeInfo{OwnerNodeID: "node1", PoolName: "zfspv", Capacity: "4294967296"},
+		Status:     zfs.VolStatus{State: state},
+	}
+}
+
+var (
+	zfsControllerPod = newPod("openebs-zfs-controller-0", "openebs-zfs-controller", corev1.PodRunning)
+	zfsNodePod       = newPod("openebs-zfs-node-x7k2p", "openebs-zfs-node", corev1.PodPending)
+
+	zfsNode1 = &zfs.ZFSNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+		Pools:      []zfs.Pool{{Name: "zfspv", Free: resource.MustParse("6Gi")}},
+	}
+	lvmNode2 = &lvm.LVMNode{
+		ObjectMeta:   metav1.ObjectMeta{Name: "node2", Namespace: "openebs"},
+		VolumeGroups: []lvm.VolumeGroup{{Name: "lvmvg", Size: resource.MustParse("20Gi"), Free: resource.MustParse("12Gi")}},
+	}
+)
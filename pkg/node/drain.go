@@ -0,0 +1,221 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/openebs/openebsctl/pkg/volume"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// drainImpactKind is the kind of the machine-readable drain impact
+	drainImpactKind = "DrainImpactList"
+
+	// ImpactUnavailable is the impact on a workload with no ready pods on the
+	// other nodes
+	ImpactUnavailable = "Unavailable"
+	// ImpactDegraded is the impact on a workload with ready pods on the other
+	// nodes, its pods on the node can't start elsewhere without their volumes
+	ImpactDegraded = "Degraded"
+)
+
+const drainImpactTemplate = `
+{{.Node}} Drain Impact :
+-----------------------
+NODE          : {{.Node}}
+LOCAL VOLUMES : {{len .Volumes}}
+WORKLOADS     : {{len .Workloads}}
+STORAGE LOSS  : {{.StorageLoss}}
+`
+
+// DrainCheck shows the local volumes pinned to the node, the workloads
+// using them & the impact of draining the node on them. Nothing is changed,
+// the returned bool is true if pods would lose their storage.
+func DrainCheck(name, output string) (bool, error) {
+	if err := util.CheckOutputFormat(output); err != nil {
+		return false, err
+	}
+	k := client.NewK8sClient()
+	nodes, err := k.GetNodes([]string{name}, "", "")
+	if err != nil {
+		return false, err
+	}
+	if len(nodes.Items) == 0 {
+		return false, fmt.Errorf("node %s not found", name)
+	}
+	impact, err := getDrainImpact(k, name)
+	if err != nil {
+		return false, err
+	}
+	if util.IsStructuredOutput(output) {
+		return impact.StorageLoss, util.PrintList(output, drainImpactKind, []interface{}{*impact})
+	}
+	if err = util.PrintByTemplate("drainImpact", drainImpactTemplate, impact); err != nil {
+		return false, err
+	}
+	var volumes, workloads []metav1.TableRow
+	for _, v := range impact.Volumes {
+		volumes = append(volumes, metav1.TableRow{Cells: []interface{}{v.Name, v.CasType, v.Pool, v.Capacity, v.Status, v.PVC, orNone(v.MountPods, ",")}})
+	}
+	for _, w := range impact.Workloads {
+		impactCell := util.ColorText(w.Impact, util.Orange)
+		if w.Impact == ImpactUnavailable {
+			impactCell = util.ColorText(w.Impact, util.Red)
+		}
+		workloads = append(workloads, metav1.TableRow{Cells: []interface{}{
+			w.Namespace, strings.ToLower(w.Kind) + "/" + w.Name, strings.Join(w.Pods, ","), strings.Join(w.Volumes, ","), w.ReadyElsewhere, impactCell,
+		}})
+	}
+	printSection("Local Volumes", util.NodeVolumeColumnDefinitions, volumes)
+	printSection("Workloads", util.DrainWorkloadColumnDefinitions, workloads)
+	if impact.StorageLoss {
+		fmt.Printf("\nDraining node %s would leave the pods above without their storage, their volumes can't move to other nodes\n", name)
+	}
+	return impact.StorageLoss, nil
+}
+
+// getDrainImpact returns the volumes pinned to the node & the workloads with
+// pods on the node using them
+func getDrainImpact(k *client.K8sClient, node string) (*util.DrainImpact, error) {
+	// 1. Get the ZFS, LVM, hostpath & device volumes of the node
+	vols, err := volume.List(k, util.VolumeFilter{Node: node})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := k.GetAllPods("")
+	if err != nil {
+		return nil, err
+	}
+	claims := claimPods(pods.Items)
+	owners := newOwnerResolver(k)
+	impact := &util.DrainImpact{Node: node}
+	workloads := make(map[string]*util.DrainWorkload)
+	// 2. Find the workloads of the pods on the node using the volumes, the
+	// finished pods don't need their volumes
+	for _, vol := range vols {
+		if !isLocal(vol) {
+			continue
+		}
+		nv := newNodeVolume(vol)
+		for _, pod := range claims[nv.PVC] {
+			nv.MountPods = append(nv.MountPods, pod.Name)
+			if pod.Spec.NodeName != node || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			kind, name := owners.workloadOf(pod)
+			key := pod.Namespace + "/" + kind + "/" + name
+			w, ok := workloads[key]
+			if !ok {
+				w = &util.DrainWorkload{Namespace: pod.Namespace, Kind: kind, Name: name}
+				workloads[key] = w
+			}
+			w.Pods = appendUnique(w.Pods, pod.Name)
+			w.Volumes = appendUnique(w.Volumes, vol.Name)
+		}
+		impact.Volumes = append(impact.Volumes, nv)
+	}
+	// 3. Count the ready pods of the workloads on the other nodes
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" || pod.Spec.NodeName == node || !util.IsPodReady(pod) {
+			continue
+		}
+		if !hasWorkloadIn(workloads, pod.Namespace) {
+			continue
+		}
+		kind, name := owners.workloadOf(pod)
+		if w, ok := workloads[pod.Namespace+"/"+kind+"/"+name]; ok {
+			w.ReadyElsewhere++
+		}
+	}
+	for _, w := range workloads {
+		w.Impact = ImpactDegraded
+		if w.ReadyElsewhere == 0 {
+			w.Impact = ImpactUnavailable
+		}
+		impact.Workloads = append(impact.Workloads, *w)
+	}
+	sort.Slice(impact.Workloads, func(i, j int) bool {
+		a, b := impact.Workloads[i], impact.Workloads[j]
+		return a.Namespace+"/"+a.Kind+"/"+a.Name < b.Namespace+"/"+b.Kind+"/"+b.Name
+	})
+	impact.StorageLoss = len(impact.Workloads) > 0
+	return impact, nil
+}
+
+// ownerResolver finds the workloads of the pods, the ReplicaSets are cached
+// as the pods of a Deployment share them
+type ownerResolver struct {
+	k           *client.K8sClient
+	replicaSets map[string]*metav1.OwnerReference
+}
+
+func newOwnerResolver(k *client.K8sClient) *ownerResolver {
+	return &ownerResolver{k: k, replicaSets: make(map[string]*metav1.OwnerReference)}
+}
+
+// workloadOf returns the kind & name of the controller of the pod, the
+// Deployment of its ReplicaSet or the pod itself if it has no controller
+func (r *ownerResolver) workloadOf(pod corev1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+	if owner.Kind != "ReplicaSet" {
+		return owner.Kind, owner.Name
+	}
+	key := pod.Namespace + "/" + owner.Name
+	rsOwner, ok := r.replicaSets[key]
+	if !ok {
+		// a ReplicaSet which isn't found is taken as the workload
+		if rs, err := r.k.GetReplicaSet(owner.Name, pod.Namespace); err == nil {
+			rsOwner = metav1.GetControllerOf(rs)
+		}
+		r.replicaSets[key] = rsOwner
+	}
+	if rsOwner != nil && rsOwner.Kind == "Deployment" {
+		return rsOwner.Kind, rsOwner.Name
+	}
+	return owner.Kind, owner.Name
+}
+
+// hasWorkloadIn returns true if any of the workloads is in the namespace
+func hasWorkloadIn(workloads map[string]*util.DrainWorkload, namespace string) bool {
+	for _, w := range workloads {
+		if w.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// appendUnique appends the value unless the values already have it, the
+// values keep their order
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
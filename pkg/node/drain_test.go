@@ -0,0 +1,79 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetDrainImpact(t *testing.T) {
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(&node1, &node2, &appPod1, &zfsPV1, &zfsPV2, &webReplicaSet,
+			newOwnedPod("db-0", "node1", "data-db-0", "StatefulSet", "db"),
+			newOwnedPod("db-1", "node2", "data-db-1", "StatefulSet", "db"),
+			newOwnedPod("web-5d8-abcde", "node1", "zfs-pvc-1", "ReplicaSet", "web-5d8"),
+		),
+		ZFCS:  zfsfake.NewSimpleClientset(&zfsVol1, &zfsVol2),
+		LVMCS: lvmfake.NewSimpleClientset(),
+	}
+	tests := []struct {
+		name string
+		node string
+		want *util.DrainImpact
+	}{
+		{
+			"node with local volumes",
+			"node1",
+			&util.DrainImpact{
+				Node: "node1",
+				Volumes: []util.NodeVolume{
+					{Name: "pvc-1", CasType: util.ZFSCasType, Pool: "zfspv", Capacity: "4.0GiB", Status: "Ready", PVC: "default/zfs-pvc-1", MountPods: []string{"app-1", "web-5d8-abcde"}},
+					{Name: "pvc-2", CasType: util.ZFSCasType, Pool: "zfspv", Capacity: "4.0GiB", Status: "Ready", PVC: "default/data-db-0", MountPods: []string{"db-0"}},
+				},
+				Workloads: []util.DrainWorkload{
+					{Namespace: "default", Kind: "Deployment", Name: "web", Pods: []string{"web-5d8-abcde"}, Volumes: []string{"pvc-1"}, Impact: ImpactUnavailable},
+					{Namespace: "default", Kind: "Pod", Name: "app-1", Pods: []string{"app-1"}, Volumes: []string{"pvc-1"}, Impact: ImpactUnavailable},
+					{Namespace: "default", Kind: "StatefulSet", Name: "db", Pods: []string{"db-0"}, Volumes: []string{"pvc-2"}, ReadyElsewhere: 1, Impact: ImpactDegraded},
+				},
+				StorageLoss: true,
+			},
+		},
+		{
+			"node without local volumes",
+			"node2",
+			&util.DrainImpact{Node: "node2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getDrainImpact(k, tt.node)
+			if err != nil {
+				t.Fatalf("getDrainImpact() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getDrainImpact() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/capacity"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/openebs/openebsctl/pkg/volume"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// nodeListKind is the kind of the machine-readable node listing
+	nodeListKind = "NodeList"
+	// nodeDescribeKind is the kind of the machine-readable node describe
+	nodeDescribeKind = "NodeDescriptionList"
+)
+
+const nodeInfoTemplate = `
+{{.Name}} Details :
+------------------
+NAME     : {{.Name}}
+STATUS   : {{.Status}}
+`
+
+// Get lists the nodes along with the engine agents, pools & volumes on
+// them, all the nodes are listed if none are passed
+func Get(nodes []string, output string) error {
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	descs, err := getNodeDescs(k, nodes)
+	if err != nil {
+		return err
+	}
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, 0, len(descs))
+		for _, d := range descs {
+			items = append(items, d)
+		}
+		return util.PrintList(output, nodeListKind, items)
+	}
+	if len(descs) == 0 {
+		return fmt.Errorf("no nodes found")
+	}
+	var rows []metav1.TableRow
+	for _, d := range descs {
+		var agents []string
+		for _, a := range d.Agents {
+			agents = append(agents, fmt.Sprintf("%s(%s)", a.CasType, a.Ready))
+		}
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{d.Name, d.Status, orNone(agents, ","), len(d.Pools), len(d.Volumes)}})
+	}
+	util.TablePrinter(util.NodeListColumnDefinitions, rows, printers.PrintOptions{})
+	return nil
+}
+
+// Describe shows the engine agents, the ZFS pools, the LVM volume groups &
+// the volumes of a node, along with the pods mounting the volumes
+func Describe(name, output string) error {
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	descs, err := getNodeDescs(k, []string{name})
+	if err != nil {
+		return err
+	}
+	if len(descs) == 0 {
+		return fmt.Errorf("node %s not found", name)
+	}
+	d := descs[0]
+	if util.IsStructuredOutput(output) {
+		return util.PrintList(output, nodeDescribeKind, []interface{}{d})
+	}
+	if err = util.PrintByTemplate("node", nodeInfoTemplate, d); err != nil {
+		return err
+	}
+	var agents, pools, volumes []metav1.TableRow
+	for _, a := range d.Agents {
+		agents = append(agents, metav1.TableRow{Cells: []interface{}{a.Pod, a.CasType, a.Ready, a.Status}})
+	}
+	for _, p := range d.Pools {
+		pools = append(pools, metav1.TableRow{Cells: []interface{}{p.Name, p.CasType, p.Free, p.Total, p.UsedPercentage}})
+	}
+	for _, v := range d.Volumes {
+		volumes = append(volumes, metav1.TableRow{Cells: []interface{}{v.Name, v.CasType, v.Pool, v.Capacity, v.Status, v.PVC, orNone(v.MountPods, ",")}})
+	}
+	printSection("Engine Agents", util.NodeAgentColumnDefinitions, agents)
+	printSection("Pools", util.NodePoolColumnDefinitions, pools)
+	printSection("Volumes", util.NodeVolumeColumnDefinitions, volumes)
+	return nil
+}
+
+// printSection prints the title & the table of a section of the describe
+// output, or <none> if it has no rows
+func printSection(title string, columns []metav1.TableColumnDefinition, rows []metav1.TableRow) {
+	fmt.Printf("\n%s :\n%s\n", title, strings.Repeat("-", len(title)+2))
+	if len(rows) == 0 {
+		fmt.Println("<none>")
+		return
+	}
+	util.TablePrinter(columns, rows, printers.PrintOptions{})
+}
+
+// getNodeDescs returns the engine agents, pools & volumes of the nodes, of
+// all the nodes if none are passed
+func getNodeDescs(k *client.K8sClient, names []string) ([]util.NodeDesc, error) {
+	nodes, err := k.GetNodes(names, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes.Items) == 0 {
+		return nil, nil
+	}
+	// 1. Get the CSI node pods of the engines
+	agents, err := getAgents(k)
+	if err != nil {
+		return nil, err
+	}
+	// 2. Get the pools & volume groups, the errors of the engines which
+	// aren't installed are ignored
+	report, err := capacity.Report(k, names, "", capacity.DefaultThresholds)
+	if err != nil {
+		return nil, err
+	}
+	pools := make(map[string][]util.NodePool)
+	for _, n := range report {
+		for _, p := range n.Pools {
+			pools[n.Node] = append(pools[n.Node], util.NodePool{CasType: n.CasType, PoolCapacity: p})
+		}
+	}
+	// 3. Get the volumes with the pods mounting them
+	volumes, err := getVolumes(k)
+	if err != nil {
+		return nil, err
+	}
+	descs := make([]util.NodeDesc, 0, len(nodes.Items))
+	for _, n := range nodes.Items {
+		descs = append(descs, util.NodeDesc{
+			Name:    n.Name,
+			Status:  nodeStatus(n),
+			Agents:  agents[n.Name],
+			Pools:   pools[n.Name],
+			Volumes: volumes[n.Name],
+		})
+	}
+	sort.Slice(descs, func(i, j int) bool {
+		return descs[i].Name < descs[j].Name
+	})
+	return descs, nil
+}
+
+// getAgents returns the CSI node pods of the engines by their node
+func getAgents(k *client.K8sClient) (map[string][]util.NodeAgent, error) {
+	casTypes := make(map[string]string)
+	var components []string
+	for casType, component := range util.CasTypeToCSINodeComponentMap {
+		casTypes[component] = casType
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	pods, err := k.GetPods(fmt.Sprintf("openebs.io/component-name in (%s)", strings.Join(components, ",")), "", "")
+	if err != nil {
+		return nil, err
+	}
+	agents := make(map[string][]util.NodeAgent)
+	for _, pod := range pods.Items {
+		agents[pod.Spec.NodeName] = append(agents[pod.Spec.NodeName], util.NodeAgent{
+			Pod:     pod.Name,
+			CasType: casTypes[pod.Labels["openebs.io/component-name"]],
+			Ready:   util.GetReadyContainers(pod.Status.ContainerStatuses),
+			Status:  string(pod.Status.Phase),
+		})
+	}
+	for _, a := range agents {
+		sort.Slice(a, func(i, j int) bool {
+			return a[i].Pod < a[j].Pod
+		})
+	}
+	return agents, nil
+}
+
+// getVolumes returns the local volumes by their node, along with the pods
+// mounting them
+func getVolumes(k *client.K8sClient) (map[string][]util.NodeVolume, error) {
+	vols, err := volume.List(k, util.VolumeFilter{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := k.GetAllPods("")
+	if err != nil {
+		return nil, err
+	}
+	claims := claimPods(pods.Items)
+	volumes := make(map[string][]util.NodeVolume)
+	for _, vol := range vols {
+		node := volume.NodeOf(vol)
+		if node == "" || !isLocal(vol) {
+			continue
+		}
+		nv := newNodeVolume(vol)
+		for _, pod := range claims[nv.PVC] {
+			nv.MountPods = append(nv.MountPods, pod.Name)
+		}
+		volumes[node] = append(volumes[node], nv)
+	}
+	return volumes, nil
+}
+
+// isLocal returns true if the volume is pinned to its node, the node of the
+// replicated volumes like the Mayastor ones is only the node of their target
+func isLocal(vol util.Volume) bool {
+	switch vol.CasType {
+	case util.ZFSCasType, util.LVMCasType, util.LocalPvHostpathCasType, util.LocalPvDeviceCasType:
+		return true
+	default:
+		return false
+	}
+}
+
+// newNodeVolume returns the NodeVolume of the volume without its mounting pods
+func newNodeVolume(vol util.Volume) util.NodeVolume {
+	pool, pvc := volume.PoolOf(vol), util.NotAvailable
+	if pool == "" {
+		pool = util.NotAvailable
+	}
+	if vol.PVC != "" {
+		pvc = vol.PVCNamespace + "/" + vol.PVC
+	}
+	return util.NodeVolume{
+		Name:     vol.Name,
+		CasType:  vol.CasType,
+		Pool:     pool,
+		Capacity: vol.Capacity,
+		Status:   vol.Status,
+		PVC:      pvc,
+	}
+}
+
+// claimPods returns the pods by the namespace/name of the PVCs they mount,
+// the pods of every PVC are sorted by their name
+func claimPods(pods []corev1.Pod) map[string][]corev1.Pod {
+	claims := make(map[string][]corev1.Pod)
+	for _, pod := range pods {
+		for _, v := range pod.Spec.Volumes {
+			if v.PersistentVolumeClaim != nil {
+				key := pod.Namespace + "/" + v.PersistentVolumeClaim.ClaimName
+				claims[key] = append(claims[key], pod)
+			}
+		}
+	}
+	for _, p := range claims {
+		sort.Slice(p, func(i, j int) bool {
+			return p[i].Name < p[j].Name
+		})
+	}
+	return claims
+}
+
+// nodeStatus returns the status of the node like kubectl, i.e. Ready or
+// NotReady & SchedulingDisabled once it is cordoned
+func nodeStatus(node corev1.Node) string {
+	status := "NotReady"
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+			status = "Ready"
+		}
+	}
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+// orNone returns the joined values, or none if there are no values
+func orNone(values []string, sep string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, sep)
+}
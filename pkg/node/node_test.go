@@ -0,0 +1,88 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetNodeDescs(t *testing.T) {
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(&node1, &node2, &zfsNodePod1, &appPod1, &zfsPV1),
+		ZFCS:  zfsfake.NewSimpleClientset(&zfsVol1, &zfsNode1),
+		LVMCS: lvmfake.NewSimpleClientset(),
+	}
+	node1Desc := util.NodeDesc{
+		Name:   "node1",
+		Status: "Ready",
+		Agents: []util.NodeAgent{{Pod: "openebs-zfs-node-abcde", CasType: util.ZFSCasType, Ready: "1/2", Status: string(corev1.PodRunning)}},
+		Pools: []util.NodePool{{CasType: util.ZFSCasType, PoolCapacity: util.PoolCapacity{Name: "zfspv", CapacityUsage: util.CapacityUsage{
+			Total: "10.0GiB", Free: "6.0GiB", UsedPercentage: "40.0%", Provisioned: "4.0GiB", Overcommit: "40.0%", Headroom: "6.0GiB",
+		}}}},
+		Volumes: []util.NodeVolume{{Name: "pvc-1", CasType: util.ZFSCasType, Pool: "zfspv", Capacity: "4.0GiB", Status: "Ready",
+			PVC: "default/zfs-pvc-1", MountPods: []string{"app-1"}}},
+	}
+	node2Desc := util.NodeDesc{Name: "node2", Status: "NotReady,SchedulingDisabled"}
+	tests := []struct {
+		name  string
+		nodes []string
+		want  []util.NodeDesc
+	}{
+		{"all the nodes", nil, []util.NodeDesc{node1Desc, node2Desc}},
+		{"node by name", []string{"node2"}, []util.NodeDesc{node2Desc}},
+		{"node not found", []string{"node3"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getNodeDescs(k, tt.nodes)
+			if err != nil {
+				t.Fatalf("getNodeDescs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getNodeDescs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLocal(t *testing.T) {
+	tests := []struct {
+		name string
+		vol  util.Volume
+		want bool
+	}{
+		{"zfs volume", util.Volume{CasType: util.ZFSCasType}, true},
+		{"hostpath volume", util.Volume{CasType: util.LocalPvHostpathCasType}, true},
+		{"device volume", util.Volume{CasType: util.LocalPvDeviceCasType}, true},
+		{"mayastor volume on its target node", util.Volume{CasType: util.MayastorCasType, Node: "node1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isLocal(tt.vol); got != tt.want {
+				t.Errorf("isLocal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"github.com/openebs/openebsctl/pkg/util"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var node1 = corev1.Node{
+	ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+	Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+}
+
+var node2 = corev1.Node{
+	ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+	Spec:       corev1.NodeSpec{Unschedulable: true},
+	Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}},
+}
+
+var zfsNodePod1 = corev1.Pod{
+	ObjectMeta: metav1.ObjectMeta{Name: "openebs-zfs-node-abcde", Namespace: "openebs",
+		Labels: map[string]string{"openebs.io/component-name": util.ZFSLocalPVcsiNodeLabelValue}},
+	Spec: corev1.PodSpec{NodeName: "node1"},
+	Status: corev1.PodStatus{
+		Phase:             corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{{Ready: true}, {Ready: false}},
+	},
+}
+
+var appPod1 = corev1.Pod{
+	ObjectMeta: metav1.ObjectMeta{Name: "app-1", Namespace: "default"},
+	Spec: corev1.PodSpec{
+		NodeName: "node1",
+		Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "zfs-pvc-1"},
+		}}},
+	},
+}
+
+var zfsPV1 = corev1.PersistentVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+	Spec: corev1.PersistentVolumeSpec{
+		Capacity:               corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("4Gi")},
+		PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: util.ZFSCSIDriver}},
+		AccessModes:            []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		ClaimRef:               &corev1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: "default", Name: "zfs-pvc-1"},
+		StorageClassName:       "zfs-sc-1",
+	},
+	Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+}
+
+var zfsVol1 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "openebs", Labels: map[string]string{"kubernetes.io/nodename": "node1"}},
+	Spec:       zfs.VolumeInfo{OwnerNodeID: "node1", PoolName: "zfspv", Capacity: "4294967296"},
+	Status:     zfs.VolStatus{State: "Ready"},
+}
+
+var zfsNode1 = zfs.ZFSNode{
+	ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+	Pools:      []zfs.Pool{{Name: "zfspv", Free: resource.MustParse("6Gi")}},
+}
+
+var zfsPV2 = corev1.PersistentVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-2"},
+	Spec: corev1.PersistentVolumeSpec{
+		Capacity:               corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("4Gi")},
+		PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: util.ZFSCSIDriver}},
+		AccessModes:            []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		ClaimRef:               &corev1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: "default", Name: "data-db-0"},
+		StorageClassName:       "zfs-sc-1",
+	},
+	Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+}
+
+var zfsVol2 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-2", Namespace: "openebs", Labels: map[string]string{"kubernetes.io/nodename": "node1"}},
+	Spec:       zfs.VolumeInfo{OwnerNodeID: "node1", PoolName: "zfspv", Capacity: "4294967296"},
+	Status:     zfs.VolStatus{State: "Ready"},
+}
+
+var isController = true
+
+// newOwnedPod returns a ready pod on the node owned by the controller &
+// mounting the PVC
+func newOwnedPod(name, node, pvc, ownerKind, ownerName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: ownerKind, Name: ownerName, Controller: &isController}}},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc},
+			}}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+var webReplicaSet = appsv1.ReplicaSet{
+	ObjectMeta: metav1.ObjectMeta{Name: "web-5d8", Namespace: "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &isController}}},
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// resultDeleted is the audit result of a deleted orphan
+	resultDeleted = "deleted"
+	// resultSkipped is the audit result of an orphan which is still in use
+	resultSkipped = "skipped"
+	// resultFailed is the audit result of an orphan which failed to be deleted
+	resultFailed = "failed"
+)
+
+// AuditRecord is a line of the audit log of the cleanup
+type AuditRecord struct {
+	Time      string `json:"time"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	CasType   string `json:"casType"`
+	Node      string `json:"node"`
+	Size      string `json:"size"`
+	Result    string `json:"result"`
+	Message   string `json:"message,omitempty"`
+}
+
+// Cleanup deletes the orphaned ZFSVolumes, LVMVolumes & the retained PVs
+// which are Released, of one or all the engines. Nothing is deleted on a dry
+// run, else the user is asked to confirm unless yes is set. The deleted
+// resources are appended to the audit log. The volumes which are still
+// mounted by a pod or attached to a node are never deleted.
+func Cleanup(casType string, dryRun, yes bool, auditLog string) error {
+	k := client.NewK8sClient()
+	orphans, err := List(k, casType)
+	if err != nil {
+		return err
+	}
+	inUse, err := volumeUsers(k)
+	if err != nil {
+		return err
+	}
+	candidates, skipped := cleanupCandidates(orphans, inUse)
+	for _, o := range skipped {
+		fmt.Printf("skipping %s %s, it is still used by %s\n", o.Kind, o.Name, strings.Join(inUse[o.Name], ", "))
+	}
+	if len(candidates) == 0 {
+		fmt.Println("No orphaned volumes to clean up")
+		return nil
+	}
+	var rows []metav1.TableRow
+	for _, o := range candidates {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{o.Kind, o.Name, o.Namespace, o.CasType, o.Node, o.Size, o.Reason}})
+	}
+	util.TablePrinter(util.OrphanColumnDefinitions, rows, printers.PrintOptions{})
+	if dryRun {
+		fmt.Printf("\n%d resources would be deleted, nothing is deleted in a dry run, run again with --dry-run=false to delete them\n", len(candidates))
+		return nil
+	}
+	if !yes && !util.PromptToStartAgain(fmt.Sprintf("Delete these %d resources, their data will be lost [y/N]", len(candidates)), false) {
+		fmt.Println("Cleanup cancelled, nothing is deleted")
+		return nil
+	}
+	f, err := os.OpenFile(auditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open the audit log %s: %v", auditLog, err)
+	}
+	defer f.Close()
+	return cleanup(k, candidates, f, time.Now)
+}
+
+// cleanupCandidates splits the orphans which can be deleted by the cleanup
+// into the ones to delete & the ones skipped as they are still in use
+func cleanupCandidates(orphans []util.Orphan, inUse map[string][]string) ([]util.Orphan, []util.Orphan) {
+	var candidates, skipped []util.Orphan
+	for _, o := range orphans {
+		if _, ok := deleteFuncs()[o.Kind]; !ok {
+			continue
+		}
+		if len(inUse[o.Name]) > 0 {
+			skipped = append(skipped, o)
+			continue
+		}
+		candidates = append(candidates, o)
+	}
+	return candidates, skipped
+}
+
+// cleanup deletes the orphans & writes an audit record of each of them. The
+// volume users are fetched again, so the volumes mounted since the orphans
+// were listed are skipped.
+func cleanup(k *client.K8sClient, orphans []util.Orphan, audit io.Writer, now func() time.Time) error {
+	inUse, err := volumeUsers(k)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(audit)
+	failed := 0
+	for _, o := range orphans {
+		record := AuditRecord{Time: now().UTC().Format(time.RFC3339), Kind: o.Kind, Name: o.Name, Namespace: o.Namespace,
+			CasType: o.CasType, Node: o.Node, Size: o.Size, Result: resultDeleted}
+		if users := inUse[o.Name]; len(users) > 0 {
+			record.Result = resultSkipped
+			record.Message = "still used by " + strings.Join(users, ", ")
+		} else if err := deleteFuncs()[o.Kind](k, o); err != nil {
+			failed++
+			record.Result = resultFailed
+			record.Message = err.Error()
+		}
+		fmt.Printf("%s %s %s\n", o.Kind, o.Name, record.Result)
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to write the audit log: %v", err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d resources failed to be deleted", failed, len(orphans))
+	}
+	return nil
+}
+
+// volumeUsers returns the pods of the PVCs bound to a volume & the
+// VolumeAttachments of a volume, by the volume's name. The PVs, ZFSVolumes &
+// LVMVolumes of a volume share its name.
+func volumeUsers(k *client.K8sClient) (map[string][]string, error) {
+	pods, err := k.GetAllPods("")
+	if err != nil {
+		return nil, err
+	}
+	pvcs, err := k.GetPVCs("", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	vas, err := k.GetVolumeAttachments()
+	if err != nil {
+		return nil, err
+	}
+	volumes := make(map[string]string)
+	for _, pvc := range pvcs.Items {
+		volumes[pvc.Namespace+"/"+pvc.Name] = pvc.Spec.VolumeName
+	}
+	users := make(map[string][]string)
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, v := range pod.Spec.Volumes {
+			var claim string
+			switch {
+			case v.PersistentVolumeClaim != nil:
+				claim = v.PersistentVolumeClaim.ClaimName
+			case v.Ephemeral != nil:
+				// the PVCs of the generic ephemeral volumes are named after the pod
+				claim = pod.Name + "-" + v.Name
+			default:
+				continue
+			}
+			if volume := volumes[pod.Namespace+"/"+claim]; volume != "" {
+				users[volume] = append(users[volume], "pod "+pod.Namespace+"/"+pod.Name)
+			}
+		}
+	}
+	for _, va := range vas.Items {
+		if va.Spec.Source.PersistentVolumeName != nil {
+			volume := *va.Spec.Source.PersistentVolumeName
+			users[volume] = append(users[volume], "volumeattachment "+va.Name+" on node "+va.Spec.NodeName)
+		}
+	}
+	return users, nil
+}
+
+// deleteFuncs returns a map of the kinds of the orphans deleted by the
+// cleanup to the functions deleting them
+func deleteFuncs() map[string]func(*client.K8sClient, util.Orphan) error {
+	return map[string]func(*client.K8sClient, util.Orphan) error{
+		"ZFSVolume": func(k *client.K8sClient, o util.Orphan) error {
+			return k.DeleteZFSVolume(o.Name, o.Namespace)
+		},
+		"LVMVolume": func(k *client.K8sClient, o util.Orphan) error {
+			return k.DeleteLVMVolume(o.Name, o.Namespace)
+		},
+		"PersistentVolume": func(k *client.K8sClient, o util.Orphan) error {
+			return k.DeletePV(o.Name)
+		},
+	}
+}
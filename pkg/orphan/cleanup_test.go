@@ -0,0 +1,111 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCleanupCandidates(t *testing.T) {
+	zvol := util.Orphan{Kind: "ZFSVolume", Name: "pvc-gone"}
+	mounted := util.Orphan{Kind: "LVMVolume", Name: "pvc-mounted"}
+	pv := util.Orphan{Kind: "PersistentVolume", Name: "pvc-retained"}
+	snap := util.Orphan{Kind: "ZFSSnapshot", Name: "snapshot-1"}
+	va := util.Orphan{Kind: "VolumeAttachment", Name: "csi-leftover"}
+	candidates, skipped := cleanupCandidates([]util.Orphan{zvol, mounted, pv, snap, va},
+		map[string][]string{"pvc-mounted": {"pod default/app"}})
+	if want := []util.Orphan{zvol, pv}; !reflect.DeepEqual(candidates, want) {
+		t.Errorf("cleanupCandidates() candidates = %v, want %v", candidates, want)
+	}
+	if want := []util.Orphan{mounted}; !reflect.DeepEqual(skipped, want) {
+		t.Errorf("cleanupCandidates() skipped = %v, want %v", skipped, want)
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	lostClaim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pvc-mounted"},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimLost},
+	}
+	app := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+		}}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(retainedHostpath, lostClaim, app,
+			newVolumeAttachment("csi-attached", util.ZFSCSIDriver, "pvc-attached")),
+		ZFCS:  zfsfake.NewSimpleClientset(newZFSVol("pvc-gone", "Ready"), newZFSVol("pvc-mounted", "Ready"), newZFSVol("pvc-attached", "Ready")),
+		LVMCS: lvmfake.NewSimpleClientset(&orphanLVMVol),
+	}
+	orphans := []util.Orphan{
+		{Kind: "LVMVolume", Name: "pvc-lvm-gone", Namespace: "openebs", CasType: util.LVMCasType, Node: "node2", Size: "1.0GiB"},
+		{Kind: "PersistentVolume", Name: "pvc-retained", CasType: util.LocalPvHostpathCasType, Node: "node1", Size: "4.0GiB"},
+		{Kind: "ZFSVolume", Name: "pvc-attached", Namespace: "openebs", CasType: util.ZFSCasType, Node: "node1", Size: "4.0GiB"},
+		{Kind: "ZFSVolume", Name: "pvc-gone", Namespace: "openebs", CasType: util.ZFSCasType, Node: "node1", Size: "4.0GiB"},
+		{Kind: "ZFSVolume", Name: "pvc-mounted", Namespace: "openebs", CasType: util.ZFSCasType, Node: "node1", Size: "4.0GiB"},
+		{Kind: "ZFSVolume", Name: "pvc-deleted", Namespace: "openebs", CasType: util.ZFSCasType, Node: "node1", Size: "4.0GiB"},
+	}
+	now := func() time.Time { return time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC) }
+	var audit bytes.Buffer
+	if err := cleanup(k, orphans, &audit, now); err == nil {
+		t.Errorf("cleanup() error = nil, want the deletion of pvc-deleted to fail")
+	}
+	var results []string
+	dec := json.NewDecoder(&audit)
+	for dec.More() {
+		var r AuditRecord
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("invalid audit record: %v", err)
+		}
+		if r.Time != "2022-01-02T03:04:05Z" {
+			t.Errorf("audit record of %s has time %s", r.Name, r.Time)
+		}
+		results = append(results, r.Name+" "+r.Result)
+	}
+	want := []string{"pvc-lvm-gone deleted", "pvc-retained deleted", "pvc-attached skipped", "pvc-gone deleted", "pvc-mounted skipped", "pvc-deleted failed"}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("cleanup() audit results = %v, want %v", results, want)
+	}
+	zvols, _ := k.ZFCS.ZfsV1().ZFSVolumes("").List(context.TODO(), metav1.ListOptions{})
+	var left []string
+	for _, zv := range zvols.Items {
+		left = append(left, zv.Name)
+	}
+	if want := []string{"pvc-attached", "pvc-mounted"}; !reflect.DeepEqual(left, want) {
+		t.Errorf("cleanup() left the ZFSVolumes %v, want %v", left, want)
+	}
+	if _, err := k.GetPV("pvc-retained"); err == nil {
+		t.Errorf("cleanup() didn't delete the pv pvc-retained")
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphan
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetLVMOrphans returns the LVMVolumes without a PV & the LVMSnapshots whose
+// source LVMVolume is deleted, the LVMVolumes being created or deleted are
+// skipped
+func GetLVMOrphans(k *client.K8sClient, pvs map[string]corev1.PersistentVolume) ([]util.Orphan, error) {
+	_, lvols, err := k.GetLVMvol(nil, util.Map, "", "", util.MapOptions{Key: util.Name})
+	if err != nil {
+		return nil, err
+	}
+	snaps, err := k.GetLVMSnapshots(nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var orphans []util.Orphan
+	for _, lv := range lvols {
+		if _, ok := pvs[lv.Name]; ok || lv.Status.State == volumePendingState || lv.DeletionTimestamp != nil {
+			continue
+		}
+		orphans = append(orphans, util.Orphan{
+			Kind:      "LVMVolume",
+			Name:      lv.Name,
+			Namespace: lv.Namespace,
+			CasType:   util.LVMCasType,
+			Node:      lv.Spec.OwnerNodeID,
+			Size:      util.ConvertToIBytes(lv.Spec.Capacity),
+			Reason:    fmt.Sprintf("no pv, the logical volume is kept in volume group %s", lv.Spec.VolGroup),
+		})
+	}
+	for _, ls := range snaps.Items {
+		volume := ls.Labels[util.PersistentVolumeKey]
+		if _, ok := lvols[volume]; ok || ls.DeletionTimestamp != nil {
+			continue
+		}
+		size := util.NotAvailable
+		if ls.Spec.SnapSize != "" {
+			size = util.ConvertToIBytes(ls.Spec.SnapSize)
+		}
+		orphans = append(orphans, util.Orphan{
+			Kind:      "LVMSnapshot",
+			Name:      ls.Name,
+			Namespace: ls.Namespace,
+			CasType:   util.LVMCasType,
+			Node:      ls.Spec.OwnerNodeID,
+			Size:      size,
+			Reason:    fmt.Sprintf("its source volume %s is deleted", volume),
+		})
+	}
+	return orphans, nil
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphan
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// orphanListKind is the kind of the machine-readable orphans listing
+	orphanListKind = "OrphanList"
+
+	// failedHostpathCleanupKind is the kind of the hostpath directories of
+	// the deleted PVs whose cleanup pods didn't succeed
+	failedHostpathCleanupKind = "FailedHostpathCleanup"
+	// leakedDirectoriesNote tells that the hostpath directories without a
+	// cleanup pod aren't found, the directories of the nodes aren't listed
+	leakedDirectoriesNote = "the leaked hostpath directories without a cleanup pod aren't detected, only the failed cleanups are listed"
+	// cleanupPodPrefix is the name prefix of the helper pods of the
+	// dynamic-localpv-provisioner removing the directories of the deleted PVs
+	cleanupPodPrefix = "cleanup-"
+	// volumePendingState is the state of a ZFSVolume or LVMVolume being
+	// created, its PV is created once it is ready
+	volumePendingState = "Pending"
+)
+
+// pvNameRegex matches the names of the dynamically provisioned PVs, i.e.
+// pvc-<uuid of the pvc>
+var pvNameRegex = regexp.MustCompile(`^pvc-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// Get shows the leftover resources of one or all the engines, i.e. the
+// volumes without a PV, the Released PVs which are retained, the hostpath
+// directories which failed to be cleaned up, the snapshots of deleted
+// volumes & the VolumeAttachments of deleted PVs
+func Get(casType, output string) error {
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	orphans, err := List(k, casType)
+	if err != nil {
+		return err
+	}
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, 0, len(orphans))
+		for _, o := range orphans {
+			items = append(items, o)
+		}
+		return util.PrintList(output, orphanListKind, items)
+	}
+	if len(orphans) == 0 {
+		fmt.Println("No orphaned resources found")
+	} else {
+		var rows []metav1.TableRow
+		for _, o := range orphans {
+			rows = append(rows, metav1.TableRow{Cells: []interface{}{o.Kind, o.Name, o.Namespace, o.CasType, o.Node, o.Size, o.Reason}})
+		}
+		util.TablePrinter(util.OrphanColumnDefinitions, rows, printers.PrintOptions{})
+	}
+	if casType == "" || casType == util.LocalPvHostpathCasType {
+		fmt.Printf("\nNote: %s\n", leakedDirectoriesNote)
+	}
+	return nil
+}
+
+// List returns the leftover resources of the cas-type, or of all the
+// engines if it is empty, sorted by their kind & name
+func List(k *client.K8sClient, casType string) ([]util.Orphan, error) {
+	if _, ok := util.CasTypeToComponentNamesMap[casType]; casType != "" && !ok {
+		return nil, fmt.Errorf("cas-type %s is not supported", casType)
+	}
+	pvList, err := k.GetPVs(nil, "", "")
+	if err != nil {
+		return nil, err
+	}
+	pvs := make(map[string]corev1.PersistentVolume)
+	for _, pv := range pvList.Items {
+		pvs[pv.Name] = pv
+	}
+	var orphans []util.Orphan
+	// 1. Get the volumes & snapshots of the engines without a PV or a volume
+	if f, ok := CasListMap()[casType]; ok {
+		found, err := f(k, pvs)
+		if err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, found...)
+	} else if casType == "" {
+		// the errors are ignored as all the engines may not be installed
+		for _, f := range CasList() {
+			if found, err := f(k, pvs); err == nil {
+				orphans = append(orphans, found...)
+			}
+		}
+	}
+	// 2. Get the retained PVs which are Released
+	orphans = append(orphans, releasedPVs(pvList.Items, casType)...)
+	// 3. Get the hostpath directories whose cleanup failed
+	if casType == "" || casType == util.LocalPvHostpathCasType {
+		found, err := failedHostpathCleanups(k, pvs)
+		if err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, found...)
+	}
+	// 4. Get the VolumeAttachments of the deleted PVs
+	found, err := volumeAttachments(k, pvs, casType)
+	if err != nil {
+		return nil, err
+	}
+	orphans = append(orphans, found...)
+	sort.SliceStable(orphans, func(i, j int) bool {
+		if orphans[i].Kind != orphans[j].Kind {
+			return orphans[i].Kind < orphans[j].Kind
+		}
+		return orphans[i].Name < orphans[j].Name
+	})
+	return orphans, nil
+}
+
+// releasedPVs returns the OpenEBS PVs of the cas-type with the Retain
+// reclaim policy whose PVC is deleted, their volumes are kept till the PVs
+// are deleted
+func releasedPVs(pvs []corev1.PersistentVolume, casType string) []util.Orphan {
+	var orphans []util.Orphan
+	for _, pv := range pvs {
+		if pv.Status.Phase != corev1.VolumeReleased || pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
+			continue
+		}
+		pvCasType := casTypeOf(&pv)
+		if _, ok := util.CasTypeToComponentNamesMap[pvCasType]; !ok || (casType != "" && pvCasType != casType) {
+			continue
+		}
+		claim := util.NotAvailable
+		if pv.Spec.ClaimRef != nil {
+			claim = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+		}
+		orphans = append(orphans, util.Orphan{
+			Kind:    "PersistentVolume",
+			Name:    pv.Name,
+			CasType: pvCasType,
+			Node:    util.GetNodeFromPV(&pv),
+			Size:    util.ConvertToIBytes(pv.Spec.Capacity.Storage().String()),
+			Reason:  fmt.Sprintf("released pv with the Retain reclaim policy, its pvc %s is deleted", claim),
+		})
+	}
+	return orphans
+}
+
+// failedHostpathCleanups returns the directories of the deleted hostpath PVs
+// whose cleanup pods didn't succeed. The directories of the nodes aren't
+// listed, so the leaked ones which are referenced by no cleanup pod aren't
+// found.
+// The cleanup pods are created in the namespace of the provisioner, so none
+// are found if it isn't running.
+func failedHostpathCleanups(k *client.K8sClient, pvs map[string]corev1.PersistentVolume) ([]util.Orphan, error) {
+	ns, err := k.GetOpenEBSNamespace(util.LocalPvHostpathCasType)
+	if err != nil {
+		return nil, nil
+	}
+	pods, err := k.GetAllPods(ns)
+	if err != nil {
+		return nil, err
+	}
+	var orphans []util.Orphan
+	for _, pod := range pods.Items {
+		pvName := strings.TrimPrefix(pod.Name, cleanupPodPrefix)
+		if pvName == pod.Name || !pvNameRegex.MatchString(pvName) || pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		if _, ok := pvs[pvName]; ok {
+			continue
+		}
+		// the parent directory of the PV directories is mounted as a hostPath
+		var dir string
+		for _, v := range pod.Spec.Volumes {
+			if v.HostPath != nil {
+				dir = strings.TrimSuffix(v.HostPath.Path, "/") + "/" + pvName
+			}
+		}
+		if dir == "" {
+			continue
+		}
+		orphans = append(orphans, util.Orphan{
+			Kind:      failedHostpathCleanupKind,
+			Name:      dir,
+			Namespace: pod.Namespace,
+			CasType:   util.LocalPvHostpathCasType,
+			Node:      pod.Spec.NodeName,
+			Size:      util.NotAvailable,
+			Reason:    fmt.Sprintf("the cleanup pod %s of the deleted pv %s is %s", pod.Name, pvName, pod.Status.Phase),
+		})
+	}
+	return orphans, nil
+}
+
+// volumeAttachments returns the VolumeAttachments of the OpenEBS CSI drivers
+// of the cas-type whose PVs are deleted
+func volumeAttachments(k *client.K8sClient, pvs map[string]corev1.PersistentVolume, casType string) ([]util.Orphan, error) {
+	vas, err := k.GetVolumeAttachments()
+	if err != nil {
+		return nil, err
+	}
+	var orphans []util.Orphan
+	for _, va := range vas.Items {
+		vaCasType, ok := util.ProvsionerAndCasTypeMap[va.Spec.Attacher]
+		if !ok || (casType != "" && vaCasType != casType) {
+			continue
+		}
+		pvName := va.Spec.Source.PersistentVolumeName
+		if pvName == nil {
+			continue
+		}
+		if _, ok := pvs[*pvName]; ok {
+			continue
+		}
+		orphans = append(orphans, util.Orphan{
+			Kind:    "VolumeAttachment",
+			Name:    va.Name,
+			CasType: vaCasType,
+			Node:    va.Spec.NodeName,
+			Size:    util.NotAvailable,
+			Reason:  fmt.Sprintf("its pv %s is deleted", *pvName),
+		})
+	}
+	return orphans, nil
+}
+
+// casTypeOf returns the cas-type of the PV, the dynamic-localpv-provisioner
+// PVs have the local-hostpath & local-device labels
+func casTypeOf(pv *corev1.PersistentVolume) string {
+	return util.NormalizeCasType(util.GetCasTypeFromPV(pv))
+}
+
+// CasList returns a list of functions by cas-types for the leftover resources
+func CasList() []func(*client.K8sClient, map[string]corev1.PersistentVolume) ([]util.Orphan, error) {
+	// a good hack to implement immutable lists in Golang & also write tests for it
+	return []func(*client.K8sClient, map[string]corev1.PersistentVolume) ([]util.Orphan, error){GetZFSOrphans, GetLVMOrphans}
+}
+
+// CasListMap returns a map cas-types to functions for the leftover resources
+func CasListMap() map[string]func(*client.K8sClient, map[string]corev1.PersistentVolume) ([]util.Orphan, error) {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient, map[string]corev1.PersistentVolume) ([]util.Orphan, error){
+		util.ZFSCasType: GetZFSOrphans,
+		util.LVMCasType: GetLVMOrphans,
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphan
+
+import (
+	"reflect"
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestList(t *testing.T) {
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(zfsPV1, retainedHostpath, releasedHostpath,
+			&hostpathProvisionerPod, newCleanupPod("openebs", oldPVName, corev1.PodFailed), newCleanupPod("openebs", donePVName, corev1.PodSucceeded),
+			// the pods named like cleanup pods which aren't the ones of the provisioner
+			newCleanupPod("default", "pvc-7e6d5c4b-3a2f-4e1d-8c0b-9a8f7e6d5c4b", corev1.PodFailed), newCleanupPod("openebs", "cache", corev1.PodFailed),
+			newVolumeAttachment("csi-attached", util.ZFSCSIDriver, "pvc-1"),
+			newVolumeAttachment("csi-leftover", util.ZFSCSIDriver, "pvc-deleted"),
+			newVolumeAttachment("csi-other-driver", "ebs.csi.aws.com", "pvc-deleted"),
+		),
+		ZFCS: zfsfake.NewSimpleClientset(newZFSVol("pvc-1", "Ready"), newZFSVol("pvc-gone", "Ready"), newZFSVol("pvc-new", "Pending"),
+			newZFSSnap("snapshot-1", "pvc-1"), newZFSSnap("snapshot-2", "pvc-deleted")),
+		LVMCS: lvmfake.NewSimpleClientset(&orphanLVMVol, &orphanLVMSnap),
+	}
+	hostpathDir := util.Orphan{Kind: failedHostpathCleanupKind, Name: "/var/openebs/local/" + oldPVName, Namespace: "openebs", CasType: util.LocalPvHostpathCasType,
+		Node: "node1", Size: util.NotAvailable, Reason: "the cleanup pod cleanup-" + oldPVName + " of the deleted pv " + oldPVName + " is Failed"}
+	lvmSnap := util.Orphan{Kind: "LVMSnapshot", Name: "snapshot-lvm", Namespace: "openebs", CasType: util.LVMCasType,
+		Node: "node2", Size: "500.0MiB", Reason: "its source volume pvc-lvm-deleted is deleted"}
+	lvmVol := util.Orphan{Kind: "LVMVolume", Name: "pvc-lvm-gone", Namespace: "openebs", CasType: util.LVMCasType,
+		Node: "node2", Size: "1.0GiB", Reason: "no pv, the logical volume is kept in volume group lvmvg"}
+	retainedPV := util.Orphan{Kind: "PersistentVolume", Name: "pvc-retained", CasType: util.LocalPvHostpathCasType,
+		Node: "node1", Size: "4.0GiB", Reason: "released pv with the Retain reclaim policy, its pvc default/pvc-retained-claim is deleted"}
+	attachment := util.Orphan{Kind: "VolumeAttachment", Name: "csi-leftover", CasType: util.ZFSCasType,
+		Node: "node1", Size: util.NotAvailable, Reason: "its pv pvc-deleted is deleted"}
+	zfsSnap := util.Orphan{Kind: "ZFSSnapshot", Name: "snapshot-2", Namespace: "openebs", CasType: util.ZFSCasType,
+		Node: "node1", Size: util.NotAvailable, Reason: "its source volume pvc-deleted is deleted"}
+	zfsVol := util.Orphan{Kind: "ZFSVolume", Name: "pvc-gone", Namespace: "openebs", CasType: util.ZFSCasType,
+		Node: "node1", Size: "4.0GiB", Reason: "no pv, the dataset is kept in pool zfspv"}
+	tests := []struct {
+		name    string
+		casType string
+		want    []util.Orphan
+		wantErr bool
+	}{
+		{"all the engines", "", []util.Orphan{hostpathDir, lvmSnap, lvmVol, retainedPV, attachment, zfsSnap, zfsVol}, false},
+		{"zfs engine", util.ZFSCasType, []util.Orphan{attachment, zfsSnap, zfsVol}, false},
+		{"hostpath engine", util.LocalPvHostpathCasType, []util.Orphan{hostpathDir, retainedPV}, false},
+		{"unsupported cas-type", "cstor", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := List(k, tt.casType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphan
+
+import (
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newPV(name string, labels map[string]string, csi *corev1.CSIPersistentVolumeSource, policy corev1.PersistentVolumeReclaimPolicy, phase corev1.PersistentVolumePhase) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("4Gi")},
+			PersistentVolumeSource:        corev1.PersistentVolumeSource{CSI: csi},
+			PersistentVolumeReclaimPolicy: policy,
+			ClaimRef:                      &corev1.ObjectReference{Namespace: "default", Name: name + "-claim"},
+			NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "kubernetes.io/hostname", Operator: corev1.NodeSelectorOpIn, Values: []string{"node1"}}}},
+			}}},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: phase},
+	}
+}
+
+var (
+	zfsPV1           = newPV("pvc-1", nil, &corev1.CSIPersistentVolumeSource{Driver: util.ZFSCSIDriver}, corev1.PersistentVolumeReclaimDelete, corev1.VolumeBound)
+	retainedHostpath = newPV("pvc-retained", map[string]string{util.OpenEBSCasTypeKey: util.LocalHostpathCasLabel}, nil, corev1.PersistentVolumeReclaimRetain, corev1.VolumeReleased)
+	releasedHostpath = newPV("pvc-released", map[string]string{util.OpenEBSCasTypeKey: util.LocalHostpathCasLabel}, nil, corev1.PersistentVolumeReclaimDelete, corev1.VolumeReleased)
+)
+
+func newZFSVol(name, state string) *zfs.ZFSVolume {
+	return &zfs.ZFSVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openebs"},
+		Spec:       zfs.VolumeInfo{OwnerNodeID: "node1", PoolName: "zfspv", Capacity: "4294967296"},
+		Status:     zfs.VolStatus{State: state},
+	}
+}
+
+func newZFSSnap(name, volume string) *zfs.ZFSSnapshot {
+	return &zfs.ZFSSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openebs", Labels: map[string]string{util.PersistentVolumeKey: volume}},
+		Spec:       zfs.VolumeInfo{OwnerNodeID: "node1", PoolName: "zfspv"},
+	}
+}
+
+var orphanLVMVol = lvm.LVMVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-lvm-gone", Namespace: "openebs"},
+	Spec:       lvm.VolumeInfo{OwnerNodeID: "node2", VolGroup: "lvmvg", Capacity: "1073741824"},
+	Status:     lvm.VolStatus{State: "Ready"},
+}
+
+var orphanLVMSnap = lvm.LVMSnapshot{
+	ObjectMeta: metav1.ObjectMeta{Name: "snapshot-lvm", Namespace: "openebs", Labels: map[string]string{util.PersistentVolumeKey: "pvc-lvm-deleted"}},
+	Spec:       lvm.LVMSnapshotSpec{OwnerNodeID: "node2", VolGroup: "lvmvg", SnapSize: "524288000"},
+}
+
+const (
+	// oldPVName is a deleted hostpath PV whose directory isn't cleaned up
+	oldPVName = "pvc-4f6a2c1e-8b3d-4e7a-9c5f-1d2e3b4a5c6d"
+	// donePVName is a deleted hostpath PV whose directory is cleaned up
+	donePVName = "pvc-9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+)
+
+var hostpathProvisionerPod = corev1.Pod{
+	ObjectMeta: metav1.ObjectMeta{Name: "openebs-localpv-provisioner-7d9f8c6b5-abcde", Namespace: "openebs",
+		Labels: map[string]string{"openebs.io/component-name": util.HostpathComponentNames}},
+	Status: corev1.PodStatus{Phase: corev1.PodRunning},
+}
+
+func newCleanupPod(namespace, pvName string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: cleanupPodPrefix + pvName, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			NodeName: "node1",
+			Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{Path: "/var/openebs/local/"},
+			}}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func newVolumeAttachment(name, attacher, pv string) *storagev1.VolumeAttachment {
+	return &storagev1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: storagev1.VolumeAttachmentSpec{
+			Attacher: attacher,
+			NodeName: "node1",
+			Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pv},
+		},
+	}
+}
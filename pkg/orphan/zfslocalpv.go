@@ -0,0 +1,70 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphan
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetZFSOrphans returns the ZFSVolumes without a PV & the ZFSSnapshots whose
+// source ZFSVolume is deleted, the ZFSVolumes being created or deleted are
+// skipped
+func GetZFSOrphans(k *client.K8sClient, pvs map[string]corev1.PersistentVolume) ([]util.Orphan, error) {
+	_, zvols, err := k.GetZFSVols(nil, util.Map, "", "", util.MapOptions{Key: util.Name})
+	if err != nil {
+		return nil, err
+	}
+	snaps, err := k.GetZFSSnapshots(nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var orphans []util.Orphan
+	for _, zv := range zvols {
+		if _, ok := pvs[zv.Name]; ok || zv.Status.State == volumePendingState || zv.DeletionTimestamp != nil {
+			continue
+		}
+		orphans = append(orphans, util.Orphan{
+			Kind:      "ZFSVolume",
+			Name:      zv.Name,
+			Namespace: zv.Namespace,
+			CasType:   util.ZFSCasType,
+			Node:      zv.Spec.OwnerNodeID,
+			Size:      util.ConvertToIBytes(zv.Spec.Capacity),
+			Reason:    fmt.Sprintf("no pv, the dataset is kept in pool %s", zv.Spec.PoolName),
+		})
+	}
+	for _, zs := range snaps.Items {
+		volume := zs.Labels[util.PersistentVolumeKey]
+		if _, ok := zvols[volume]; ok || zs.DeletionTimestamp != nil {
+			continue
+		}
+		orphans = append(orphans, util.Orphan{
+			Kind:      "ZFSSnapshot",
+			Name:      zs.Name,
+			Namespace: zs.Namespace,
+			CasType:   util.ZFSCasType,
+			Node:      zs.Spec.OwnerNodeID,
+			Size:      util.NotAvailable,
+			Reason:    fmt.Sprintf("its source volume %s is deleted", volume),
+		})
+	}
+	return orphans, nil
+}
@@ -40,10 +40,21 @@ MOUNTED BY       : {{.MountPods}}
 func DescribeGenericVolumeClaim(pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume, casType string, mountPods string) error {
 	// Incase a not known casType pvc is entered show minimal details pertaining to the PVC
 	// 1. Fill in the PVC details.
+	pvcInfo := newGenericPVCInfo(pvc, pv, casType, mountPods)
+	// 2. Print the details
+	_ = util.PrintByTemplate("pvc", genericPvcInfoTemplate, pvcInfo)
+
+	return nil
+}
+
+// newGenericPVCInfo fills in the minimal details of a PersistentVolumeClaim
+func newGenericPVCInfo(pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume, casType string, mountPods string) util.PVCInfo {
 	pvcInfo := util.PVCInfo{}
 	pvcInfo.Name = pvc.Name
 	pvcInfo.Namespace = pvc.Namespace
-	pvcInfo.StorageClassName = *pvc.Spec.StorageClassName
+	if pvc.Spec.StorageClassName != nil {
+		pvcInfo.StorageClassName = *pvc.Spec.StorageClassName
+	}
 	quantity := pvc.Status.Capacity[util.StorageKey]
 	pvcInfo.Size = util.ConvertToIBytes(quantity.String())
 	if pv != nil {
@@ -52,8 +63,5 @@ func DescribeGenericVolumeClaim(pvc *corev1.PersistentVolumeClaim, pv *corev1.Pe
 	}
 	pvcInfo.CasType = casType
 	pvcInfo.MountPods = mountPods
-	// 2. Print the details
-	_ = util.PrintByTemplate("pvc", genericPvcInfoTemplate, pvcInfo)
-
-	return nil
+	return pvcInfo
 }
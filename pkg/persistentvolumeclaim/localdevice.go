@@ -0,0 +1,70 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package persistentvolumeclaim
+
+import (
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/openebs/openebsctl/pkg/volume"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	localDevicePvcInfoTemplate = `
+{{.Name}} Details  :
+-------------------
+NAME               : {{.Name}}
+NAMESPACE          : {{.Namespace}}
+CAS TYPE           : {{.CasType}}
+BOUND VOLUME       : {{.BoundVolume}}
+STORAGE CLASS      : {{.StorageClassName}}
+SIZE               : {{.Size}}
+PVC STATUS         : {{.PVCStatus}}
+MOUNTED BY         : {{.MountPods}}
+`
+)
+
+// DescribeLocalDeviceVolumeClaim describes a localpv-device PersistentVolumeClaim
+func DescribeLocalDeviceVolumeClaim(c *client.K8sClient, pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume, mountPods string) error {
+	// 1. Fill in the PVC information
+	localDevicePVCInfo := newLocalDevicePVCInfo(pvc, mountPods)
+	_ = util.PrintByTemplate("localDevicePvc", localDevicePvcInfoTemplate, localDevicePVCInfo)
+
+	// 2. If PV is present Describe the device volume & its BlockDevice
+	if pv != nil {
+		_ = volume.DescribeLocalDeviceVolume(c, pv)
+	} else {
+		printClaimEvents(c, pvc, nil)
+	}
+	return nil
+}
+
+// newLocalDevicePVCInfo fills in the details of a localpv-device PersistentVolumeClaim
+func newLocalDevicePVCInfo(pvc *corev1.PersistentVolumeClaim, mountPods string) util.LocalDevicePVCInfo {
+	info := util.LocalDevicePVCInfo{
+		Name:        pvc.Name,
+		Namespace:   pvc.Namespace,
+		CasType:     util.LocalPvDeviceCasType,
+		BoundVolume: pvc.Spec.VolumeName,
+		Size:        pvc.Spec.Resources.Requests.Storage().String(),
+		PVCStatus:   pvc.Status.Phase,
+		MountPods:   mountPods,
+	}
+	if pvc.Spec.StorageClassName != nil {
+		info.StorageClassName = *pvc.Spec.StorageClassName
+	}
+	return info
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package persistentvolumeclaim
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewLocalDevicePVCInfo(t *testing.T) {
+	sc := "openebs-device"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "mongo-device", Namespace: "local-app"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &sc,
+			VolumeName:       "pvc-device-1",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("4Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+	want := util.LocalDevicePVCInfo{
+		Name:             "mongo-device",
+		Namespace:        "local-app",
+		CasType:          util.LocalPvDeviceCasType,
+		BoundVolume:      "pvc-device-1",
+		StorageClassName: "openebs-device",
+		Size:             "4Gi",
+		PVCStatus:        corev1.ClaimBound,
+		MountPods:        "none",
+	}
+	if got := newLocalDevicePVCInfo(pvc, "none"); !reflect.DeepEqual(got, want) {
+		t.Errorf("newLocalDevicePVCInfo() = %+v, want %+v", got, want)
+	}
+	// the storage class of the PVC is optional
+	pvc.Spec.StorageClassName = nil
+	want.StorageClassName = ""
+	if got := newLocalDevicePVCInfo(pvc, "none"); !reflect.DeepEqual(got, want) {
+		t.Errorf("newLocalDevicePVCInfo() = %+v, want %+v", got, want)
+	}
+}
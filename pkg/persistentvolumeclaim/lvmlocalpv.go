@@ -41,16 +41,7 @@ MOUNTED BY         : {{.MountPods}}
 // DescribeLVMVolumeClaim describes a LVM storage engine PersistentVolumeClaim
 func DescribeLVMVolumeClaim(c *client.K8sClient, pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume, mountPods string) error {
 	// 1. Fill in the PVC information
-	lvmPVCinfo := util.LVMPVCInfo{
-		Name:             pvc.Name,
-		Namespace:        pvc.Namespace,
-		CasType:          util.LVMCasType,
-		BoundVolume:      pvc.Spec.VolumeName,
-		StorageClassName: *pvc.Spec.StorageClassName,
-		Size:             pvc.Spec.Resources.Requests.Storage().String(),
-		PVCStatus:        pvc.Status.Phase,
-		MountPods:        mountPods,
-	}
+	lvmPVCinfo := newLVMPVCInfo(pvc, mountPods)
 
 	// 2. If PV is present Describe the LVM Volume
 	if pv != nil {
@@ -59,7 +50,22 @@ func DescribeLVMVolumeClaim(c *client.K8sClient, pvc *corev1.PersistentVolumeCla
 	} else {
 		// Show only PVC details if volume is not found.
 		_ = util.PrintByTemplate("lvmPvc", lvmPvcInfoTemplate, lvmPVCinfo)
+		printClaimEvents(c, pvc, nil)
 	}
 
 	return nil
 }
+
+// newLVMPVCInfo fills in the details of a LVM storage engine PersistentVolumeClaim
+func newLVMPVCInfo(pvc *corev1.PersistentVolumeClaim, mountPods string) util.LVMPVCInfo {
+	return util.LVMPVCInfo{
+		Name:             pvc.Name,
+		Namespace:        pvc.Namespace,
+		CasType:          util.LVMCasType,
+		BoundVolume:      pvc.Spec.VolumeName,
+		StorageClassName: *pvc.Spec.StorageClassName,
+		Size:             pvc.Spec.Resources.Requests.Storage().String(),
+		PVCStatus:        pvc.Status.Phase,
+		MountPods:        mountPods,
+	}
+}
@@ -17,19 +17,28 @@ limitations under the License.
 package persistentvolumeclaim
 
 import (
+	"fmt"
+	"os"
 	"sort"
 
 	"github.com/openebs/openebsctl/pkg/client"
 	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/openebs/openebsctl/pkg/volume"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// pvcDescribeKind is the kind of the machine-readable pvc describe
+const pvcDescribeKind = "PersistentVolumeClaimDescriptionList"
+
 // Describe manages various implementations of PersistentVolumeClaim Describing
-func Describe(pvcs []string, namespace string, openebsNs string) error {
+func Describe(pvcs []string, namespace string, openebsNs string, output string) error {
 	if len(pvcs) == 0 || pvcs == nil {
 		return errors.New("please provide atleast one pvc name to describe")
 	}
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
 	// Clienset creation
 	k := client.NewK8sClient(openebsNs)
 
@@ -49,13 +58,14 @@ func Describe(pvcs []string, namespace string, openebsNs string) error {
 	} else {
 		nsPods = podList.Items
 	}
+	var items []interface{}
 	// 4. Range over the list of PVCs
 	for _, pvc := range pvcList.Items {
 		// 5. Fetch the storage class, used to get the cas-type
 		sc, _ := k.GetSC(*pvc.Spec.StorageClassName)
 		pv, _ := k.GetPV(pvc.Spec.VolumeName)
 		// 6. Get cas type
-		casType := util.GetCasType(pv, sc)
+		casType := util.NormalizeCasType(util.GetCasType(pv, sc))
 		mountPods := PodsToString(SortPods(GetMountPods(pvc.Name, nsPods)))
 		// 7. Assign a namespace corresponding to the engine
 		if openebsNs == "" {
@@ -64,7 +74,9 @@ func Describe(pvcs []string, namespace string, openebsNs string) error {
 			}
 		}
 		// 8. Describe the volume based on its casType
-		if desc, ok := CasDescribeMap()[casType]; ok {
+		if util.IsStructuredOutput(output) {
+			items = append(items, GetPVCDesc(k, &pvc, pv, casType, mountPods))
+		} else if desc, ok := CasDescribeMap()[casType]; ok {
 			err = desc(k, &pvc, pv, mountPods)
 			if err != nil {
 				continue
@@ -75,17 +87,60 @@ func Describe(pvcs []string, namespace string, openebsNs string) error {
 			if err != nil {
 				continue
 			}
+			printClaimEvents(k, &pvc, pv)
 		}
 	}
+	if util.IsStructuredOutput(output) {
+		return util.PrintList(output, pvcDescribeKind, items)
+	}
 	return nil
 }
 
+// GetPVCDesc returns the details of a PersistentVolumeClaim & its volume for
+// the machine-readable output
+func GetPVCDesc(k *client.K8sClient, pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume, casType string, mountPods string) util.PVCDesc {
+	desc := util.PVCDesc{Name: pvc.Name}
+	switch casType {
+	case util.ZFSCasType:
+		desc.PVC = newZFSPVCInfo(pvc, mountPods)
+	case util.LVMCasType:
+		desc.PVC = newLVMPVCInfo(pvc, mountPods)
+	case util.LocalPvDeviceCasType:
+		desc.PVC = newLocalDevicePVCInfo(pvc, mountPods)
+	default:
+		desc.PVC = newGenericPVCInfo(pvc, pv, casType, mountPods)
+	}
+	if pv != nil {
+		if volDesc, ok := volume.CasDescribeOutputMap()[casType]; ok {
+			v, err := volDesc(k, pv)
+			if err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, err)
+			}
+			desc.Volume = v
+		}
+	}
+	return desc
+}
+
+// printClaimEvents prints the events of the PVC & of its PV if it isn't nil
+func printClaimEvents(c *client.K8sClient, pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume) {
+	objects := []corev1.ObjectReference{{Kind: "PersistentVolumeClaim", Name: pvc.Name, Namespace: pvc.Namespace}}
+	if pv != nil {
+		objects = append(objects, corev1.ObjectReference{Kind: "PersistentVolume", Name: pv.Name})
+	}
+	// the events are only a part of the description, they are skipped on errors
+	if events, err := c.GetObjectEvents(objects...); err == nil {
+		util.PrintEvents(events)
+	}
+}
+
 // CasDescribeMap returns a map cas-types to functions for persistentvolumeclaim describing
 func CasDescribeMap() map[string]func(*client.K8sClient, *corev1.PersistentVolumeClaim, *corev1.PersistentVolume, string) error {
 	// a good hack to implement immutable maps in Golang & also write tests for it
 	return map[string]func(*client.K8sClient, *corev1.PersistentVolumeClaim, *corev1.PersistentVolume, string) error{
-		util.LVMCasType: DescribeLVMVolumeClaim,
-		util.ZFSCasType: DescribeZFSVolumeClaim,
+		util.LVMCasType:           DescribeLVMVolumeClaim,
+		util.ZFSCasType:           DescribeZFSVolumeClaim,
+		util.LocalPvDeviceCasType: DescribeLocalDeviceVolumeClaim,
 	}
 }
 
@@ -40,23 +40,29 @@ MOUNTED BY         : {{.MountPods}}
 
 // DescribeZFSVolumeClaim describes a ZFS storage engine PersistentVolumeClaim
 func DescribeZFSVolumeClaim(c *client.K8sClient, pvc *corev1.PersistentVolumeClaim, pv *corev1.PersistentVolume, mountPods string) error {
-	zfsPVCinfo := util.ZFSPVCInfo{
-		Name:             pvc.Name,
-		Namespace:        pvc.Namespace,
-		CasType:          util.ZFSCasType,
-		BoundVolume:      pvc.Spec.VolumeName,
-		StorageClassName: *pvc.Spec.StorageClassName,
-		Size:             pvc.Spec.Resources.Requests.Storage().String(),
-		PVCStatus:        pvc.Status.Phase,
-		MountPods:        mountPods,
-	}
+	zfsPVCinfo := newZFSPVCInfo(pvc, mountPods)
 
 	if pv != nil {
 		_ = util.PrintByTemplate("zfsPvc", zfsPvcInfoTemplate, zfsPVCinfo)
 		_ = volume.DescribeZFSLocalPVs(c, pv)
 	} else {
 		_ = util.PrintByTemplate("ZFSPvc", zfsPvcInfoTemplate, zfsPVCinfo)
+		printClaimEvents(c, pvc, nil)
 	}
 
 	return nil
 }
+
+// newZFSPVCInfo fills in the details of a ZFS storage engine PersistentVolumeClaim
+func newZFSPVCInfo(pvc *corev1.PersistentVolumeClaim, mountPods string) util.ZFSPVCInfo {
+	return util.ZFSPVCInfo{
+		Name:             pvc.Name,
+		Namespace:        pvc.Namespace,
+		CasType:          util.ZFSCasType,
+		BoundVolume:      pvc.Spec.VolumeName,
+		StorageClassName: *pvc.Spec.StorageClassName,
+		Size:             pvc.Spec.Resources.Requests.Storage().String(),
+		PVCStatus:        pvc.Status.Phase,
+		MountPods:        mountPods,
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resize
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GetLVMVolumeSpace returns the capacity of the LVMVolume along with the free
+// space of its volume group from the LVMNode of its node
+func GetLVMVolumeSpace(k *client.K8sClient, volume string) (*volumeSpace, error) {
+	lvols, _, err := k.GetLVMvol([]string{volume}, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(lvols.Items) == 0 {
+		return nil, fmt.Errorf("lvmvolume %s not found", volume)
+	}
+	lv := lvols.Items[0]
+	capacity, err := resource.ParseQuantity(lv.Spec.Capacity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity %s of lvmvolume %s: %v", lv.Spec.Capacity, volume, err)
+	}
+	space := &volumeSpace{
+		Capacity: capacity,
+		Node:     lv.Spec.OwnerNodeID,
+		Pool:     "volume group " + lv.Spec.VolGroup,
+		Thin:     lv.Spec.ThinProvision == "yes",
+	}
+	if nodes, _, err := k.GetLVMNodes([]string{lv.Spec.OwnerNodeID}, util.List, "", "", util.MapOptions{}); err == nil && len(nodes.Items) > 0 {
+		for _, vg := range nodes.Items[0].VolumeGroups {
+			if vg.Name == lv.Spec.VolGroup {
+				free := vg.Free
+				space.Free = &free
+			}
+		}
+	}
+	return space, nil
+}
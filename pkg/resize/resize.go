@@ -0,0 +1,165 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resize
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// pollInterval is the interval between the checks of the volume's capacity
+const pollInterval = 2 * time.Second
+
+// volumeSpace is the capacity of a volume along with the free space of the
+// pool or the volume group it is on
+type volumeSpace struct {
+	// Capacity of the volume
+	Capacity resource.Quantity
+	// Node the volume is on
+	Node string
+	// Pool is the ZFS pool or the LVM volume group of the volume
+	Pool string
+	// Free space of the pool or the volume group, nil if it isn't known
+	Free *resource.Quantity
+	// Thin is true for the thin provisioned volumes, they don't need free space
+	Thin bool
+}
+
+// Resize expands the PVC to size & waits till the volume & its filesystem
+// are resized
+func Resize(pvcName, namespace, size string, timeout time.Duration) error {
+	k := client.NewK8sClient()
+	pvc, casType, newSize, err := resizePVC(k, pvcName, namespace, size)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("pvc %s/%s is being resized to %s\n", namespace, pvcName, newSize.String())
+	getSpace := CasListMap()[casType]
+	volumeResized := false
+	err = wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+		if !volumeResized {
+			space, err := getSpace(k, pvc.Spec.VolumeName)
+			if err != nil || space.Capacity.Cmp(newSize) < 0 {
+				return false, nil
+			}
+			volumeResized = true
+			fmt.Printf("volume %s is resized, waiting for the filesystem resize\n", pvc.Spec.VolumeName)
+		}
+		return filesystemResized(k, pvcName, namespace, newSize)
+	})
+	if err != nil {
+		return fmt.Errorf("pvc %s/%s is not resized: %v", namespace, pvcName, err)
+	}
+	fmt.Printf("pvc %s/%s is resized to %s\n", namespace, pvcName, newSize.String())
+	return nil
+}
+
+// resizePVC checks that the PVC can be expanded to size & patches it, it
+// returns the PVC, its cas-type & the new size
+func resizePVC(k *client.K8sClient, pvcName, namespace, size string) (*corev1.PersistentVolumeClaim, string, resource.Quantity, error) {
+	// 1. Parse the new size, it must be more than the current one
+	newSize, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, "", newSize, fmt.Errorf("invalid size %s: %v", size, err)
+	}
+	pvc, err := k.GetPVC(pvcName, namespace)
+	if err != nil {
+		return nil, "", newSize, err
+	}
+	if pvc.Spec.VolumeName == "" {
+		return nil, "", newSize, fmt.Errorf("pvc %s/%s is not bound to a volume", namespace, pvcName)
+	}
+	current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if newSize.Cmp(current) <= 0 {
+		return nil, "", newSize, fmt.Errorf("pvc %s/%s is of %s, the new size %s must be more than it", namespace, pvcName, current.String(), newSize.String())
+	}
+	// 2. The StorageClass must allow the expansion
+	if pvc.Spec.StorageClassName == nil {
+		return nil, "", newSize, fmt.Errorf("pvc %s/%s has no storageclass", namespace, pvcName)
+	}
+	sc, err := k.GetSC(*pvc.Spec.StorageClassName)
+	if err != nil {
+		return nil, "", newSize, err
+	}
+	if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+		return nil, "", newSize, fmt.Errorf("storageclass %s doesn't allow volume expansion", sc.Name)
+	}
+	// 3. Only the engines with resize support are allowed
+	pv, err := k.GetPV(pvc.Spec.VolumeName)
+	if err != nil {
+		return nil, "", newSize, err
+	}
+	casType := util.NormalizeCasType(util.GetCasTypeFromPV(pv))
+	getSpace, ok := CasListMap()[casType]
+	if !ok {
+		return nil, "", newSize, fmt.Errorf("resize is not supported for the pvc %s/%s of cas-type %s", namespace, pvcName, casType)
+	}
+	// 4. The pool or the volume group must have room for the expansion
+	space, err := getSpace(k, pv.Name)
+	if err != nil {
+		return nil, "", newSize, err
+	}
+	if err = checkSpace(space, newSize); err != nil {
+		return nil, "", newSize, err
+	}
+	// 5. Patch the PVC
+	patch := fmt.Sprintf(`{"spec":{"resources":{"requests":{"storage":"%s"}}}}`, newSize.String())
+	pvc, err = k.PatchPVC(pvcName, namespace, []byte(patch))
+	return pvc, casType, newSize, err
+}
+
+// checkSpace returns an error if the pool or the volume group of the volume
+// doesn't have room to expand it to newSize
+func checkSpace(space *volumeSpace, newSize resource.Quantity) error {
+	if space.Thin || space.Free == nil {
+		return nil
+	}
+	needed := newSize.DeepCopy()
+	needed.Sub(space.Capacity)
+	if needed.Cmp(*space.Free) > 0 {
+		return fmt.Errorf("%s on node %s has %s free, %s more is needed", space.Pool, space.Node,
+			util.ConvertToIBytes(space.Free.String()), util.ConvertToIBytes(needed.String()))
+	}
+	return nil
+}
+
+// filesystemResized returns true once the capacity of the PVC reaches
+// newSize, the kubelet updates it after the filesystem resize
+func filesystemResized(k *client.K8sClient, pvcName, namespace string, newSize resource.Quantity) (bool, error) {
+	pvc, err := k.GetPVC(pvcName, namespace)
+	if err != nil {
+		return false, err
+	}
+	capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	return ok && capacity.Cmp(newSize) >= 0, nil
+}
+
+// CasListMap returns a map of cas-types to the functions returning the space
+// of their volumes
+func CasListMap() map[string]func(*client.K8sClient, string) (*volumeSpace, error) {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient, string) (*volumeSpace, error){
+		util.ZFSCasType: GetZFSVolumeSpace,
+		util.LVMCasType: GetLVMVolumeSpace,
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resize
+
+import (
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResizePVC(t *testing.T) {
+	tests := []struct {
+		name    string
+		pvc     string
+		size    string
+		want    string
+		wantErr bool
+	}{
+		{"zfs pool has room", "zfs-data", "5Gi", util.ZFSCasType, false},
+		{"zfs pool has no room", "zfs-data", "8Gi", "", true},
+		{"zfs thin volume", "zfs-thin-data", "100Gi", util.ZFSCasType, false},
+		{"lvm volume group has room", "lvm-data", "5Gi", util.LVMCasType, false},
+		{"lvm volume group has no room", "lvm-data", "6Gi", "", true},
+		{"storageclass doesn't allow expansion", "fixed-data", "5Gi", "", true},
+		{"smaller size", "zfs-data", "2Gi", "", true},
+		{"invalid size", "zfs-data", "5Zz", "", true},
+		{"no resize support", "hostpath-data", "5Gi", "", true},
+		{"pvc not bound", "pending-data", "5Gi", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				K8sCS: k8sfake.NewSimpleClientset(
+					&expandableSC, &fixedSC,
+					newPV("pvc-zfs", util.ZFSCSIDriver),
+					newPV("pvc-zfs-thin", util.ZFSCSIDriver),
+					newPV("pvc-lvm", util.LocalPVLVMCSIDriver),
+					newPV("pvc-hostpath", "openebs.io/local"),
+					newPVC("zfs-data", "pvc-zfs", &expandableSCName, "4Gi"),
+					newPVC("zfs-thin-data", "pvc-zfs-thin", &expandableSCName, "4Gi"),
+					newPVC("lvm-data", "pvc-lvm", &expandableSCName, "4Gi"),
+					newPVC("fixed-data", "pvc-zfs", &fixedSCName, "4Gi"),
+					newPVC("hostpath-data", "pvc-hostpath", &expandableSCName, "4Gi"),
+					newPVC("pending-data", "", &expandableSCName, "4Gi"),
+				),
+				ZFCS:  zfsfake.NewSimpleClientset(&zfsVol1, &zfsThinVol1, &zfsNode1),
+				LVMCS: lvmfake.NewSimpleClientset(&lvmVol1, &lvmNode1),
+			}
+			pvc, casType, _, err := resizePVC(k, tt.pvc, "default", tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resizePVC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if casType != tt.want {
+				t.Errorf("resizePVC() cas-type = %s, want %s", casType, tt.want)
+			}
+			got := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+			if got.Cmp(resource.MustParse(tt.size)) != 0 {
+				t.Errorf("resizePVC() size = %s, want %s", got.String(), tt.size)
+			}
+		})
+	}
+}
+
+func TestFilesystemResized(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity string
+		want     bool
+	}{
+		{"filesystem resized", "5Gi", true},
+		{"filesystem resize pending", "4Gi", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(newPVC("data", "pvc-1", &expandableSCName, tt.capacity))}
+			got, err := filesystemResized(k, "data", "default", resource.MustParse("5Gi"))
+			if err != nil {
+				t.Fatalf("filesystemResized() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("filesystemResized() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
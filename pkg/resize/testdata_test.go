@@ -0,0 +1,108 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resize
+
+import (
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	expandableSCName = "expandable"
+	fixedSCName      = "fixed"
+	allowExpansion   = true
+)
+
+func newPV(name, driver string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: name},
+			},
+		},
+	}
+}
+
+func newPVC(name, volume string, class *string, capacity string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: class,
+			VolumeName:       volume,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("4Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+		},
+	}
+}
+
+var expandableSC = storagev1.StorageClass{
+	ObjectMeta:           metav1.ObjectMeta{Name: expandableSCName},
+	AllowVolumeExpansion: &allowExpansion,
+}
+
+var fixedSC = storagev1.StorageClass{
+	ObjectMeta: metav1.ObjectMeta{Name: fixedSCName},
+}
+
+var zfsVol1 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-zfs", Namespace: "openebs"},
+	Spec: zfs.VolumeInfo{
+		OwnerNodeID: "node1",
+		PoolName:    "zfspv",
+		Capacity:    "4294967296",
+	},
+}
+
+var zfsThinVol1 = zfs.ZFSVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-zfs-thin", Namespace: "openebs"},
+	Spec: zfs.VolumeInfo{
+		OwnerNodeID:   "node1",
+		PoolName:      "zfspv",
+		Capacity:      "4294967296",
+		ThinProvision: "yes",
+	},
+}
+
+var zfsNode1 = zfs.ZFSNode{
+	ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+	Pools:      []zfs.Pool{{Name: "zfspv", Free: resource.MustParse("2Gi")}},
+}
+
+var lvmVol1 = lvm.LVMVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-lvm", Namespace: "openebs"},
+	Spec: lvm.VolumeInfo{
+		OwnerNodeID: "node1",
+		VolGroup:    "lvmvg",
+		Capacity:    "4294967296",
+	},
+}
+
+var lvmNode1 = lvm.LVMNode{
+	ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+	VolumeGroups: []lvm.VolumeGroup{
+		{Name: "lvmvg", Size: resource.MustParse("10Gi"), Free: resource.MustParse("1Gi")},
+	},
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resize
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// GetZFSVolumeSpace returns the capacity of the ZFSVolume along with the free
+// space of its pool from the ZFSNode of its node
+func GetZFSVolumeSpace(k *client.K8sClient, volume string) (*volumeSpace, error) {
+	zvols, _, err := k.GetZFSVols([]string{volume}, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(zvols.Items) == 0 {
+		return nil, fmt.Errorf("zfsvolume %s not found", volume)
+	}
+	zv := zvols.Items[0]
+	capacity, err := resource.ParseQuantity(zv.Spec.Capacity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity %s of zfsvolume %s: %v", zv.Spec.Capacity, volume, err)
+	}
+	space := &volumeSpace{
+		Capacity: capacity,
+		Node:     zv.Spec.OwnerNodeID,
+		Pool:     "zfs pool " + zv.Spec.PoolName,
+		Thin:     zv.Spec.ThinProvision == "yes",
+	}
+	// the ZFSNodes are not there in the older versions of ZFS-LocalPV
+	if nodes, _, err := k.GetZFSNodes([]string{zv.Spec.OwnerNodeID}, util.List, "", "", util.MapOptions{}); err == nil && len(nodes.Items) > 0 {
+		for _, pool := range nodes.Items[0].Pools {
+			if pool.Name == zv.Spec.PoolName {
+				free := pool.Free
+				space.Free = &free
+			}
+		}
+	}
+	return space, nil
+}
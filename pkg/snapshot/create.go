@@ -0,0 +1,215 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// defaultSnapshotClassKey is the annotation of the default VolumeSnapshotClass of a driver
+	defaultSnapshotClassKey = "snapshot.storage.kubernetes.io/is-default-class"
+	// snapshotReadyState is the state of a ready ZFSSnapshot or LVMSnapshot
+	snapshotReadyState = "Ready"
+	// pollInterval is the interval between the checks of a snapshot's status
+	pollInterval = 2 * time.Second
+)
+
+// Create creates a CSI VolumeSnapshot of the PVC & waits till the snapshot of
+// the engine is ready to use, the VolumeSnapshotClass is picked from the CSI
+// driver of the PVC if class is empty
+func Create(pvc, namespace, name, class string, timeout time.Duration) error {
+	k := client.NewK8sClient()
+	vs, casType, err := createSnapshot(k, pvc, namespace, name, class)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("volumesnapshot %s/%s created, waiting for it to be ready\n", vs.Namespace, vs.Name)
+	err = wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+		return snapshotReady(k, vs.Name, vs.Namespace, casType)
+	})
+	if err != nil {
+		return fmt.Errorf("volumesnapshot %s/%s is not ready: %v", vs.Namespace, vs.Name, err)
+	}
+	fmt.Printf("volumesnapshot %s/%s is ready to use\n", vs.Namespace, vs.Name)
+	return nil
+}
+
+// Delete deletes the CSI VolumeSnapshots of the namespace & waits till they
+// are removed from the cluster
+func Delete(names []string, namespace string, timeout time.Duration) error {
+	k := client.NewK8sClient()
+	for _, name := range names {
+		if err := deleteSnapshot(k, name, namespace); err != nil {
+			return err
+		}
+		err := wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+			_, err := k.GetVolumeSnapshot(name, namespace)
+			if k8serrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+		if err != nil {
+			return fmt.Errorf("volumesnapshot %s/%s is not deleted: %v", namespace, name, err)
+		}
+		fmt.Printf("volumesnapshot %s/%s deleted\n", namespace, name)
+	}
+	return nil
+}
+
+// createSnapshot creates the CSI VolumeSnapshot of the PVC if its engine
+// supports snapshots & returns it along with the cas-type of the PVC
+func createSnapshot(k *client.K8sClient, pvcName, namespace, name, class string) (*snapshotv1.VolumeSnapshot, string, error) {
+	// 1. Get the PVC & its PV
+	pvc, err := k.GetPVC(pvcName, namespace)
+	if err != nil {
+		return nil, "", err
+	}
+	if pvc.Spec.VolumeName == "" {
+		return nil, "", fmt.Errorf("pvc %s/%s is not bound to a volume", namespace, pvcName)
+	}
+	pv, err := k.GetPV(pvc.Spec.VolumeName)
+	if err != nil {
+		return nil, "", err
+	}
+	// 2. Only the engines with snapshot support are allowed
+	casType := util.NormalizeCasType(util.GetCasTypeFromPV(pv))
+	if _, ok := CasListMap()[casType]; !ok {
+		return nil, "", fmt.Errorf("snapshots are not supported for the pvc %s/%s of cas-type %s", namespace, pvcName, casType)
+	}
+	// 3. Pick the VolumeSnapshotClass of the CSI driver
+	class, err = getSnapshotClass(k, util.CasTypeToCSIProvisionerMap[casType], class)
+	if err != nil {
+		return nil, "", err
+	}
+	if name == "" {
+		name = pvcName + "-snap-" + time.Now().UTC().Format("20060102150405")
+	}
+	// 4. Create the VolumeSnapshot
+	vs := &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: snapshotv1.VolumeSnapshotSpec{
+			Source:                  snapshotv1.VolumeSnapshotSource{PersistentVolumeClaimName: &pvcName},
+			VolumeSnapshotClassName: &class,
+		},
+	}
+	vs, err = k.CreateVolumeSnapshot(vs)
+	return vs, casType, err
+}
+
+// getSnapshotClass returns the VolumeSnapshotClass of the CSI driver, if class
+// is empty the default class of the driver or its only class is returned
+func getSnapshotClass(k *client.K8sClient, driver, class string) (string, error) {
+	classes, err := k.GetVolumeSnapshotClasses()
+	if err != nil {
+		return "", fmt.Errorf("failed to list the volumesnapshotclasses: %v", err)
+	}
+	var found []string
+	for _, vsc := range classes.Items {
+		if vsc.Driver != driver {
+			continue
+		}
+		if class == vsc.Name || (class == "" && vsc.Annotations[defaultSnapshotClassKey] == "true") {
+			return vsc.Name, nil
+		}
+		found = append(found, vsc.Name)
+	}
+	switch {
+	case class != "":
+		return "", fmt.Errorf("volumesnapshotclass %s of the driver %s not found", class, driver)
+	case len(found) == 0:
+		return "", fmt.Errorf("no volumesnapshotclass found for the driver %s", driver)
+	case len(found) > 1:
+		return "", fmt.Errorf("multiple volumesnapshotclasses found for the driver %s: %s, use --class to pick one", driver, strings.Join(found, ", "))
+	}
+	return found[0], nil
+}
+
+// deleteSnapshot deletes the CSI VolumeSnapshot if it is of an engine with
+// snapshot support
+func deleteSnapshot(k *client.K8sClient, name, namespace string) error {
+	vs, err := k.GetVolumeSnapshot(name, namespace)
+	if err != nil {
+		return err
+	}
+	if driver := driverOf(k, vs); driver != "" {
+		if _, ok := CasListMap()[util.ProvsionerAndCasTypeMap[driver]]; !ok {
+			return fmt.Errorf("volumesnapshot %s/%s of the driver %s is not an OpenEBS snapshot", namespace, name, driver)
+		}
+	}
+	return k.DeleteVolumeSnapshot(name, namespace)
+}
+
+// driverOf returns the CSI driver of the VolumeSnapshot from its class, it is
+// empty if the class can't be found
+func driverOf(k *client.K8sClient, vs *snapshotv1.VolumeSnapshot) string {
+	if vs.Spec.VolumeSnapshotClassName == nil {
+		return ""
+	}
+	classes, err := k.GetVolumeSnapshotClasses()
+	if err != nil {
+		return ""
+	}
+	for _, vsc := range classes.Items {
+		if vsc.Name == *vs.Spec.VolumeSnapshotClassName {
+			return vsc.Driver
+		}
+	}
+	return ""
+}
+
+// snapshotReady returns true once the ZFSSnapshot or LVMSnapshot of the
+// VolumeSnapshot is ready, errors of the CSI snapshotter are returned as is
+func snapshotReady(k *client.K8sClient, name, namespace, casType string) (bool, error) {
+	vs, err := k.GetVolumeSnapshot(name, namespace)
+	if err != nil {
+		return false, err
+	}
+	if vs.Status == nil {
+		return false, nil
+	}
+	if vs.Status.Error != nil && vs.Status.Error.Message != nil {
+		return false, fmt.Errorf("%s", *vs.Status.Error.Message)
+	}
+	if vs.Status.BoundVolumeSnapshotContentName == nil {
+		return false, nil
+	}
+	content, err := k.GetVolumeSnapshotContent(*vs.Status.BoundVolumeSnapshotContentName)
+	if err != nil || content.Status == nil || content.Status.SnapshotHandle == nil {
+		return false, nil
+	}
+	// both the engines set the handle to <volume>@<snapshot-name>
+	handle := strings.SplitN(*content.Status.SnapshotHandle, "@", 2)
+	if len(handle) != 2 {
+		return false, nil
+	}
+	snapshots, err := getSnapshots(k, []string{handle[1]}, casType, handle[0])
+	if err != nil || len(snapshots) == 0 {
+		return false, nil
+	}
+	return snapshots[0].State == snapshotReadyState, nil
+}
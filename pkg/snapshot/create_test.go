@@ -0,0 +1,175 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"strings"
+	"testing"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	snapfake "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned/fake"
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateSnapshot(t *testing.T) {
+	tests := []struct {
+		name      string
+		pvc       string
+		snapName  string
+		class     string
+		wantClass string
+		wantErr   bool
+	}{
+		{"default class of the driver", pvcName, "snap-1", "", className, false},
+		{"class passed by the user", pvcName, "snap-1", "zfs-snapclass-2", "zfs-snapclass-2", false},
+		{"class of another driver", pvcName, "snap-1", lvmClassName, "", true},
+		{"generated name", pvcName, "", "", className, false},
+		{"no snapshot support", hostpathPVCName, "snap-1", "", "", true},
+		{"pvc not bound", "pending", "snap-1", "", "", true},
+		{"pvc not found", "missing", "snap-1", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				K8sCS:  k8sfake.NewSimpleClientset(&zfsPV1, &zfsPVC1, &hostpathPV1, &hostpathPVC1, &pendingPVC1),
+				SnapCS: snapfake.NewSimpleClientset(&zfsSnapClass1, &zfsSnapClass2, &lvmSnapClass1),
+			}
+			vs, casType, err := createSnapshot(k, tt.pvc, "default", tt.snapName, tt.class)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("createSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if casType != util.ZFSCasType {
+				t.Errorf("createSnapshot() cas-type = %s, want %s", casType, util.ZFSCasType)
+			}
+			if *vs.Spec.VolumeSnapshotClassName != tt.wantClass {
+				t.Errorf("createSnapshot() class = %s, want %s", *vs.Spec.VolumeSnapshotClassName, tt.wantClass)
+			}
+			if *vs.Spec.Source.PersistentVolumeClaimName != tt.pvc {
+				t.Errorf("createSnapshot() pvc = %s, want %s", *vs.Spec.Source.PersistentVolumeClaimName, tt.pvc)
+			}
+			if _, err := k.GetVolumeSnapshot(vs.Name, "default"); err != nil {
+				t.Errorf("createSnapshot() volumesnapshot %s not created: %v", vs.Name, err)
+			}
+		})
+	}
+}
+
+func TestCreateSnapshotUnsupportedCasType(t *testing.T) {
+	k := &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(&hostpathPV1, &hostpathPVC1)}
+	// the error has the cas-type shown by get volume, not the PV label
+	_, _, err := createSnapshot(k, hostpathPVCName, "default", "snap-1", "")
+	if err == nil || !strings.HasSuffix(err.Error(), "of cas-type "+util.LocalPvHostpathCasType) {
+		t.Errorf("createSnapshot() error = %v, want the unsupported cas-type %s", err, util.LocalPvHostpathCasType)
+	}
+}
+
+func TestGetSnapshotClass(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []runtime.Object
+		driver  string
+		want    string
+		wantErr bool
+	}{
+		{"default class", []runtime.Object{&zfsSnapClass1, &zfsSnapClass2}, "zfs.csi.openebs.io", className, false},
+		{"only class", []runtime.Object{&zfsSnapClass1, &lvmSnapClass1}, "local.csi.openebs.io", lvmClassName, false},
+		{"multiple classes", []runtime.Object{&lvmSnapClass1, &lvmSnapClass2}, "local.csi.openebs.io", "", true},
+		{"no class", []runtime.Object{&zfsSnapClass1}, "local.csi.openebs.io", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{SnapCS: snapfake.NewSimpleClientset(tt.classes...)}
+			got, err := getSnapshotClass(k, tt.driver, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getSnapshotClass() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getSnapshotClass() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotReady(t *testing.T) {
+	notReady := zfsSnap1
+	notReady.Status.State = "Pending"
+	message := "failed to take snapshot"
+	failed := boundVolSnap1
+	failed.Status = &snapshotv1.VolumeSnapshotStatus{Error: &snapshotv1.VolumeSnapshotError{Message: &message}}
+	tests := []struct {
+		name    string
+		vs      snapshotv1.VolumeSnapshot
+		zfsSnap runtime.Object
+		want    bool
+		wantErr bool
+	}{
+		{"engine snapshot ready", boundVolSnap1, &zfsSnap1, true, false},
+		{"engine snapshot not ready", boundVolSnap1, &notReady, false, false},
+		{"content not bound", zfsVolSnap1, &zfsSnap1, false, false},
+		{"snapshotter error", failed, &zfsSnap1, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				ZFCS:   zfsfake.NewSimpleClientset(tt.zfsSnap),
+				LVMCS:  lvmfake.NewSimpleClientset(),
+				SnapCS: snapfake.NewSimpleClientset(&tt.vs, &zfsSnapContent1),
+			}
+			got, err := snapshotReady(k, tt.vs.Name, tt.vs.Namespace, util.ZFSCasType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("snapshotReady() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("snapshotReady() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		snap    string
+		wantErr bool
+	}{
+		{"openebs snapshot", boundVolSnap1.Name, false},
+		{"snapshot of another driver", otherVolSnap1.Name, true},
+		{"snapshot not found", "missing", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				SnapCS: snapfake.NewSimpleClientset(&boundVolSnap1, &otherVolSnap1, &zfsSnapClass1, &otherSnapClass1),
+			}
+			err := deleteSnapshot(k, tt.snap, "default")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("deleteSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, err := k.GetVolumeSnapshot(tt.snap, "default"); !tt.wantErr && err == nil {
+				t.Errorf("deleteSnapshot() volumesnapshot %s is not deleted", tt.snap)
+			}
+		})
+	}
+}
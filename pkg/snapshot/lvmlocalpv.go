@@ -0,0 +1,51 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package snapshot
+
+import (
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+)
+
+// GetLVMSnapshots returns the LVMSnapshots matching the names & the label
+// selector, thin snapshots don't reserve any space so their size is N/A
+func GetLVMSnapshots(c *client.K8sClient, snaps []string, labelSelector string) ([]util.Snapshot, error) {
+	lsnaps, err := c.GetLVMSnapshots(snaps, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []util.Snapshot
+	for _, ls := range lsnaps.Items {
+		size := util.NotAvailable
+		if ls.Spec.SnapSize != "" {
+			size = util.ConvertToIBytes(ls.Spec.SnapSize)
+		}
+		snapshots = append(snapshots, util.Snapshot{
+			Name:         ls.Name,
+			Namespace:    ls.Namespace,
+			CasType:      util.LVMCasType,
+			Volume:       ls.Labels[util.PersistentVolumeKey],
+			Node:         ls.Spec.OwnerNodeID,
+			Pool:         ls.Spec.VolGroup,
+			Size:         size,
+			State:        ls.Status.State,
+			CreationTime: ls.CreationTimestamp.UTC().Format(time.RFC3339),
+		})
+	}
+	return snapshots, nil
+}
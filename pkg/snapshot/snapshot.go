@@ -0,0 +1,243 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// snapshotListKind is the kind of the machine-readable snapshot listing
+	snapshotListKind = "SnapshotList"
+	// snapshotDescribeKind is the kind of the machine-readable snapshot describe
+	snapshotDescribeKind = "SnapshotDescriptionList"
+)
+
+const snapshotInfo = `
+{{.Name}} Details :
+------------------
+NAME                    : {{.Name}}
+NAMESPACE               : {{.Namespace}}
+CAS TYPE                : {{.CasType}}
+VOLUME                  : {{.Volume}}
+PVC                     : {{.PVC}}
+NODE                    : {{.Node}}
+POOL                    : {{.Pool}}
+SIZE                    : {{.Size}}
+STATE                   : {{.State}}
+VOLUMESNAPSHOT          : {{.VolumeSnapshot}}
+VOLUMESNAPSHOTCONTENT   : {{.VolumeSnapshotContent}}
+SNAPSHOT CLASS          : {{.SnapshotClass}}
+READY TO USE            : {{.ReadyToUse}}
+CREATION TIME           : {{.CreationTime}}
+`
+
+// Get manages various implementations of Snapshot listing, if volume is not
+// empty only the snapshots of that volume are listed
+func Get(snaps []string, casType, volume, output string) error {
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	// 1. Create the clientset
+	k := client.NewK8sClient()
+	// 2. Get the snapshots of the cas-types
+	snapshots, err := getSnapshots(k, snaps, casType, volume)
+	if err != nil {
+		return err
+	}
+	// 3. Return Error or Print the snapshots
+	if len(snapshots) == 0 {
+		if util.IsStructuredOutput(output) {
+			return util.PrintList(output, snapshotListKind, nil)
+		}
+		return util.HandleEmptyTableError("Snapshot", "", casType)
+	}
+	switch output {
+	case "":
+		util.TablePrinter(util.SnapshotListColumnDefinitions, snapshotRows(snapshots, false), printers.PrintOptions{Wide: true})
+	case util.WideOutput:
+		util.TablePrinter(util.SnapshotListWideColumnDefinitions, snapshotRows(snapshots, true), printers.PrintOptions{Wide: true})
+	default:
+		items := make([]interface{}, len(snapshots))
+		for i := range snapshots {
+			items[i] = snapshots[i]
+		}
+		return util.PrintList(output, snapshotListKind, items)
+	}
+	return nil
+}
+
+// Describe manages various implementations of Snapshot describing
+func Describe(snaps []string, casType, output string) error {
+	if len(snaps) == 0 {
+		return errors.New("please provide atleast one snapshot name to describe")
+	}
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
+	// 1. Create the clientset
+	k := client.NewK8sClient()
+	// 2. Get the snapshots of the cas-types
+	snapshots, err := getSnapshots(k, snaps, casType, "")
+	if err != nil {
+		return err
+	}
+	found := make(map[string]bool)
+	for _, s := range snapshots {
+		found[s.Name] = true
+	}
+	for _, name := range snaps {
+		if !found[name] {
+			_, _ = fmt.Fprintf(os.Stderr, "Error from server (NotFound): snapshot %s not found\n", name)
+		}
+	}
+	// 3. Print the snapshots
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, len(snapshots))
+		for i := range snapshots {
+			items[i] = snapshots[i]
+		}
+		return util.PrintList(output, snapshotDescribeKind, items)
+	}
+	for _, s := range snapshots {
+		if err := util.PrintByTemplate("snapshot", snapshotInfo, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSnapshots returns the snapshots of one or all cas-types along with the
+// details of their CSI snapshot objects
+func getSnapshots(k *client.K8sClient, snaps []string, casType, volume string) ([]util.Snapshot, error) {
+	var labelSelector string
+	if volume != "" {
+		labelSelector = util.PersistentVolumeKey + "=" + volume
+	}
+	var snapshots []util.Snapshot
+	if f, ok := CasListMap()[casType]; ok {
+		found, err := f(k, snaps, labelSelector)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = found
+	} else if casType != "" {
+		return nil, fmt.Errorf("cas-type %s is not supported", casType)
+	} else {
+		// the errors are ignored as all the engines may not be installed
+		for _, f := range CasList() {
+			if found, err := f(k, snaps, labelSelector); err == nil {
+				snapshots = append(snapshots, found...)
+			}
+		}
+	}
+	addCSISnapshots(k, snapshots)
+	return snapshots, nil
+}
+
+// addCSISnapshots adds the details of the CSI VolumeSnapshotContents & of
+// their VolumeSnapshots to the snapshots. The contents are matched by their
+// snapshot handle, both the engines set it to <volume>@<snapshot-name>.
+func addCSISnapshots(k *client.K8sClient, snapshots []util.Snapshot) {
+	for i := range snapshots {
+		snapshots[i].VolumeSnapshot = util.NotAvailable
+		snapshots[i].VolumeSnapshotContent = util.NotAvailable
+		snapshots[i].PVC = util.NotAvailable
+		snapshots[i].ReadyToUse = util.NotAvailable
+		snapshots[i].SnapshotClass = util.NotAvailable
+	}
+	// 1. The VolumeSnapshot CRDs may not be installed
+	contents, err := k.GetVolumeSnapshotContents()
+	if err != nil {
+		return
+	}
+	contentMap := make(map[string]snapshotv1.VolumeSnapshotContent)
+	for _, content := range contents.Items {
+		if content.Status != nil && content.Status.SnapshotHandle != nil {
+			contentMap[*content.Status.SnapshotHandle] = content
+		} else if content.Spec.Source.SnapshotHandle != nil {
+			// pre-provisioned snapshots
+			contentMap[*content.Spec.Source.SnapshotHandle] = content
+		}
+	}
+	// 2. Get the source PVCs of the VolumeSnapshots
+	pvcMap := make(map[string]string)
+	if vss, err := k.GetVolumeSnapshots(); err == nil {
+		for _, vs := range vss.Items {
+			if vs.Spec.Source.PersistentVolumeClaimName != nil {
+				pvcMap[vs.Namespace+"/"+vs.Name] = vs.Namespace + "/" + *vs.Spec.Source.PersistentVolumeClaimName
+			}
+		}
+	}
+	// 3. Add the details of the matching contents
+	for i, s := range snapshots {
+		content, ok := contentMap[s.Volume+"@"+s.Name]
+		if !ok {
+			continue
+		}
+		snapshots[i].VolumeSnapshotContent = content.Name
+		ref := content.Spec.VolumeSnapshotRef
+		if ref.Name != "" {
+			snapshots[i].VolumeSnapshot = ref.Namespace + "/" + ref.Name
+			if pvc, ok := pvcMap[snapshots[i].VolumeSnapshot]; ok {
+				snapshots[i].PVC = pvc
+			}
+		}
+		if content.Status != nil && content.Status.ReadyToUse != nil {
+			snapshots[i].ReadyToUse = fmt.Sprint(*content.Status.ReadyToUse)
+		}
+		if content.Spec.VolumeSnapshotClassName != nil {
+			snapshots[i].SnapshotClass = *content.Spec.VolumeSnapshotClassName
+		}
+	}
+}
+
+// snapshotRows returns the table rows of the snapshots, the wide rows have
+// the PVC, readiness & snapshot class too
+func snapshotRows(snapshots []util.Snapshot, wide bool) []metav1.TableRow {
+	var rows []metav1.TableRow
+	for _, s := range snapshots {
+		cells := []interface{}{s.Name, s.Volume, s.CasType, s.Node, s.Pool, s.Size, s.State, s.VolumeSnapshot, s.VolumeSnapshotContent}
+		if wide {
+			cells = append(cells, s.PVC, s.ReadyToUse, s.SnapshotClass)
+		}
+		rows = append(rows, metav1.TableRow{Cells: cells})
+	}
+	return rows
+}
+
+// CasList returns a list of functions by cas-types for snapshot listing
+func CasList() []func(*client.K8sClient, []string, string) ([]util.Snapshot, error) {
+	// a good hack to implement immutable lists in Golang & also write tests for it
+	return []func(*client.K8sClient, []string, string) ([]util.Snapshot, error){GetZFSSnapshots, GetLVMSnapshots}
+}
+
+// CasListMap returns a map cas-types to functions for snapshot listing
+func CasListMap() map[string]func(*client.K8sClient, []string, string) ([]util.Snapshot, error) {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient, []string, string) ([]util.Snapshot, error){
+		util.ZFSCasType: GetZFSSnapshots,
+		util.LVMCasType: GetLVMSnapshots,
+	}
+}
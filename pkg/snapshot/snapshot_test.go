@@ -0,0 +1,111 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package snapshot
+
+import (
+	"reflect"
+	"testing"
+
+	snapfake "github.com/kubernetes-csi/external-snapshotter/client/v6/clientset/versioned/fake"
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+)
+
+func TestCasList(t *testing.T) {
+	if len(CasList()) != len(CasListMap()) {
+		t.Errorf("CasList() & CasListMap() have %d & %d cas-types", len(CasList()), len(CasListMap()))
+	}
+}
+
+func TestGetSnapshots(t *testing.T) {
+	zfsWant := util.Snapshot{
+		Name:                  "snapshot-4a3b",
+		Namespace:             "zfs",
+		CasType:               util.ZFSCasType,
+		Volume:                "pvc-1",
+		Node:                  "node1",
+		Pool:                  "zfspv",
+		Size:                  "4.0GiB",
+		State:                 "Ready",
+		VolumeSnapshot:        "default/data-snap",
+		VolumeSnapshotContent: "snapcontent-4a3b",
+		PVC:                   "default/data",
+		ReadyToUse:            "true",
+		SnapshotClass:         "zfs-snapclass",
+	}
+	lvmWant := util.Snapshot{
+		Name:                  "4c0e",
+		Namespace:             "lvm",
+		CasType:               util.LVMCasType,
+		Volume:                "pvc-2",
+		Node:                  "node2",
+		Pool:                  "lvmvg",
+		Size:                  util.NotAvailable,
+		State:                 "Ready",
+		VolumeSnapshot:        util.NotAvailable,
+		VolumeSnapshotContent: util.NotAvailable,
+		PVC:                   util.NotAvailable,
+		ReadyToUse:            util.NotAvailable,
+		SnapshotClass:         util.NotAvailable,
+	}
+	tests := []struct {
+		name    string
+		snaps   []string
+		casType string
+		volume  string
+		want    []util.Snapshot
+		wantErr bool
+	}{
+		{"all the snapshots", nil, "", "", []util.Snapshot{zfsWant, lvmWant}, false},
+		{"snapshots of a cas-type", nil, util.LVMCasType, "", []util.Snapshot{lvmWant}, false},
+		{"snapshots of a volume", nil, "", "pvc-1", []util.Snapshot{zfsWant}, false},
+		{"snapshot by name", []string{"4c0e"}, "", "", []util.Snapshot{lvmWant}, false},
+		{"snapshots of an unknown volume", nil, "", "pvc-3", nil, false},
+		{"unsupported cas-type", nil, "cstor", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				ZFCS:   zfsfake.NewSimpleClientset(&zfsSnap1),
+				LVMCS:  lvmfake.NewSimpleClientset(&lvmSnap1),
+				SnapCS: snapfake.NewSimpleClientset(&zfsSnapContent1, &zfsVolSnap1),
+			}
+			got, err := getSnapshots(k, tt.snaps, tt.casType, tt.volume)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getSnapshots() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// the creation time of the fake objects is not set
+			for i := range got {
+				got[i].CreationTime = ""
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSnapshots() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotRows(t *testing.T) {
+	s := util.Snapshot{Name: "snap", Volume: "pvc-1", CasType: util.ZFSCasType, PVC: "default/data"}
+	if got := len(snapshotRows([]util.Snapshot{s}, false)[0].Cells); got != len(util.SnapshotListColumnDefinitions) {
+		t.Errorf("snapshotRows() has %d cells, want %d", got, len(util.SnapshotListColumnDefinitions))
+	}
+	if got := len(snapshotRows([]util.Snapshot{s}, true)[0].Cells); got != len(util.SnapshotListWideColumnDefinitions) {
+		t.Errorf("snapshotRows() wide has %d cells, want %d", got, len(util.SnapshotListWideColumnDefinitions))
+	}
+}
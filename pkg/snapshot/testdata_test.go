@@ -0,0 +1,170 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package snapshot
+
+import (
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	zfsSnapHandle = "pvc-1@snapshot-4a3b"
+	lvmSnapHandle = "pvc-2@4c0e"
+	pvcName       = "data"
+	className     = "zfs-snapclass"
+	ready         = true
+)
+
+var zfsSnap1 = zfs.ZFSSnapshot{
+	TypeMeta: metav1.TypeMeta{
+		Kind:       "ZFSSnapshot",
+		APIVersion: "zfs.openebs.io/v1",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "snapshot-4a3b",
+		Namespace: "zfs",
+		Labels:    map[string]string{"openebs.io/persistent-volume": "pvc-1"},
+	},
+	Spec: zfs.VolumeInfo{
+		OwnerNodeID: "node1",
+		PoolName:    "zfspv",
+		Capacity:    "4294967296",
+	},
+	Status: zfs.SnapStatus{State: "Ready"},
+}
+
+var lvmSnap1 = lvm.LVMSnapshot{
+	TypeMeta: metav1.TypeMeta{
+		Kind:       "LVMSnapshot",
+		APIVersion: "local.openebs.io/v1alpha1",
+	},
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "4c0e",
+		Namespace: "lvm",
+		Labels:    map[string]string{"openebs.io/persistent-volume": "pvc-2"},
+	},
+	Spec: lvm.LVMSnapshotSpec{
+		OwnerNodeID: "node2",
+		VolGroup:    "lvmvg",
+	},
+	Status: lvm.SnapStatus{State: "Ready"},
+}
+
+var zfsSnapContent1 = snapshotv1.VolumeSnapshotContent{
+	ObjectMeta: metav1.ObjectMeta{Name: "snapcontent-4a3b"},
+	Spec: snapshotv1.VolumeSnapshotContentSpec{
+		VolumeSnapshotRef:       corev1.ObjectReference{Namespace: "default", Name: "data-snap"},
+		Driver:                  "zfs.csi.openebs.io",
+		VolumeSnapshotClassName: &className,
+	},
+	Status: &snapshotv1.VolumeSnapshotContentStatus{
+		SnapshotHandle: &zfsSnapHandle,
+		ReadyToUse:     &ready,
+	},
+}
+
+var zfsVolSnap1 = snapshotv1.VolumeSnapshot{
+	ObjectMeta: metav1.ObjectMeta{Name: "data-snap", Namespace: "default"},
+	Spec: snapshotv1.VolumeSnapshotSpec{
+		Source: snapshotv1.VolumeSnapshotSource{PersistentVolumeClaimName: &pvcName},
+	},
+}
+
+var (
+	contentName     = "snapcontent-4a3b"
+	lvmClassName    = "lvm-snapclass"
+	hostpathPVCName = "hostpath-data"
+)
+
+var zfsPV1 = corev1.PersistentVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-1"},
+	Spec: corev1.PersistentVolumeSpec{
+		PersistentVolumeSource: corev1.PersistentVolumeSource{
+			CSI: &corev1.CSIPersistentVolumeSource{Driver: "zfs.csi.openebs.io", VolumeHandle: "pvc-1"},
+		},
+	},
+}
+
+var zfsPVC1 = corev1.PersistentVolumeClaim{
+	ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: "default"},
+	Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pvc-1"},
+}
+
+var hostpathPV1 = corev1.PersistentVolume{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:   "pvc-3",
+		Labels: map[string]string{"openebs.io/cas-type": "local-hostpath"},
+	},
+}
+
+var hostpathPVC1 = corev1.PersistentVolumeClaim{
+	ObjectMeta: metav1.ObjectMeta{Name: hostpathPVCName, Namespace: "default"},
+	Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pvc-3"},
+}
+
+var pendingPVC1 = corev1.PersistentVolumeClaim{
+	ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+}
+
+var zfsSnapClass1 = snapshotv1.VolumeSnapshotClass{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:        className,
+		Annotations: map[string]string{"snapshot.storage.kubernetes.io/is-default-class": "true"},
+	},
+	Driver: "zfs.csi.openebs.io",
+}
+
+var zfsSnapClass2 = snapshotv1.VolumeSnapshotClass{
+	ObjectMeta: metav1.ObjectMeta{Name: "zfs-snapclass-2"},
+	Driver:     "zfs.csi.openebs.io",
+}
+
+var lvmSnapClass1 = snapshotv1.VolumeSnapshotClass{
+	ObjectMeta: metav1.ObjectMeta{Name: lvmClassName},
+	Driver:     "local.csi.openebs.io",
+}
+
+var lvmSnapClass2 = snapshotv1.VolumeSnapshotClass{
+	ObjectMeta: metav1.ObjectMeta{Name: "lvm-snapclass-2"},
+	Driver:     "local.csi.openebs.io",
+}
+
+var otherSnapClass1 = snapshotv1.VolumeSnapshotClass{
+	ObjectMeta: metav1.ObjectMeta{Name: "other-snapclass"},
+	Driver:     "ebs.csi.aws.com",
+}
+
+var otherClassName = "other-snapclass"
+
+var otherVolSnap1 = snapshotv1.VolumeSnapshot{
+	ObjectMeta: metav1.ObjectMeta{Name: "other-snap", Namespace: "default"},
+	Spec: snapshotv1.VolumeSnapshotSpec{
+		Source:                  snapshotv1.VolumeSnapshotSource{PersistentVolumeClaimName: &pvcName},
+		VolumeSnapshotClassName: &otherClassName,
+	},
+}
+
+var boundVolSnap1 = snapshotv1.VolumeSnapshot{
+	ObjectMeta: metav1.ObjectMeta{Name: "data-snap", Namespace: "default"},
+	Spec: snapshotv1.VolumeSnapshotSpec{
+		Source:                  snapshotv1.VolumeSnapshotSource{PersistentVolumeClaimName: &pvcName},
+		VolumeSnapshotClassName: &className,
+	},
+	Status: &snapshotv1.VolumeSnapshotStatus{BoundVolumeSnapshotContentName: &contentName},
+}
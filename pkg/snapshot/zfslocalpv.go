@@ -0,0 +1,47 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package snapshot
+
+import (
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+)
+
+// GetZFSSnapshots returns the ZFSSnapshots matching the names & the label
+// selector, the size of a ZFS snapshot is the capacity of its source volume
+func GetZFSSnapshots(c *client.K8sClient, snaps []string, labelSelector string) ([]util.Snapshot, error) {
+	zsnaps, err := c.GetZFSSnapshots(snaps, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	var snapshots []util.Snapshot
+	for _, zs := range zsnaps.Items {
+		snapshots = append(snapshots, util.Snapshot{
+			Name:         zs.Name,
+			Namespace:    zs.Namespace,
+			CasType:      util.ZFSCasType,
+			Volume:       zs.Labels[util.PersistentVolumeKey],
+			Node:         zs.Spec.OwnerNodeID,
+			Pool:         zs.Spec.PoolName,
+			Size:         util.ConvertToIBytes(zs.Spec.Capacity),
+			State:        zs.Status.State,
+			CreationTime: zs.CreationTimestamp.UTC().Format(time.RFC3339),
+		})
+	}
+	return snapshots, nil
+}
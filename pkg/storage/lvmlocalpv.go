@@ -31,9 +31,9 @@ const (
 	lastElemPrefix  = `└─`
 )
 
-// GetVolumeGroups lists all volume groups by node
-func GetVolumeGroups(c *client.K8sClient, vgs []string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error) {
-	lvmNodes, _, err := c.GetLVMNodes(vgs, util.List, "", util.MapOptions{})
+// GetVolumeGroups lists all volume groups by nodes matching the label & field selectors
+func GetVolumeGroups(c *client.K8sClient, vgs []string, labelSelector, fieldSelector string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error) {
+	lvmNodes, _, err := c.GetLVMNodes(vgs, util.List, labelSelector, fieldSelector, util.MapOptions{})
 	if err != nil {
 		// should this error be white-washed with return fmt.Errorf("no lvm volumegroups found")
 		return nil, nil, err
@@ -75,48 +75,46 @@ TOTAL PVs       : {{.TotalPVs}}
 
 // LVMvgDesc describes an LVM Volume group & the vgs present in it
 type LVMvgDesc struct {
-	HostName            string
-	Namespace           string
-	NumberOfPools       int
-	Size                string
-	TotalFree           string
-	TotalLogicalVolumes int32
-	TotalPVs            int32
+	HostName            string        `json:"hostName"`
+	Namespace           string        `json:"namespace"`
+	NumberOfPools       int           `json:"numberOfPools"`
+	Size                string        `json:"size"`
+	TotalFree           string        `json:"totalFree"`
+	TotalLogicalVolumes int32         `json:"totalLogicalVolumes"`
+	TotalPVs            int32         `json:"totalPVs"`
+	VolumeGroups        []LVMvgDetail `json:"volumeGroups"`
+	CasType             string        `json:"casType"`
+}
+
+// LVMvgDetail describes a single volume group present in an LVM node
+type LVMvgDetail struct {
+	Name           string `json:"name"`
+	UUID           string `json:"uuid"`
+	Size           string `json:"size"`
+	Free           string `json:"free"`
+	LVCount        int32  `json:"lvCount"`
+	PVCount        int32  `json:"pvCount"`
+	UsedPercentage string `json:"usedPercentage"`
+}
+
+// ResourceName returns the resource/name reference of the LVMvgDesc
+func (d LVMvgDesc) ResourceName() string {
+	return "lvmnode/" + d.HostName
 }
 
 // DescribeLVMvg describes an LVM volume group
 func DescribeLVMvg(c *client.K8sClient, vg string) error {
-	vgs, _, err := c.GetLVMNodes([]string{vg}, util.List, "", util.MapOptions{})
+	descs, err := GetLVMvgDescs(c, []string{vg}, "", "")
 	if err != nil {
 		return err
 	}
-	if len(vgs.Items) == 0 {
+	if len(descs) == 0 {
 		return fmt.Errorf("vg group %s not found", vg)
 	}
-	volGrp := vgs.Items[0]
-	var totalFree, total resource.Quantity
-	var totLV, totPV int32
-	for _, pools := range volGrp.VolumeGroups {
-		totalFree.Add(pools.Free)
-		total.Add(pools.Size)
-		totLV += pools.LVCount
-		totPV += pools.PVCount
-	}
-
-	desc := LVMvgDesc{
-		HostName:            volGrp.Name,
-		Namespace:           volGrp.Namespace,
-		NumberOfPools:       len(volGrp.VolumeGroups),
-		Size:                util.ConvertToIBytes(total.String()),
-		TotalFree:           util.ConvertToIBytes(totalFree.String()),
-		TotalLogicalVolumes: totLV,
-		TotalPVs:            totPV,
-	}
-
+	desc := descs[0]
 	var r []metav1.TableRow
-	for _, k := range volGrp.VolumeGroups {
-		usedPercent := util.GetUsedPercentage(k.Size.String(), k.Free.String())
-		r = append(r, metav1.TableRow{Cells: []interface{}{k.Name, k.UUID, k.LVCount, k.PVCount, fmt.Sprintf("%0.1f%%", 100-usedPercent)}})
+	for _, k := range desc.VolumeGroups {
+		r = append(r, metav1.TableRow{Cells: []interface{}{k.Name, k.UUID, k.LVCount, k.PVCount, k.UsedPercentage}})
 	}
 	_ = util.PrintByTemplate("lvmvgs", lvmdesc, desc)
 	fmt.Println("Volume group details")
@@ -129,5 +127,49 @@ func DescribeLVMvg(c *client.K8sClient, vg string) error {
 		{Name: "Used percentage", Type: "string"},
 	}
 	util.TablePrinter(def, r, printers.PrintOptions{Wide: true})
+	printNodeEvents(c, "LVMNode", vg)
 	return nil
 }
+
+// GetLVMvgDescs returns the details of the LVM nodes & the volume groups
+// present in them, all the LVM nodes matching the selectors are returned if vgs is empty
+func GetLVMvgDescs(c *client.K8sClient, vgs []string, labelSelector, fieldSelector string) ([]LVMvgDesc, error) {
+	lvmNodes, _, err := c.GetLVMNodes(vgs, util.List, labelSelector, fieldSelector, util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var descs []LVMvgDesc
+	for _, volGrp := range lvmNodes.Items {
+		var totalFree, total resource.Quantity
+		var totLV, totPV int32
+		details := make([]LVMvgDetail, 0, len(volGrp.VolumeGroups))
+		for _, pools := range volGrp.VolumeGroups {
+			totalFree.Add(pools.Free)
+			total.Add(pools.Size)
+			totLV += pools.LVCount
+			totPV += pools.PVCount
+			usedPercent := util.GetUsedPercentage(pools.Size.String(), pools.Free.String())
+			details = append(details, LVMvgDetail{
+				Name:           pools.Name,
+				UUID:           pools.UUID,
+				Size:           util.ConvertToIBytes(pools.Size.String()),
+				Free:           util.ConvertToIBytes(pools.Free.String()),
+				LVCount:        pools.LVCount,
+				PVCount:        pools.PVCount,
+				UsedPercentage: fmt.Sprintf("%0.1f%%", 100-usedPercent),
+			})
+		}
+		descs = append(descs, LVMvgDesc{
+			HostName:            volGrp.Name,
+			Namespace:           volGrp.Namespace,
+			NumberOfPools:       len(volGrp.VolumeGroups),
+			Size:                util.ConvertToIBytes(total.String()),
+			TotalFree:           util.ConvertToIBytes(totalFree.String()),
+			TotalLogicalVolumes: totLV,
+			TotalPVs:            totPV,
+			VolumeGroups:        details,
+			CasType:             util.LVMCasType,
+		})
+	}
+	return descs, nil
+}
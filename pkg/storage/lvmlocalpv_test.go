@@ -27,6 +27,7 @@ import (
 	"github.com/openebs/openebsctl/pkg/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	k8stest "k8s.io/client-go/testing"
 )
 
@@ -84,7 +85,7 @@ func TestGetVolumeGroup(t *testing.T) {
 				tt.args.lvmfunc(tt.args.c)
 			}
 			// 2. Run the test & assert the result
-			if head, row, err := GetVolumeGroups(tt.args.c, tt.args.vg); (err != nil) != tt.wantErr {
+			if head, row, err := GetVolumeGroups(tt.args.c, tt.args.vg, "", ""); (err != nil) != tt.wantErr {
 				t.Errorf("GetVolumeGroups() error = %v, wantErr %v", err, tt.wantErr)
 			} else if err == nil {
 				if !reflect.DeepEqual(row, tt.want) {
@@ -120,22 +121,22 @@ func TestDescribeLVMvg(t *testing.T) {
 	}{
 		{
 			"no LVM vgs exist",
-			args{c: &client.K8sClient{Ns: "", LVMCS: fakelvmclient.NewSimpleClientset()}, lvmFunc: lvnNodeNotFound, vg: "some-vg-name"},
+			args{c: &client.K8sClient{Ns: "", K8sCS: k8sfake.NewSimpleClientset(), LVMCS: fakelvmclient.NewSimpleClientset()}, lvmFunc: lvnNodeNotFound, vg: "some-vg-name"},
 			true,
 		},
 		{
 			"one LVM node exist and asked for",
-			args{c: &client.K8sClient{Ns: "lvm", LVMCS: fakelvmclient.NewSimpleClientset(&lvmNode1)}, vg: "node1"},
+			args{c: &client.K8sClient{Ns: "lvm", K8sCS: k8sfake.NewSimpleClientset(), LVMCS: fakelvmclient.NewSimpleClientset(&lvmNode1)}, vg: "node1"},
 			false,
 		},
 		{
 			"one ZFS node exist with differing namespace",
-			args{c: &client.K8sClient{Ns: "zfs", LVMCS: fakelvmclient.NewSimpleClientset(&lvmNode1)}, vg: "node1"},
+			args{c: &client.K8sClient{Ns: "zfs", K8sCS: k8sfake.NewSimpleClientset(), LVMCS: fakelvmclient.NewSimpleClientset(&lvmNode1)}, vg: "node1"},
 			false,
 		},
 		{
 			"two ZFS node exist, none asked for",
-			args{c: &client.K8sClient{Ns: "zfs", LVMCS: fakelvmclient.NewSimpleClientset(&lvmNode1, &lvmNode2)}, vg: ""},
+			args{c: &client.K8sClient{Ns: "zfs", K8sCS: k8sfake.NewSimpleClientset(), LVMCS: fakelvmclient.NewSimpleClientset(&lvmNode1, &lvmNode2)}, vg: ""},
 			true,
 		},
 	}
@@ -150,3 +151,45 @@ func TestDescribeLVMvg(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLVMvgDescs(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *client.K8sClient
+		vgs     []string
+		want    []LVMvgDesc
+		wantErr bool
+	}{
+		{
+			"one LVM node with two volume groups",
+			&client.K8sClient{Ns: "lvm", LVMCS: fakelvmclient.NewSimpleClientset(&lvmNode1)},
+			nil,
+			[]LVMvgDesc{{HostName: "node1", Namespace: "lvm", NumberOfPools: 2, Size: "10.0GiB", TotalFree: "8.0GiB",
+				TotalLogicalVolumes: 2, TotalPVs: 2, CasType: util.LVMCasType,
+				VolumeGroups: []LVMvgDetail{
+					{Name: "lvmvg", UUID: "ed6fko-Lf33-AW2d-Vblk-cUJZ-sQt5-Gr4rcH", Size: "5.0GiB", Free: "4.0GiB",
+						LVCount: 1, PVCount: 1, UsedPercentage: "20.0%"},
+					{Name: "lvmvg2", UUID: "ed6fko-Lf33-AW2d-Vblk-cUJZ-sQt5-Hr4rcI", Size: "5.0GiB", Free: "4.0GiB",
+						LVCount: 1, PVCount: 1, UsedPercentage: "20.0%"}}}},
+			false,
+		},
+		{
+			"no LVM nodes present",
+			&client.K8sClient{Ns: "lvm", LVMCS: fakelvmclient.NewSimpleClientset()},
+			nil,
+			nil,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetLVMvgDescs(tt.c, tt.vgs, "", "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetLVMvgDescs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetLVMvgDescs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
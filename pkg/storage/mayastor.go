@@ -0,0 +1,157 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const diskpooldesc = `
+{{.Name}} Details :
+
+NAME        : {{.Name}}
+NAMESPACE   : {{.Namespace}}
+NODE        : {{.Node}}
+DISKS       : {{.DiskList}}
+STATE       : {{.State}}
+POOL STATUS : {{.PoolStatus}}
+CAPACITY    : {{.Capacity}}
+USED        : {{.Used}}
+AVAILABLE   : {{.Available}}
+
+`
+
+// DiskPoolDesc describes a mayastor DiskPool
+type DiskPoolDesc struct {
+	Name       string   `json:"name"`
+	Namespace  string   `json:"namespace"`
+	Node       string   `json:"node"`
+	Disks      []string `json:"disks"`
+	State      string   `json:"state"`
+	PoolStatus string   `json:"poolStatus"`
+	Capacity   string   `json:"capacity"`
+	Used       string   `json:"used"`
+	Available  string   `json:"available"`
+	CasType    string   `json:"casType"`
+}
+
+// ResourceName returns the resource/name reference of the DiskPoolDesc
+func (d DiskPoolDesc) ResourceName() string {
+	return "diskpool/" + d.Name
+}
+
+// DiskList returns the disks of the DiskPool separated by commas
+func (d DiskPoolDesc) DiskList() string {
+	if len(d.Disks) == 0 {
+		return util.NotAvailable
+	}
+	return strings.Join(d.Disks, ", ")
+}
+
+// DescribeDiskPool describes a mayastor DiskPool
+func DescribeDiskPool(c *client.K8sClient, pool string) error {
+	descs, err := GetDiskPoolDescs(c, []string{pool}, "", "")
+	if err != nil {
+		return err
+	}
+	if len(descs) == 0 {
+		return fmt.Errorf("diskpool %s not found", pool)
+	}
+	desc := descs[0]
+	if err = util.PrintByTemplate("diskpools", diskpooldesc, desc); err != nil {
+		return err
+	}
+	// the events are only a part of the description, they are skipped on errors
+	if events, err := c.GetObjectEvents(corev1.ObjectReference{Kind: "DiskPool", Name: desc.Name, Namespace: desc.Namespace}); err == nil {
+		util.PrintEvents(events)
+	}
+	return nil
+}
+
+// GetDiskPools lists the mayastor DiskPools matching the label & field selectors
+func GetDiskPools(c *client.K8sClient, pools []string, labelSelector, fieldSelector string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error) {
+	descs, err := GetDiskPoolDescs(c, pools, labelSelector, fieldSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows []metav1.TableRow
+	for _, d := range descs {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{d.Name, d.Node, d.State, d.PoolStatus, d.Capacity, d.Used, d.Available}})
+	}
+	if len(rows) == 0 {
+		return nil, nil, util.HandleEmptyTableError("diskpools", c.Ns, "")
+	}
+	return util.DiskPoolListColumnDefinitions, rows, nil
+}
+
+// GetDiskPoolDescs returns the details of the DiskPools, all the DiskPools
+// matching the selectors are returned if pools is empty
+func GetDiskPoolDescs(c *client.K8sClient, pools []string, labelSelector, fieldSelector string) ([]DiskPoolDesc, error) {
+	dsps, err := c.GetDiskPools(pools, labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	descs := make([]DiskPoolDesc, 0, len(dsps))
+	for _, dsp := range dsps {
+		descs = append(descs, DiskPoolDesc{
+			Name:       dsp.Name,
+			Namespace:  dsp.Namespace,
+			Node:       dsp.Spec.Node,
+			Disks:      dsp.Spec.Disks,
+			State:      orNotAvailable(dsp.Status.CRState),
+			PoolStatus: orNotAvailable(dsp.Status.PoolStatus),
+			Capacity:   bytesOf(dsp.Status.Capacity),
+			Used:       bytesOf(dsp.Status.Used),
+			Available:  bytesOf(dsp.Status.Available),
+			CasType:    util.MayastorCasType,
+		})
+	}
+	return descs, nil
+}
+
+// getDiskPoolOutput returns the DiskPoolDescs of the DiskPools as output items
+func getDiskPoolOutput(c *client.K8sClient, pools []string, labelSelector, fieldSelector string) ([]interface{}, error) {
+	descs, err := GetDiskPoolDescs(c, pools, labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]interface{}, len(descs))
+	for i := range descs {
+		items[i] = descs[i]
+	}
+	return items, nil
+}
+
+// bytesOf returns the bytes of a DiskPool status in binary units
+func bytesOf(bytes int64) string {
+	return util.ConvertToIBytes(strconv.FormatInt(bytes, 10))
+}
+
+// orNotAvailable returns N/A for the empty states of a DiskPool being created
+func orNotAvailable(state string) string {
+	if state == "" {
+		return util.NotAvailable
+	}
+	return state
+}
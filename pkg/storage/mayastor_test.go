@@ -0,0 +1,178 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stest "k8s.io/client-go/testing"
+)
+
+// newDynamicClient returns a fake dynamic client serving the DiskPools of
+// all the versions
+func newDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	listKinds := make(map[schema.GroupVersionResource]string)
+	for _, gvr := range client.DiskPoolGVRs {
+		listKinds[gvr] = "DiskPoolList"
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objects...)
+}
+
+func TestGetDiskPools(t *testing.T) {
+	// the cluster only serves the older v1beta1 DiskPools
+	v1beta1Only := newDynamicClient(newDiskPool("openebs.io/v1beta1", "pool-node1", "node1", 10737418240, 4294967296))
+	v1beta1Only.PrependReactor("list", "diskpools", func(action k8stest.Action) (bool, runtime.Object, error) {
+		if action.GetResource().Version == "v1beta2" {
+			return true, nil, k8serrors.NewNotFound(action.GetResource().GroupResource(), "")
+		}
+		return false, nil, nil
+	})
+	tests := []struct {
+		name    string
+		c       *client.K8sClient
+		pools   []string
+		want    []metav1.TableRow
+		wantErr bool
+	}{
+		{
+			name: "diskpools present",
+			c: &client.K8sClient{DynCS: newDynamicClient(
+				newDiskPool("openebs.io/v1beta2", "pool-node1", "node1", 10737418240, 4294967296),
+				newDiskPool("openebs.io/v1beta2", "pool-node2", "node2", 10737418240, 0))},
+			want: []metav1.TableRow{
+				{Cells: []interface{}{"pool-node1", "node1", "Created", "Online", "10.0GiB", "4.0GiB", "6.0GiB"}},
+				{Cells: []interface{}{"pool-node2", "node2", "Created", "Online", "10.0GiB", "0.0B", "10.0GiB"}},
+			},
+		},
+		{
+			name: "diskpool by name",
+			c: &client.K8sClient{DynCS: newDynamicClient(
+				newDiskPool("openebs.io/v1beta2", "pool-node1", "node1", 10737418240, 4294967296),
+				newDiskPool("openebs.io/v1beta2", "pool-node2", "node2", 10737418240, 0))},
+			pools: []string{"pool-node2"},
+			want: []metav1.TableRow{
+				{Cells: []interface{}{"pool-node2", "node2", "Created", "Online", "10.0GiB", "0.0B", "10.0GiB"}},
+			},
+		},
+		{
+			name: "older diskpool version",
+			c:    &client.K8sClient{DynCS: v1beta1Only},
+			want: []metav1.TableRow{
+				{Cells: []interface{}{"pool-node1", "node1", "Created", "Online", "10.0GiB", "4.0GiB", "6.0GiB"}},
+			},
+		},
+		{
+			name:    "no diskpools present",
+			c:       &client.K8sClient{DynCS: newDynamicClient()},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, rows, err := GetDiskPools(tt.c, tt.pools, "", "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetDiskPools() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Errorf("GetDiskPools() returned %v want = %v", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeDiskPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *client.K8sClient
+		pool    string
+		wantErr bool
+	}{
+		{
+			name: "diskpool present",
+			c: &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(), DynCS: newDynamicClient(
+				newDiskPool("openebs.io/v1beta2", "pool-node1", "node1", 10737418240, 4294967296))},
+			pool: "pool-node1",
+		},
+		{
+			name: "diskpool absent",
+			c: &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(), DynCS: newDynamicClient(
+				newDiskPool("openebs.io/v1beta2", "pool-node1", "node1", 10737418240, 4294967296))},
+			pool:    "pool-node2",
+			wantErr: true,
+		},
+		{
+			name:    "no diskpools present",
+			c:       &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(), DynCS: newDynamicClient()},
+			pool:    "pool-node1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := DescribeDiskPool(tt.c, tt.pool); (err != nil) != tt.wantErr {
+				t.Errorf("DescribeDiskPool() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetDiskPoolsWatched(t *testing.T) {
+	k := &client.K8sClient{DynCS: newDynamicClient(newDiskPool("openebs.io/v1beta2", "pool-node1", "node1", 10737418240, 4294967296))}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	changed, err := k.Watch(stopCh, client.DiskPoolResource)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	used := func() string {
+		descs, err := GetDiskPoolDescs(k, nil, "", "")
+		if err != nil {
+			t.Fatalf("GetDiskPoolDescs() error = %v", err)
+		}
+		if len(descs) != 1 {
+			t.Fatalf("GetDiskPoolDescs() = %v, want 1 diskpool", descs)
+		}
+		return descs[0].Used
+	}
+	if got := used(); got != "4.0GiB" {
+		t.Errorf("GetDiskPoolDescs() used = %s, want 4.0GiB", got)
+	}
+	// the diskpool is read from the cache once the informer gets the update
+	gvr := client.DiskPoolGVRs[0]
+	dsp := newDiskPool("openebs.io/v1beta2", "pool-node1", "node1", 10737418240, 5368709120)
+	if _, err := k.DynCS.Resource(gvr).Namespace("mayastor").Update(context.TODO(), dsp, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	timeout := time.After(5 * time.Second)
+	for got := used(); got != "5.0GiB"; got = used() {
+		select {
+		case <-changed:
+		case <-timeout:
+			t.Fatalf("GetDiskPoolDescs() used = %s, want 5.0GiB", got)
+		}
+	}
+}
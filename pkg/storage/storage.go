@@ -19,21 +19,50 @@ package storage
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/openebs/openebsctl/pkg/client"
 	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/printers"
 )
 
-// Get manages various implementations of Storage listing
-func Get(pools []string, openebsNS string, casType string) error {
+const (
+	// storageListKind is the kind of the machine-readable storage listing
+	storageListKind = "StorageList"
+	// storageDescribeKind is the kind of the machine-readable storage describe
+	storageDescribeKind = "StorageDescriptionList"
+)
+
+// Get manages various implementations of Storage listing, the storage is
+// filtered by the label & field selectors if they are not empty. With watch
+// the storage is read from informer caches & the table of a cas-type is
+// reprinted when its storage changes.
+func Get(pools []string, openebsNS, casType, output, labelSelector, fieldSelector string, watch bool) error {
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	if watch {
+		if err := util.CheckWatchOutputFormat(output); err != nil {
+			return err
+		}
+	}
+	if err := util.CheckSelectors(pools, labelSelector, fieldSelector); err != nil {
+		return err
+	}
 	// 1. Create the clientset
 	k := client.NewK8sClient()
+	if watch {
+		return watchStorage(k, pools, openebsNS, casType, labelSelector, fieldSelector)
+	}
+	if util.IsStructuredOutput(output) {
+		return printStorageList(k, pools, casType, output, labelSelector, fieldSelector)
+	}
 	// 2. If casType is specified, call the specific function & exit
 	if f, ok := CasListMap()[casType]; ok {
 		// if a cas-type is found, run it and return the error
-		header, rows, err := f(k, pools)
+		header, rows, err := f(k, pools, labelSelector, fieldSelector)
 		if err != nil {
 			return err
 		}
@@ -47,7 +76,7 @@ func Get(pools []string, openebsNS string, casType string) error {
 		storageResourcesFound := false
 		// 3. Call all functions & exit
 		for _, f := range CasList() {
-			header, row, err := f(k, pools)
+			header, row, err := f(k, pools, labelSelector, fieldSelector)
 			if err == nil {
 				if len(row) > 0 {
 					storageResourcesFound = true
@@ -66,17 +95,84 @@ func Get(pools []string, openebsNS string, casType string) error {
 	return nil
 }
 
+// watchStorage prints the storage & then reprints the table of every
+// cas-type whose storage changes, the tables are reprinted as a whole as the
+// pools are listed as a tree under their node
+func watchStorage(k *client.K8sClient, pools []string, openebsNS, casType, labelSelector, fieldSelector string) error {
+	works := CasList()
+	if casType != "" {
+		f, ok := CasListMap()[casType]
+		if !ok {
+			return fmt.Errorf("cas-type %s is not supported", casType)
+		}
+		works = []func(*client.K8sClient, []string, string, string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error){f}
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	changed, err := k.Watch(stopCh, client.ZFSNodeResource, client.LVMNodeResource, client.DiskPoolResource)
+	if err != nil {
+		return err
+	}
+	// printed has the last printed rows of every cas-type
+	printed := make([]string, len(works))
+	for first := true; ; first = false {
+		storageResourcesFound := false
+		for i, f := range works {
+			header, rows, err := f(k, pools, labelSelector, fieldSelector)
+			if err != nil || len(rows) == 0 {
+				continue
+			}
+			storageResourcesFound = true
+			if cells := fmt.Sprint(rows); cells != printed[i] {
+				printed[i] = cells
+				util.TablePrinter(header, rows, printers.PrintOptions{Wide: true})
+				// A visual separator for different cas-type pools/storage entities
+				fmt.Println()
+			}
+		}
+		if first && !storageResourcesFound {
+			_, _ = fmt.Fprintln(os.Stderr, util.HandleEmptyTableError("Storage", openebsNS, casType))
+		}
+		<-changed
+	}
+}
+
+// printStorageList prints the storage of one or all cas-types in the
+// machine-readable output format
+func printStorageList(k *client.K8sClient, pools []string, casType, output, labelSelector, fieldSelector string) error {
+	var items []interface{}
+	if f, ok := CasOutputMap()[casType]; ok {
+		found, err := f(k, pools, labelSelector, fieldSelector)
+		if err != nil {
+			return err
+		}
+		items = found
+	} else if casType != "" {
+		return fmt.Errorf("cas-type %s is not supported", casType)
+	} else {
+		for _, f := range CasOutputList() {
+			if found, err := f(k, pools, labelSelector, fieldSelector); err == nil {
+				items = append(items, found...)
+			}
+		}
+	}
+	return util.PrintList(output, storageListKind, items)
+}
+
 // CasList has a list of method implementations for different cas-types
-func CasList() []func(*client.K8sClient, []string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error) {
-	return []func(*client.K8sClient, []string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error){
-		GetVolumeGroups, GetZFSPools}
+func CasList() []func(*client.K8sClient, []string, string, string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error) {
+	return []func(*client.K8sClient, []string, string, string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error){
+		GetVolumeGroups, GetZFSPools, GetDiskPools}
 }
 
 // Describe manages various implementations of Storage Describing
-func Describe(storages []string, openebsNs, casType string) error {
+func Describe(storages []string, openebsNs, casType, output string) error {
 	if len(storages) == 0 || storages == nil {
 		return errors.New("please provide atleast one storage node name to describe")
 	}
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
 	// 1. Create the clientset
 	k := client.NewK8sClient(openebsNs)
 	// 2. Get the namespace
@@ -84,7 +180,7 @@ func Describe(storages []string, openebsNs, casType string) error {
 	if openebsNs == "" {
 		if casType == util.ZFSCasType {
 			// a temporary way to get the zfs-namespace
-			zfs, _, err := k.GetZFSNodes(nil, util.List, "", util.MapOptions{})
+			zfs, _, err := k.GetZFSNodes(nil, util.List, "", "", util.MapOptions{})
 			if err != nil {
 				return fmt.Errorf("please specify --openebs-namespace for ZFS LocalPV")
 			}
@@ -95,6 +191,9 @@ func Describe(storages []string, openebsNs, casType string) error {
 			k.Ns = val
 		}
 	}
+	if util.IsStructuredOutput(output) {
+		return printStorageDescription(k, storages, casType, output)
+	}
 	// 3. Run a specific cas-type function
 	if casType != "" {
 		if work, ok := CasDescribeMap()[casType]; ok {
@@ -117,12 +216,43 @@ func Describe(storages []string, openebsNs, casType string) error {
 	return nil
 }
 
+// printStorageDescription prints the details of the storage nodes in the
+// machine-readable output format
+func printStorageDescription(k *client.K8sClient, storages []string, casType string, output string) error {
+	works := CasOutputList()
+	if casType != "" {
+		work, ok := CasOutputMap()[casType]
+		if !ok {
+			return fmt.Errorf("cas-type %s unknown", casType)
+		}
+		works = []func(*client.K8sClient, []string, string, string) ([]interface{}, error){work}
+	}
+	var items []interface{}
+	for _, storageName := range storages {
+		for _, work := range works {
+			if found, err := work(k, []string{storageName}, "", ""); err == nil {
+				items = append(items, found...)
+			}
+		}
+	}
+	return util.PrintList(output, storageDescribeKind, items)
+}
+
+// printNodeEvents prints the events of the ZFSNode or the LVMNode
+func printNodeEvents(c *client.K8sClient, kind, name string) {
+	// the events are only a part of the description, they are skipped on errors
+	if events, err := c.GetObjectEvents(corev1.ObjectReference{Kind: kind, Name: name}); err == nil {
+		util.PrintEvents(events)
+	}
+}
+
 // CasListMap returns a map cas-types to functions for Storage listing
-func CasListMap() map[string]func(*client.K8sClient, []string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error) {
+func CasListMap() map[string]func(*client.K8sClient, []string, string, string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error) {
 	// a good hack to implement immutable maps in Golang & also write tests for it
-	return map[string]func(*client.K8sClient, []string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error){
-		util.LVMCasType: GetVolumeGroups,
-		util.ZFSCasType: GetZFSPools,
+	return map[string]func(*client.K8sClient, []string, string, string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error){
+		util.LVMCasType:      GetVolumeGroups,
+		util.ZFSCasType:      GetZFSPools,
+		util.MayastorCasType: GetDiskPools,
 	}
 }
 
@@ -130,12 +260,56 @@ func CasListMap() map[string]func(*client.K8sClient, []string) ([]metav1.TableCo
 func CasDescribeMap() map[string]func(*client.K8sClient, string) error {
 	// a good hack to implement immutable maps in Golang & also write tests for it
 	return map[string]func(*client.K8sClient, string) error{
-		util.ZFSCasType: DescribeZFSNode,
-		util.LVMCasType: DescribeLVMvg,
+		util.ZFSCasType:      DescribeZFSNode,
+		util.LVMCasType:      DescribeLVMvg,
+		util.MayastorCasType: DescribeDiskPool,
 	}
 }
 
-// CasDescribeList returns a list of functions which describe a Storage i.e. a zfspool/volume-group
+// CasDescribeList returns a list of functions which describe a Storage i.e. a zfspool/volume-group/diskpool
 func CasDescribeList() []func(*client.K8sClient, string) error {
-	return []func(*client.K8sClient, string) error{DescribeZFSNode, DescribeLVMvg}
+	return []func(*client.K8sClient, string) error{DescribeZFSNode, DescribeLVMvg, DescribeDiskPool}
+}
+
+// CasOutputMap returns a map cas-types to functions which return the details
+// of Storage for the machine-readable output
+func CasOutputMap() map[string]func(*client.K8sClient, []string, string, string) ([]interface{}, error) {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient, []string, string, string) ([]interface{}, error){
+		util.LVMCasType:      getLVMvgOutput,
+		util.ZFSCasType:      getZFSNodeOutput,
+		util.MayastorCasType: getDiskPoolOutput,
+	}
+}
+
+// CasOutputList returns a list of functions which return the details of
+// Storage for the machine-readable output
+func CasOutputList() []func(*client.K8sClient, []string, string, string) ([]interface{}, error) {
+	return []func(*client.K8sClient, []string, string, string) ([]interface{}, error){getLVMvgOutput, getZFSNodeOutput, getDiskPoolOutput}
+}
+
+// getLVMvgOutput returns the LVMvgDescs of the LVM nodes as output items
+func getLVMvgOutput(c *client.K8sClient, vgs []string, labelSelector, fieldSelector string) ([]interface{}, error) {
+	descs, err := GetLVMvgDescs(c, vgs, labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]interface{}, len(descs))
+	for i := range descs {
+		items[i] = descs[i]
+	}
+	return items, nil
+}
+
+// getZFSNodeOutput returns the ZfsNodeDescs of the zfsnodes as output items
+func getZFSNodeOutput(c *client.K8sClient, zfsnodes []string, labelSelector, fieldSelector string) ([]interface{}, error) {
+	descs, err := GetZFSNodeDescs(c, zfsnodes, labelSelector, fieldSelector)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]interface{}, len(descs))
+	for i := range descs {
+		items[i] = descs[i]
+	}
+	return items, nil
 }
@@ -19,8 +19,10 @@ package storage
 import (
 	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
 	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 var (
@@ -102,3 +104,28 @@ var zfsNode3 = zfs.ZFSNode{
 	Pools: []zfs.Pool{{Name: "zfs-pool1", UUID: "15423895941648453428", Free: resource.MustParse("33285828")},
 		{Name: "zfs-poolX", UUID: "15423895941648453426", Free: resource.MustParse("33285828Ki")}},
 }
+
+var zfsNode1Event = corev1.Event{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "node1.1234",
+		Namespace: "zfs",
+	},
+	InvolvedObject: corev1.ObjectReference{Kind: "ZFSNode", Name: "node1", Namespace: "zfs"},
+	Type:           corev1.EventTypeWarning,
+	Reason:         "PoolNotFound",
+	Message:        "pool zfs-pool2 is not imported",
+	Count:          2,
+	LastTimestamp:  metav1.Now(),
+}
+
+// newDiskPool returns a Mayastor DiskPool of the api version, its space is in bytes
+func newDiskPool(apiVersion, name, node string, capacity, used int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       "DiskPool",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "mayastor"},
+		"spec":       map[string]interface{}{"node": node, "disks": []interface{}{"/dev/sdb"}},
+		"status": map[string]interface{}{"cr_state": "Created", "pool_status": "Online",
+			"capacity": capacity, "used": used, "available": capacity - used},
+	}}
+}
@@ -34,9 +34,9 @@ NUMBER OF POOLS : {{.NumberOfPools}}
 TOTAL FREE      : {{.TotalFree}}
 `
 
-// GetZFSPools lists all zfspools by zfsnodes
-func GetZFSPools(c *client.K8sClient, zfsnodes []string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error) {
-	zfsNodes, _, err := c.GetZFSNodes(zfsnodes, util.List, "", util.MapOptions{})
+// GetZFSPools lists all zfspools by zfsnodes matching the label & field selectors
+func GetZFSPools(c *client.K8sClient, zfsnodes []string, labelSelector, fieldSelector string) ([]metav1.TableColumnDefinition, []metav1.TableRow, error) {
+	zfsNodes, _, err := c.GetZFSNodes(zfsnodes, util.List, labelSelector, fieldSelector, util.MapOptions{})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -65,32 +65,70 @@ func GetZFSPools(c *client.K8sClient, zfsnodes []string) ([]metav1.TableColumnDe
 
 // ZfsNodeDesc describes a zfsnode
 type ZfsNodeDesc struct {
-	HostName      string
-	Namespace     string
-	NumberOfPools int
-	TotalFree     string
+	HostName      string        `json:"hostName"`
+	Namespace     string        `json:"namespace"`
+	NumberOfPools int           `json:"numberOfPools"`
+	TotalFree     string        `json:"totalFree"`
+	Pools         []ZfsPoolDesc `json:"pools"`
+	CasType       string        `json:"casType"`
+}
+
+// ZfsPoolDesc describes a zfspool present in a zfsnode
+type ZfsPoolDesc struct {
+	Name string `json:"name"`
+	UUID string `json:"uuid"`
+	Free string `json:"free"`
+}
+
+// ResourceName returns the resource/name reference of the ZfsNodeDesc
+func (d ZfsNodeDesc) ResourceName() string {
+	return "zfsnode/" + d.HostName
 }
 
 // DescribeZFSNode describes a ZFS node & the zfspools present in it
 func DescribeZFSNode(c *client.K8sClient, sName string) error {
-	zfsInfo, _, err := c.GetZFSNodes([]string{sName}, util.List, "", util.MapOptions{})
+	descs, err := GetZFSNodeDescs(c, []string{sName}, "", "")
 	if err != nil {
 		return err
 	}
-	if len(zfsInfo.Items) == 0 {
+	if len(descs) == 0 {
 		return fmt.Errorf("zfsnode %s not found", sName)
 	}
-	zfsN := zfsInfo.Items[0]
-	var totalFree resource.Quantity
-	for _, pools := range zfsN.Pools {
-		// TODO: handle case when size is just represented in numbers of bytes
-		totalFree.Add(pools.Free)
+	if err = util.PrintByTemplate("zfsnodes", zfsdesc, descs[0]); err != nil {
+		return err
+	}
+	printNodeEvents(c, "ZFSNode", sName)
+	return nil
+}
+
+// GetZFSNodeDescs returns the details of the zfsnodes & the zfspools present
+// in them, all the zfsnodes matching the selectors are returned if zfsnodes is empty
+func GetZFSNodeDescs(c *client.K8sClient, zfsnodes []string, labelSelector, fieldSelector string) ([]ZfsNodeDesc, error) {
+	zfsInfo, _, err := c.GetZFSNodes(zfsnodes, util.List, labelSelector, fieldSelector, util.MapOptions{})
+	if err != nil {
+		return nil, err
 	}
-	desc := ZfsNodeDesc{
-		HostName:      zfsN.Name,
-		Namespace:     zfsN.Namespace,
-		NumberOfPools: len(zfsN.Pools),
-		TotalFree:     util.ConvertToIBytes(totalFree.String()),
+	var descs []ZfsNodeDesc
+	for _, zfsN := range zfsInfo.Items {
+		var totalFree resource.Quantity
+		pools := make([]ZfsPoolDesc, 0, len(zfsN.Pools))
+		for _, pool := range zfsN.Pools {
+			// TODO: handle case when size is just represented in numbers of bytes
+			totalFree.Add(pool.Free)
+			pools = append(pools, ZfsPoolDesc{
+				Name: pool.Name,
+				UUID: pool.UUID,
+				Free: util.ConvertToIBytes(pool.Free.String()),
+			})
+		}
+		descs = append(descs, ZfsNodeDesc{
+			HostName:      zfsN.Name,
+			Namespace:     zfsN.Namespace,
+			NumberOfPools: len(zfsN.Pools),
+			TotalFree:     util.ConvertToIBytes(totalFree.String()),
+			Pools:         pools,
+			CasType:       util.ZFSCasType,
+		})
 	}
-	return util.PrintByTemplate("zfsnodes", zfsdesc, desc)
+	return descs, nil
 }
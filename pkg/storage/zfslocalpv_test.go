@@ -25,8 +25,10 @@ import (
 	"github.com/openebs/openebsctl/pkg/util"
 	fakezfsclient "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
 	fakezfs "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/typed/zfs/v1/fake"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	k8stest "k8s.io/client-go/testing"
 )
 
@@ -71,7 +73,7 @@ func TestGetZFSPools(t *testing.T) {
 			if tt.zfsfunc != nil {
 				tt.zfsfunc(tt.args.c)
 			}
-			if head, row, err := GetZFSPools(tt.args.c, tt.args.zfsnodes); (err != nil) != tt.wantErr {
+			if head, row, err := GetZFSPools(tt.args.c, tt.args.zfsnodes, "", ""); (err != nil) != tt.wantErr {
 				t.Errorf("GetZFSPools() error = %v, wantErr %v", err, tt.wantErr)
 			} else if err == nil {
 				if !reflect.DeepEqual(row, tt.want) {
@@ -100,22 +102,27 @@ func TestDescribeZFSNode(t *testing.T) {
 	}{
 		{
 			"no ZFS nodes exist",
-			args{c: &client.K8sClient{Ns: "", ZFCS: fakezfsclient.NewSimpleClientset()}, zfsfunc: zfsNodeNotFound, sName: "zfs-pv1"},
+			args{c: &client.K8sClient{Ns: "", K8sCS: k8sfake.NewSimpleClientset(), ZFCS: fakezfsclient.NewSimpleClientset()}, zfsfunc: zfsNodeNotFound, sName: "zfs-pv1"},
 			true,
 		},
 		{
 			"one ZFS node exist",
-			args{c: &client.K8sClient{Ns: "zfs", ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode1)}, sName: "node1"},
+			args{c: &client.K8sClient{Ns: "zfs", K8sCS: k8sfake.NewSimpleClientset(), ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode1)}, sName: "node1"},
+			false,
+		},
+		{
+			"one ZFS node exist with events",
+			args{c: &client.K8sClient{Ns: "zfs", K8sCS: k8sfake.NewSimpleClientset(&zfsNode1Event), ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode1)}, sName: "node1"},
 			false,
 		},
 		{
 			"one ZFS node exist with differing size units",
-			args{c: &client.K8sClient{Ns: "zfs", ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode3)}, sName: "node3"},
+			args{c: &client.K8sClient{Ns: "zfs", K8sCS: k8sfake.NewSimpleClientset(), ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode3)}, sName: "node3"},
 			false,
 		},
 		{
 			"two ZFS node exist, none asked for",
-			args{c: &client.K8sClient{Ns: "zfs", ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode1, &zfsNode3)}, sName: "some-pool-name"},
+			args{c: &client.K8sClient{Ns: "zfs", K8sCS: k8sfake.NewSimpleClientset(), ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode1, &zfsNode3)}, sName: "some-pool-name"},
 			true,
 		},
 	}
@@ -138,3 +145,69 @@ func zfsNodeNotFound(c *client.K8sClient) {
 		return true, nil, fmt.Errorf("failed to list ZFS nodes")
 	})
 }
+
+func TestGetZFSNodeDescs(t *testing.T) {
+	q := resource.MustParse("33285828Ki")
+	free := util.ConvertToIBytes(q.String())
+	labeledNode := zfsNode2.DeepCopy()
+	labeledNode.Labels = map[string]string{"topology.kubernetes.io/zone": "zone-a"}
+	tests := []struct {
+		name     string
+		c        *client.K8sClient
+		zfsNodes []string
+		selector string
+		want     []ZfsNodeDesc
+		wantErr  bool
+	}{
+		{
+			"one zfsnode with one pool",
+			&client.K8sClient{Ns: "zfs", ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode1)},
+			nil,
+			"",
+			[]ZfsNodeDesc{{HostName: "node1", Namespace: "zfs", NumberOfPools: 1, TotalFree: free,
+				Pools:   []ZfsPoolDesc{{Name: "zfs-pool1", UUID: "15423895941648453427", Free: free}},
+				CasType: util.ZFSCasType}},
+			false,
+		},
+		{
+			"asked zfsnode out of two",
+			&client.K8sClient{Ns: "zfs", ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode1, &zfsNode2)},
+			[]string{"node1"},
+			"",
+			[]ZfsNodeDesc{{HostName: "node1", Namespace: "zfs", NumberOfPools: 1, TotalFree: free,
+				Pools:   []ZfsPoolDesc{{Name: "zfs-pool1", UUID: "15423895941648453427", Free: free}},
+				CasType: util.ZFSCasType}},
+			false,
+		},
+		{
+			"no zfsnodes present",
+			&client.K8sClient{Ns: "zfs", ZFCS: fakezfsclient.NewSimpleClientset()},
+			nil,
+			"",
+			nil,
+			false,
+		},
+		{
+			"zfsnode matching the label selector",
+			&client.K8sClient{Ns: "zfs", ZFCS: fakezfsclient.NewSimpleClientset(&zfsNode1, labeledNode)},
+			nil,
+			"topology.kubernetes.io/zone=zone-a",
+			[]ZfsNodeDesc{{HostName: "node2", Namespace: "zfs", NumberOfPools: 2, TotalFree: util.ConvertToIBytes("66571656Ki"),
+				Pools: []ZfsPoolDesc{{Name: "zfs-pool2", UUID: "15423895941648453428", Free: free},
+					{Name: "zfs-pool3", UUID: "15423895941648453426", Free: free}},
+				CasType: util.ZFSCasType}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetZFSNodeDescs(tt.c, tt.zfsNodes, tt.selector, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetZFSNodeDescs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetZFSNodeDescs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storageclass
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// storageClassDescribeKind is the kind of the machine-readable storageclass describe
+const storageClassDescribeKind = "StorageClassDescriptionList"
+
+const storageClassInfoTemplate = `
+{{.Name}} Details :
+------------------
+NAME                : {{.Name}}
+CAS TYPE            : {{.CasType}}
+PROVISIONER         : {{.Provisioner}}
+RECLAIM POLICY      : {{.ReclaimPolicy}}
+VOLUME BINDING MODE : {{.VolumeBindingMode}}
+ALLOW EXPANSION     : {{.AllowVolumeExpansion}}
+PARAMETERS          : {{range $k, $v := .Parameters}}{{$k}}={{$v}} {{end}}
+ALLOWED NODES       : {{range $i, $n := .AllowedNodes}}{{if $i}}, {{end}}{{$n}}{{else}}all{{end}}
+PV COUNT            : {{len .PVs}}
+TOTAL CAPACITY      : {{.TotalCapacity}}
+PVC COUNT           : {{len .PVCs}}
+TOTAL REQUESTED     : {{.TotalRequested}}
+`
+
+// Describe checks the parameters of the StorageClasses against their engines
+// & shows them along with the PVs & PVCs using them
+func Describe(scs []string, output string) error {
+	if len(scs) == 0 {
+		return errors.New("please provide atleast one storageclass name to describe")
+	}
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
+	k := client.NewK8sClient()
+	var items []interface{}
+	for _, name := range scs {
+		d, err := describeStorageClass(k, name)
+		if err != nil {
+			return err
+		}
+		if util.IsStructuredOutput(output) {
+			items = append(items, d)
+			continue
+		}
+		printStorageClassDesc(d)
+	}
+	if util.IsStructuredOutput(output) {
+		return util.PrintList(output, storageClassDescribeKind, items)
+	}
+	return nil
+}
+
+// printStorageClassDesc prints the details, the problems & the volumes of a StorageClass
+func printStorageClassDesc(d util.StorageClassDesc) {
+	_ = util.PrintByTemplate("storageclass", storageClassInfoTemplate, d)
+	var problems, pvs, pvcs []metav1.TableRow
+	for _, c := range d.Problems {
+		problems = append(problems, metav1.TableRow{Cells: []interface{}{c.Severity, c.Resource, c.Message}})
+	}
+	for _, pv := range d.PVs {
+		pvs = append(pvs, metav1.TableRow{Cells: []interface{}{pv.Name, pv.PVC, pv.Phase, pv.Capacity}})
+	}
+	for _, pvc := range d.PVCs {
+		pvcs = append(pvcs, metav1.TableRow{Cells: []interface{}{pvc.Namespace, pvc.Name, pvc.Phase, pvc.Volume, pvc.Request}})
+	}
+	printSection("Problems", util.StorageClassProblemColumnDefinitions, problems)
+	printSection("Persistent Volumes", util.StorageClassPVColumnDefinitions, pvs)
+	printSection("Persistent Volume Claims", util.StorageClassPVCColumnDefinitions, pvcs)
+}
+
+// printSection prints the title & the table of a section of the describe
+// output, or <none> if it has no rows
+func printSection(title string, columns []metav1.TableColumnDefinition, rows []metav1.TableRow) {
+	fmt.Printf("\n%s :\n%s\n", title, strings.Repeat("-", len(title)+2))
+	if len(rows) == 0 {
+		fmt.Println("<none>")
+		return
+	}
+	util.TablePrinter(columns, rows, printers.PrintOptions{})
+}
+
+// describeStorageClass returns the details of the StorageClass, the problems
+// of its parameters & the PVs & PVCs using it
+func describeStorageClass(k *client.K8sClient, name string) (util.StorageClassDesc, error) {
+	sc, err := k.GetSC(name)
+	if err != nil {
+		return util.StorageClassDesc{}, err
+	}
+	d := util.StorageClassDesc{
+		Name:              sc.Name,
+		CasType:           casTypeOf(sc),
+		Provisioner:       sc.Provisioner,
+		ReclaimPolicy:     util.NotAvailable,
+		VolumeBindingMode: string(storagev1.VolumeBindingImmediate),
+		Parameters:        sc.Parameters,
+		Problems:          []util.Cause{},
+	}
+	if sc.ReclaimPolicy != nil {
+		d.ReclaimPolicy = string(*sc.ReclaimPolicy)
+	}
+	if sc.VolumeBindingMode != nil {
+		d.VolumeBindingMode = string(*sc.VolumeBindingMode)
+	}
+	if sc.AllowVolumeExpansion != nil {
+		d.AllowVolumeExpansion = *sc.AllowVolumeExpansion
+	}
+	// 1. Check the StorageClass against its engine
+	nodes, err := k.GetNodes(nil, "", "")
+	if err != nil {
+		return d, err
+	}
+	allowed := allowedNodes(sc, nodes.Items)
+	if len(sc.AllowedTopologies) > 0 {
+		d.AllowedNodes = allowed
+	}
+	d.Problems = append(d.Problems, checkCommon(sc, d.CasType, allowed)...)
+	if validate, ok := casValidateMap()[d.CasType]; ok && len(allowed) > 0 {
+		d.Problems = append(d.Problems, validate(k, sc, allowed)...)
+	}
+	// 2. Get the PVs & PVCs using the StorageClass
+	if err = addVolumes(k, &d); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+// addVolumes adds the PVs & the PVCs of the StorageClass & their totals
+func addVolumes(k *client.K8sClient, d *util.StorageClassDesc) error {
+	pvs, err := k.GetPVs(nil, "", "")
+	if err != nil {
+		return err
+	}
+	total := resource.Quantity{}
+	d.PVs = []util.StorageClassVolume{}
+	for _, pv := range pvs.Items {
+		if pv.Spec.StorageClassName != d.Name {
+			continue
+		}
+		pvc := util.NotAvailable
+		if pv.Spec.ClaimRef != nil {
+			pvc = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+		}
+		capacity := pv.Spec.Capacity[corev1.ResourceStorage]
+		total.Add(capacity)
+		d.PVs = append(d.PVs, util.StorageClassVolume{Name: pv.Name, PVC: pvc, Phase: string(pv.Status.Phase), Capacity: util.ConvertToIBytes(capacity.String())})
+	}
+	d.TotalCapacity = util.ConvertToIBytes(total.String())
+	pvcs, err := k.GetPVCs("", nil, "")
+	if err != nil {
+		return err
+	}
+	requested := resource.Quantity{}
+	d.PVCs = []util.StorageClassClaim{}
+	for _, pvc := range pvcs.Items {
+		if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != d.Name {
+			continue
+		}
+		request := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		requested.Add(request)
+		volume := pvc.Spec.VolumeName
+		if volume == "" {
+			volume = util.NotAvailable
+		}
+		d.PVCs = append(d.PVCs, util.StorageClassClaim{Name: pvc.Name, Namespace: pvc.Namespace, Phase: string(pvc.Status.Phase), Volume: volume, Request: util.ConvertToIBytes(request.String())})
+	}
+	d.TotalRequested = util.ConvertToIBytes(requested.String())
+	sort.Slice(d.PVs, func(i, j int) bool { return d.PVs[i].Name < d.PVs[j].Name })
+	sort.Slice(d.PVCs, func(i, j int) bool {
+		if d.PVCs[i].Namespace != d.PVCs[j].Namespace {
+			return d.PVCs[i].Namespace < d.PVCs[j].Namespace
+		}
+		return d.PVCs[i].Name < d.PVCs[j].Name
+	})
+	return nil
+}
+
+// casTypeOf returns the cas-type of the StorageClass, the hostpath
+// provisioner has the storage type in its config annotation
+func casTypeOf(sc *storagev1.StorageClass) string {
+	if casType := util.GetCasTypeFromSC(sc); casType != util.Unknown {
+		return casType
+	}
+	if sc.Provisioner != util.LocalPVHostpathProvisioner {
+		return util.Unknown
+	}
+	config, err := hostpathConfigOf(sc)
+	if err == nil && config["StorageType"] == deviceStorageType {
+		return util.LocalPvDeviceCasType
+	}
+	return util.LocalPvHostpathCasType
+}
+
+// allowedNodes returns the sorted names of the nodes in the allowed
+// topologies of the StorageClass, all the nodes if it has none
+func allowedNodes(sc *storagev1.StorageClass, nodes []corev1.Node) []string {
+	var names []string
+	for _, node := range nodes {
+		if util.TopologyMatches(sc.AllowedTopologies, node.Labels) {
+			names = append(names, node.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkCommon checks the provisioner, the binding mode & the allowed
+// topologies of the StorageClass
+func checkCommon(sc *storagev1.StorageClass, casType string, allowed []string) []util.Cause {
+	var problems []util.Cause
+	ref := "storageclass/" + sc.Name
+	if _, ok := casValidateMap()[casType]; !ok {
+		message := fmt.Sprintf("the provisioner %s is not a supported OpenEBS provisioner, the parameters are not checked", sc.Provisioner)
+		if util.IsValidCasType(casType) {
+			// an OpenEBS engine whose storageclasses aren't validated yet
+			message = fmt.Sprintf("the parameters of the %s storageclasses are not checked, their validation isn't implemented", casType)
+		}
+		return append(problems, util.Cause{Severity: util.SeverityWarning, Resource: ref, Message: message})
+	}
+	if driver, ok := util.CasTypeToCSIProvisionerMap[casType]; ok && driver != sc.Provisioner {
+		problems = append(problems, util.Cause{Severity: util.SeverityError, Resource: ref,
+			Message: fmt.Sprintf("the storageclass is of cas-type %s but its provisioner is %s, it must be %s", casType, sc.Provisioner, driver)})
+	}
+	if sc.VolumeBindingMode == nil || *sc.VolumeBindingMode != storagev1.VolumeBindingWaitForFirstConsumer {
+		problems = append(problems, util.Cause{Severity: util.SeverityWarning, Resource: ref,
+			Message: "the local volumes are provisioned before the pods are scheduled, use the WaitForFirstConsumer volumeBindingMode"})
+	}
+	if len(allowed) == 0 {
+		problems = append(problems, util.Cause{Severity: util.SeverityError, Resource: ref,
+			Message: "none of the nodes are in the allowed topologies"})
+	}
+	return problems
+}
+
+// checkYesNo returns a problem if the parameter is set to neither yes nor no
+func checkYesNo(sc *storagev1.StorageClass, param string) []util.Cause {
+	if value, ok := sc.Parameters[param]; ok && value != "yes" && value != "no" {
+		return []util.Cause{{Severity: util.SeverityError, Resource: "storageclass/" + sc.Name,
+			Message: fmt.Sprintf("invalid %s %s, it must be yes or no", param, value)}}
+	}
+	return nil
+}
+
+// casValidateMap returns a map of cas-types to the functions checking the
+// parameters of their StorageClasses on the allowed nodes
+func casValidateMap() map[string]func(*client.K8sClient, *storagev1.StorageClass, []string) []util.Cause {
+	return map[string]func(*client.K8sClient, *storagev1.StorageClass, []string) []util.Cause{
+		util.ZFSCasType:             validateZFS,
+		util.LVMCasType:             validateLVM,
+		util.LocalPvHostpathCasType: validateHostpath,
+		util.LocalPvDeviceCasType:   validateHostpath,
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storageclass
+
+import (
+	"reflect"
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newSC(name, provisioner string, params map[string]string, topology []corev1.TopologySelectorTerm) *storagev1.StorageClass {
+	binding := storagev1.VolumeBindingWaitForFirstConsumer
+	return &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: name},
+		Provisioner:       provisioner,
+		Parameters:        params,
+		VolumeBindingMode: &binding,
+		AllowedTopologies: topology,
+	}
+}
+
+func newClaimedPV(name, sc, size string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			StorageClassName: sc,
+			Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			ClaimRef:         &corev1.ObjectReference{Namespace: "default", Name: "claim-" + name},
+		},
+		Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+	}
+}
+
+func newClaim(name, sc, volume, size string) *corev1.PersistentVolumeClaim {
+	phase := corev1.ClaimBound
+	if volume == "" {
+		phase = corev1.ClaimPending
+	}
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &sc,
+			VolumeName:       volume,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func TestDescribeStorageClass(t *testing.T) {
+	immediate := newSC("immediate", util.ZFSCSIDriver, map[string]string{"poolname": "zfspv", "fstype": "zfs"}, nil)
+	binding := storagev1.VolumeBindingImmediate
+	immediate.VolumeBindingMode = &binding
+	hostpath := newSC("hostpath", util.LocalPVHostpathProvisioner, nil, nil)
+	hostpath.Annotations = map[string]string{util.HostpathConfigAnnotation: "- name: StorageType\n  value: hostpath\n- name: BasePath\n  value: var/openebs\n"}
+	device := newSC("device", util.LocalPVHostpathProvisioner, nil, nil)
+	device.Annotations = map[string]string{util.HostpathConfigAnnotation: "- name: StorageType\n  value: device\n"}
+	tests := []struct {
+		name     string
+		sc       *storagev1.StorageClass
+		casType  string
+		allowed  []string
+		problems []util.Cause
+	}{
+		{
+			name:     "zfs pool on every allowed node",
+			sc:       newSC("zfs", util.ZFSCSIDriver, map[string]string{"poolname": "zfspv", "fstype": "zfs", "recordsize": "64k", "compression": "zstd-3"}, hostnames("node1", "node3.example.com")),
+			casType:  util.ZFSCasType,
+			allowed:  []string{"node1", "node3"},
+			problems: []util.Cause{},
+		},
+		{
+			name:    "zfs pool missing on an allowed node",
+			sc:      newSC("zfs", util.ZFSCSIDriver, map[string]string{"poolname": "fastpool"}, hostnames("node1", "node3.example.com")),
+			casType: util.ZFSCasType,
+			allowed: []string{"node1", "node3"},
+			problems: []util.Cause{
+				{Severity: "Error", Resource: "storageclass/zfs", Message: "pool fastpool is missing on the allowed nodes node3"},
+			},
+		},
+		{
+			name:    "zfs pool missing on a node without topology",
+			sc:      newSC("zfs", util.ZFSCSIDriver, map[string]string{"poolname": "fastpool"}, nil),
+			casType: util.ZFSCasType,
+			problems: []util.Cause{
+				{Severity: "Warning", Resource: "storageclass/zfs", Message: "pool fastpool is missing on the nodes node3, the volumes of the pods scheduled on them fail, restrict the allowedTopologies to the nodes having it"},
+			},
+		},
+		{
+			name:    "zfs invalid parameters & binding mode",
+			sc:      immediate,
+			casType: util.ZFSCasType,
+			problems: []util.Cause{
+				{Severity: "Warning", Resource: "storageclass/immediate", Message: "the local volumes are provisioned before the pods are scheduled, use the WaitForFirstConsumer volumeBindingMode"},
+			},
+		},
+		{
+			name:    "zfs unsupported fstype & misplaced volblocksize",
+			sc:      newSC("zfs", util.ZFSCSIDriver, map[string]string{"poolname": "zfspv", "fstype": "ntfs", "volblocksize": "3k", "dedup": "maybe"}, nil),
+			casType: util.ZFSCasType,
+			problems: []util.Cause{
+				{Severity: "Error", Resource: "storageclass/zfs", Message: "unsupported fstype ntfs, use one of zfs, ext4, xfs, btrfs"},
+				{Severity: "Error", Resource: "storageclass/zfs", Message: "invalid volblocksize: size 3k must be a power of two between 512.0B and 128.0KiB"},
+				{Severity: "Error", Resource: "storageclass/zfs", Message: "invalid dedup maybe"},
+			},
+		},
+		{
+			name:     "lvm pattern matching a volume group",
+			sc:       newSC("lvm", util.LocalPVLVMCSIDriver, map[string]string{"vgpattern": "^lvmvg", "fsType": "xfs"}, hostnames("node1", "node2")),
+			casType:  util.LVMCasType,
+			allowed:  []string{"node1", "node2"},
+			problems: []util.Cause{},
+		},
+		{
+			name:    "lvm pattern matching no volume group",
+			sc:      newSC("lvm", util.LocalPVLVMCSIDriver, map[string]string{"vgpattern": "^ssd", "shared": "true"}, nil),
+			casType: util.LVMCasType,
+			problems: []util.Cause{
+				{Severity: "Error", Resource: "storageclass/lvm", Message: "invalid shared true, it must be yes or no"},
+				{Severity: "Error", Resource: "storageclass/lvm", Message: "a volume group matching ^ssd is not found on any of the allowed nodes"},
+			},
+		},
+		{
+			name:    "lvm wrong provisioner & no volume group",
+			sc:      newSC("lvm", "lvm.csi.openebs.io", map[string]string{"cas-type": util.LVMCasType}, nil),
+			casType: util.LVMCasType,
+			problems: []util.Cause{
+				{Severity: "Error", Resource: "storageclass/lvm", Message: "the storageclass is of cas-type localpv-lvm but its provisioner is lvm.csi.openebs.io, it must be local.csi.openebs.io"},
+				{Severity: "Error", Resource: "storageclass/lvm", Message: "the storageclass has no volgroup or vgpattern parameter"},
+			},
+		},
+		{
+			name:    "hostpath relative base path",
+			sc:      hostpath,
+			casType: util.LocalPvHostpathCasType,
+			problems: []util.Cause{
+				{Severity: "Error", Resource: "storageclass/hostpath", Message: "BasePath var/openebs must be an absolute path"},
+			},
+		},
+		{
+			name:     "device storage type",
+			sc:       device,
+			casType:  util.LocalPvDeviceCasType,
+			problems: []util.Cause{},
+		},
+		{
+			name:    "mayastor storageclass",
+			sc:      newSC("mayastor-3", util.MayastorCSIDriver, map[string]string{"repl": "3", "protocol": "nvmf"}, nil),
+			casType: util.MayastorCasType,
+			problems: []util.Cause{
+				{Severity: "Warning", Resource: "storageclass/mayastor-3", Message: "the parameters of the mayastor storageclasses are not checked, their validation isn't implemented"},
+			},
+		},
+		{
+			name:    "not an openebs storageclass",
+			sc:      newSC("gp2", "ebs.csi.aws.com", nil, nil),
+			casType: util.Unknown,
+			problems: []util.Cause{
+				{Severity: "Warning", Resource: "storageclass/gp2", Message: "the provisioner ebs.csi.aws.com is not a supported OpenEBS provisioner, the parameters are not checked"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				K8sCS: k8sfake.NewSimpleClientset(tt.sc, node1, node2, node3),
+				ZFCS:  zfsfake.NewSimpleClientset(zfsNode1, zfsNode3),
+				LVMCS: lvmfake.NewSimpleClientset(lvmNode1, lvmNode2),
+			}
+			got, err := describeStorageClass(k, tt.sc.Name)
+			if err != nil {
+				t.Fatalf("describeStorageClass() error = %v", err)
+			}
+			if got.CasType != tt.casType {
+				t.Errorf("describeStorageClass() casType = %s, want %s", got.CasType, tt.casType)
+			}
+			if !reflect.DeepEqual(got.AllowedNodes, tt.allowed) {
+				t.Errorf("describeStorageClass() allowedNodes = %v, want %v", got.AllowedNodes, tt.allowed)
+			}
+			if !reflect.DeepEqual(got.Problems, tt.problems) {
+				t.Errorf("describeStorageClass() problems = %v, want %v", got.Problems, tt.problems)
+			}
+		})
+	}
+}
+
+func TestDescribeStorageClassVolumes(t *testing.T) {
+	sc := newSC("lvm", util.LocalPVLVMCSIDriver, map[string]string{"volgroup": "lvmvg"}, nil)
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(sc, node1,
+			newClaimedPV("pvc-2", "lvm", "2Gi"), newClaimedPV("pvc-1", "lvm", "4Gi"), newClaimedPV("pvc-3", "other", "8Gi"),
+			newClaim("claim-pvc-1", "lvm", "pvc-1", "4Gi"), newClaim("pending", "lvm", "", "1Gi"), newClaim("other", "other", "pvc-3", "8Gi")),
+		LVMCS: lvmfake.NewSimpleClientset(lvmNode1),
+	}
+	got, err := describeStorageClass(k, "lvm")
+	if err != nil {
+		t.Fatalf("describeStorageClass() error = %v", err)
+	}
+	wantPVs := []util.StorageClassVolume{
+		{Name: "pvc-1", PVC: "default/claim-pvc-1", Phase: "Bound", Capacity: "4.0GiB"},
+		{Name: "pvc-2", PVC: "default/claim-pvc-2", Phase: "Bound", Capacity: "2.0GiB"},
+	}
+	wantPVCs := []util.StorageClassClaim{
+		{Name: "claim-pvc-1", Namespace: "default", Phase: "Bound", Volume: "pvc-1", Request: "4.0GiB"},
+		{Name: "pending", Namespace: "default", Phase: "Pending", Volume: "N/A", Request: "1.0GiB"},
+	}
+	if !reflect.DeepEqual(got.PVs, wantPVs) {
+		t.Errorf("describeStorageClass() pvs = %v, want %v", got.PVs, wantPVs)
+	}
+	if !reflect.DeepEqual(got.PVCs, wantPVCs) {
+		t.Errorf("describeStorageClass() pvcs = %v, want %v", got.PVCs, wantPVCs)
+	}
+	if got.TotalCapacity != "6.0GiB" || got.TotalRequested != "5.0GiB" {
+		t.Errorf("describeStorageClass() totals = %s & %s, want 6.0GiB & 5.0GiB", got.TotalCapacity, got.TotalRequested)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storageclass
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	storagev1 "k8s.io/api/storage/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// hostpathStorageType is the StorageType of the hostpath config
+	hostpathStorageType = "hostpath"
+	// deviceStorageType is the StorageType of the localpv-device config
+	deviceStorageType = "device"
+	// defaultBasePath is the default directory of the hostpath volumes
+	defaultBasePath = "/var/openebs/local"
+)
+
+// hostpathConfig is an entry of the config annotation of the
+// dynamic-localpv-provisioner StorageClasses
+type hostpathConfig struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// hostpathParameters asks for the BasePath of a localpv-hostpath
+// StorageClass, the volumes can be on any node
+func hostpathParameters(_ *client.K8sClient, p prompter, sc *storagev1.StorageClass) error {
+	basePath, err := p.Input("BasePath", defaultBasePath, func(basePath string) error {
+		if !path.IsAbs(basePath) {
+			return fmt.Errorf("BasePath %s must be an absolute path", basePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	config, err := yaml.Marshal([]hostpathConfig{
+		{Name: "StorageType", Value: hostpathStorageType},
+		{Name: "BasePath", Value: basePath},
+	})
+	if err != nil {
+		return err
+	}
+	sc.Provisioner = util.LocalPVHostpathProvisioner
+	sc.Annotations = map[string]string{
+		util.OpenEBSCasTypeKey:        "local",
+		util.HostpathConfigAnnotation: string(config),
+	}
+	return nil
+}
+
+// hostpathConfigOf returns the names & values of the config annotation of a
+// dynamic-localpv-provisioner StorageClass
+func hostpathConfigOf(sc *storagev1.StorageClass) (map[string]string, error) {
+	var entries []hostpathConfig
+	if err := yaml.Unmarshal([]byte(sc.Annotations[util.HostpathConfigAnnotation]), &entries); err != nil {
+		return nil, err
+	}
+	config := make(map[string]string)
+	for _, entry := range entries {
+		config[entry.Name] = entry.Value
+	}
+	return config, nil
+}
+
+// validateHostpath checks the config annotation of a localpv-hostpath or a
+// localpv-device StorageClass
+func validateHostpath(_ *client.K8sClient, sc *storagev1.StorageClass, _ []string) []util.Cause {
+	ref := "storageclass/" + sc.Name
+	config, err := hostpathConfigOf(sc)
+	if err != nil {
+		return []util.Cause{{Severity: util.SeverityError, Resource: ref, Message: fmt.Sprintf("invalid %s annotation: %v", util.HostpathConfigAnnotation, err)}}
+	}
+	var problems []util.Cause
+	if storageType, ok := config["StorageType"]; ok && storageType != hostpathStorageType && storageType != deviceStorageType {
+		problems = append(problems, util.Cause{Severity: util.SeverityError, Resource: ref, Message: fmt.Sprintf("unsupported StorageType %s, use hostpath or device", storageType)})
+	}
+	if basePath, ok := config["BasePath"]; ok && !path.IsAbs(basePath) {
+		problems = append(problems, util.Cause{Severity: util.SeverityError, Resource: ref, Message: fmt.Sprintf("BasePath %s must be an absolute path", basePath)})
+	}
+	return problems
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storageclass
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// lvmParameters asks for the volume group or the pattern of the volume
+// groups of a LVM-LocalPV StorageClass, only the nodes having a matching
+// volume group are allowed
+func lvmParameters(k *client.K8sClient, p prompter, sc *storagev1.StorageClass) error {
+	nodes, _, err := k.GetLVMNodes(nil, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return err
+	}
+	vgNodes := make(map[string][]string)
+	for _, node := range nodes.Items {
+		for _, vg := range node.VolumeGroups {
+			vgNodes[vg.Name] = append(vgNodes[vg.Name], node.Name)
+		}
+	}
+	if len(vgNodes) == 0 {
+		return fmt.Errorf("no lvm volume groups found in the cluster")
+	}
+	params := make(map[string]string)
+	var allowed []string
+	mode, err := p.Select("volume group by", []string{util.LVMVolGroupParam, util.LVMVgPatternParam})
+	if err != nil {
+		return err
+	}
+	if mode == util.LVMVolGroupParam {
+		vg, err := p.Select(util.LVMVolGroupParam, sortedKeys(vgNodes))
+		if err != nil {
+			return err
+		}
+		params[util.LVMVolGroupParam] = vg
+		allowed = vgNodes[vg]
+	} else {
+		pattern, err := p.Input(util.LVMVgPatternParam, "", func(pattern string) error {
+			_, err := matchingNodes(pattern, vgNodes)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		params[util.LVMVgPatternParam] = pattern
+		if allowed, err = matchingNodes(pattern, vgNodes); err != nil {
+			return err
+		}
+	}
+	if params[util.LVMThinProvisionParam], err = p.Select(util.LVMThinProvisionParam, []string{"no", "yes"}); err != nil {
+		return err
+	}
+	if params[util.LVMSharedParam], err = p.Select(util.LVMSharedParam, []string{"no", "yes"}); err != nil {
+		return err
+	}
+	if sc.AllowedTopologies, err = hostnameTopology(k, allowed); err != nil {
+		return err
+	}
+	expansion := true
+	sc.Provisioner = util.LocalPVLVMCSIDriver
+	sc.Parameters = params
+	sc.AllowVolumeExpansion = &expansion
+	return nil
+}
+
+// matchingNodes returns the sorted nodes having a volume group matching the
+// pattern, it is an error if no volume group matches
+func matchingNodes(pattern string, vgNodes map[string][]string) ([]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %v", pattern, err)
+	}
+	nodeSet := make(map[string]bool)
+	for vg, nodes := range vgNodes {
+		if !re.MatchString(vg) {
+			continue
+		}
+		for _, node := range nodes {
+			nodeSet[node] = true
+		}
+	}
+	if len(nodeSet) == 0 {
+		return nil, fmt.Errorf("pattern %s matches none of the volume groups %v", pattern, sortedKeys(vgNodes))
+	}
+	nodes := make([]string, 0, len(nodeSet))
+	for node := range nodeSet {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+// LVMFsTypes are the filesystems of the LVM-LocalPV volumes
+var LVMFsTypes = []string{"ext2", "ext3", "ext4", "xfs", "btrfs"}
+
+// validateLVM checks the filesystem & the flags of a LVM-LocalPV
+// StorageClass & that its volume group or pattern matches a volume group on
+// every allowed node
+func validateLVM(k *client.K8sClient, sc *storagev1.StorageClass, allowed []string) []util.Cause {
+	var problems []util.Cause
+	ref := "storageclass/" + sc.Name
+	add := func(severity, format string, args ...interface{}) {
+		problems = append(problems, util.Cause{Severity: severity, Resource: ref, Message: fmt.Sprintf(format, args...)})
+	}
+	// 1. Check the filesystem & the flags
+	for _, param := range []string{util.LVMFsTypeParam, "csi.storage.k8s.io/fstype"} {
+		if fsType, ok := sc.Parameters[param]; ok && !contains(LVMFsTypes, fsType) {
+			add(util.SeverityError, "unsupported %s %s, use one of %s", param, fsType, strings.Join(LVMFsTypes, ", "))
+		}
+	}
+	problems = append(problems, checkYesNo(sc, util.LVMThinProvisionParam)...)
+	problems = append(problems, checkYesNo(sc, util.LVMSharedParam)...)
+	// 2. Check the volume group or the pattern
+	vg, pattern := sc.Parameters[util.LVMVolGroupParam], sc.Parameters[util.LVMVgPatternParam]
+	var matches func(string) bool
+	var what string
+	switch {
+	case vg == "" && pattern == "":
+		add(util.SeverityError, "the storageclass has no %s or %s parameter", util.LVMVolGroupParam, util.LVMVgPatternParam)
+		return problems
+	case vg != "" && pattern != "":
+		add(util.SeverityWarning, "both %s & %s are set, only one of them should be", util.LVMVolGroupParam, util.LVMVgPatternParam)
+		fallthrough
+	case pattern != "":
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			add(util.SeverityError, "invalid %s %s: %v", util.LVMVgPatternParam, pattern, err)
+			return problems
+		}
+		matches, what = re.MatchString, "a volume group matching "+pattern
+	default:
+		matches, what = func(name string) bool { return name == vg }, "volume group "+vg
+	}
+	nodes, _, err := k.GetLVMNodes(nil, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		add(util.SeverityWarning, "failed to get the lvmnodes, the volume groups of the nodes are not known: %v", err)
+		return problems
+	}
+	nodeVGs := make(map[string][]string)
+	for _, node := range nodes.Items {
+		for _, v := range node.VolumeGroups {
+			nodeVGs[node.Name] = append(nodeVGs[node.Name], v.Name)
+		}
+	}
+	return append(problems, checkNodes(sc, allowed, nodeVGs, matches, what)...)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storageclass
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// prompter asks the user for the parameters of a StorageClass, the tests
+// replace it with scripted answers
+type prompter interface {
+	// Select returns the chosen one of the items
+	Select(label string, items []string) (string, error)
+	// Input returns the entered text, def if nothing is entered, once
+	// validate accepts it
+	Input(label, def string, validate func(string) error) (string, error)
+}
+
+// promptUI asks on the terminal, the prompts are written to stderr to keep
+// the dry-run manifest on stdout clean
+type promptUI struct{}
+
+// Select shows a list of the items to choose from
+func (promptUI) Select(label string, items []string) (string, error) {
+	s := promptui.Select{Label: label, Items: items, Stdout: os.Stderr}
+	_, value, err := s.Run()
+	return value, err
+}
+
+// Input reads a line of text
+func (promptUI) Input(label, def string, validate func(string) error) (string, error) {
+	p := promptui.Prompt{Label: label, Default: def, AllowEdit: true, Validate: validate, Stdout: os.Stderr}
+	return p.Run()
+}
+
+// Create asks for the parameters of a StorageClass of the cas-type & creates
+// it, with dryRun the StorageClass is only printed in the output format
+func Create(casType, name string, dryRun bool, output string) error {
+	if dryRun {
+		if output == "" {
+			output = util.YAMLOutput
+		}
+		if output != util.YAMLOutput && output != util.JSONOutput {
+			return fmt.Errorf("output format %s is not supported, use %s or %s", output, util.YAMLOutput, util.JSONOutput)
+		}
+	} else if output != "" {
+		return fmt.Errorf("the output format can only be used with --dry-run")
+	}
+	k := client.NewK8sClient()
+	sc, err := newStorageClass(k, promptUI{}, casType, name)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return printStorageClass(os.Stdout, sc, output)
+	}
+	if _, err = k.CreateSC(sc); err != nil {
+		return err
+	}
+	fmt.Printf("storageclass %s created\n", sc.Name)
+	return nil
+}
+
+// newStorageClass asks for the cas-type & the name if they are empty, for the
+// common fields & for the parameters of the engine
+func newStorageClass(k *client.K8sClient, p prompter, casType, name string) (*storagev1.StorageClass, error) {
+	var err error
+	if casType == "" {
+		if casType, err = p.Select("cas-type", casTypes()); err != nil {
+			return nil, err
+		}
+	}
+	params, ok := casCreateMap()[casType]
+	if !ok {
+		return nil, fmt.Errorf("creating storageclasses of cas-type %s is not supported, use one of %s", casType, strings.Join(casTypes(), ", "))
+	}
+	if name == "" {
+		if name, err = p.Input("name", "openebs-"+strings.TrimPrefix(casType, "localpv-"), validateName); err != nil {
+			return nil, err
+		}
+	} else if err = validateName(name); err != nil {
+		return nil, err
+	}
+	reclaimPolicy, err := p.Select("reclaimPolicy", []string{string(corev1.PersistentVolumeReclaimDelete), string(corev1.PersistentVolumeReclaimRetain)})
+	if err != nil {
+		return nil, err
+	}
+	policy := corev1.PersistentVolumeReclaimPolicy(reclaimPolicy)
+	// the local volumes are created on the node of the first consumer
+	binding := storagev1.VolumeBindingWaitForFirstConsumer
+	sc := &storagev1.StorageClass{
+		TypeMeta:          metav1.TypeMeta{Kind: "StorageClass", APIVersion: "storage.k8s.io/v1"},
+		ObjectMeta:        metav1.ObjectMeta{Name: name},
+		ReclaimPolicy:     &policy,
+		VolumeBindingMode: &binding,
+	}
+	if err = params(k, p, sc); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// printStorageClass writes the StorageClass manifest in the yaml or json format
+func printStorageClass(w io.Writer, sc *storagev1.StorageClass, output string) error {
+	var data []byte
+	var err error
+	if output == util.JSONOutput {
+		data, err = json.MarshalIndent(sc, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(sc)
+	}
+	if err != nil {
+		return fmt.Errorf("error printing the storageclass: %v", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// hostnameTopology returns the allowed topologies of the nodes, the nodes
+// are matched by their hostname label
+func hostnameTopology(k *client.K8sClient, nodes []string) ([]corev1.TopologySelectorTerm, error) {
+	nodeList, err := k.GetNodes(nodes, "", "")
+	if err != nil {
+		return nil, err
+	}
+	hostnames := make(map[string]string)
+	for _, node := range nodeList.Items {
+		hostnames[node.Name] = node.Labels[util.HostnameTopologyKey]
+	}
+	var values []string
+	for _, node := range nodes {
+		// the hostname label is the node name if it isn't found
+		if hostname := hostnames[node]; hostname != "" {
+			values = append(values, hostname)
+		} else {
+			values = append(values, node)
+		}
+	}
+	sort.Strings(values)
+	return []corev1.TopologySelectorTerm{{
+		MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{Key: util.HostnameTopologyKey, Values: values}},
+	}}, nil
+}
+
+// validateName returns an error if the name isn't a valid StorageClass name
+func validateName(name string) error {
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("invalid name %s: %s", name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// blockSizeRegex matches the ZFS block sizes, e.g. 4k, 128K, 1M
+var blockSizeRegex = regexp.MustCompile(`^([0-9]+)([kKmM]?)$`)
+
+// validateBlockSize returns a function validating a ZFS block size, it must
+// be a power of two between the min & max bytes
+func validateBlockSize(min, max int64) func(string) error {
+	return func(size string) error {
+		match := blockSizeRegex.FindStringSubmatch(size)
+		if match == nil {
+			return fmt.Errorf("invalid size %s, use a size like 4k or 1M", size)
+		}
+		bytes, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid size %s: %v", size, err)
+		}
+		switch strings.ToLower(match[2]) {
+		case "k":
+			bytes <<= 10
+		case "m":
+			bytes <<= 20
+		}
+		if bytes < min || bytes > max || bytes&(bytes-1) != 0 {
+			return fmt.Errorf("size %s must be a power of two between %s and %s", size, util.ConvertToIBytes(strconv.FormatInt(min, 10)), util.ConvertToIBytes(strconv.FormatInt(max, 10)))
+		}
+		return nil
+	}
+}
+
+// sortedKeys returns the sorted keys of the map of pools to their nodes
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// casTypes returns the sorted cas-types whose StorageClasses can be created
+func casTypes() []string {
+	var types []string
+	for casType := range casCreateMap() {
+		types = append(types, casType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// casCreateMap returns a map of cas-types to the functions asking for the
+// provisioner, parameters & topology of their StorageClasses
+func casCreateMap() map[string]func(*client.K8sClient, prompter, *storagev1.StorageClass) error {
+	return map[string]func(*client.K8sClient, prompter, *storagev1.StorageClass) error{
+		util.ZFSCasType:             zfsParameters,
+		util.LVMCasType:             lvmParameters,
+		util.LocalPvHostpathCasType: hostpathParameters,
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storageclass
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	zfsfake "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func hostnames(values ...string) []corev1.TopologySelectorTerm {
+	return []corev1.TopologySelectorTerm{{
+		MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{Key: util.HostnameTopologyKey, Values: values}},
+	}}
+}
+
+func TestNewStorageClass(t *testing.T) {
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(node1, node2, node3),
+		ZFCS:  zfsfake.NewSimpleClientset(zfsNode1, zfsNode3),
+		LVMCS: lvmfake.NewSimpleClientset(lvmNode1, lvmNode2),
+	}
+	tests := []struct {
+		name        string
+		casType     string
+		scName      string
+		wantName    string
+		answers     []string
+		provisioner string
+		params      map[string]string
+		topology    []corev1.TopologySelectorTerm
+		wantErr     bool
+	}{
+		{
+			name:        "zfs dataset on the nodes having the pool",
+			casType:     util.ZFSCasType,
+			answers:     []string{"", "Delete", "zfspv", "zfs", "lz4", "off", ""},
+			wantName:    "openebs-zfs",
+			provisioner: util.ZFSCSIDriver,
+			params:      map[string]string{"poolname": "zfspv", "fstype": "zfs", "compression": "lz4", "dedup": "off", "recordsize": "128k"},
+			topology:    hostnames("node1", "node3.example.com"),
+		},
+		{
+			name:        "zfs zvol on the only node having the pool",
+			casType:     util.ZFSCasType,
+			scName:      "fast",
+			answers:     []string{"Retain", "fastpool", "ext4", "off", "on", "16k"},
+			provisioner: util.ZFSCSIDriver,
+			params:      map[string]string{"poolname": "fastpool", "fstype": "ext4", "compression": "off", "dedup": "on", "volblocksize": "16k"},
+			topology:    hostnames("node1"),
+		},
+		{
+			name:    "zfs volblocksize not a power of two",
+			casType: util.ZFSCasType,
+			scName:  "fast",
+			answers: []string{"Retain", "fastpool", "ext4", "off", "on", "12k"},
+			wantErr: true,
+		},
+		{
+			name:    "zfs pool not found",
+			casType: util.ZFSCasType,
+			scName:  "fast",
+			answers: []string{"Retain", "slowpool"},
+			wantErr: true,
+		},
+		{
+			name:        "lvm volume group",
+			answers:     []string{util.LVMCasType, "lvm", "Delete", "volgroup", "lvmvg", "yes", "no"},
+			wantName:    "lvm",
+			provisioner: util.LocalPVLVMCSIDriver,
+			params:      map[string]string{"volgroup": "lvmvg", "thinProvision": "yes", "shared": "no"},
+			topology:    hostnames("node1"),
+		},
+		{
+			name:        "lvm pattern matching the volume groups of two nodes",
+			casType:     util.LVMCasType,
+			scName:      "lvm",
+			answers:     []string{"Delete", "vgpattern", "^lvmvg", "no", "yes"},
+			provisioner: util.LocalPVLVMCSIDriver,
+			params:      map[string]string{"vgpattern": "^lvmvg", "thinProvision": "no", "shared": "yes"},
+			topology:    hostnames("node1", "node2"),
+		},
+		{
+			name:    "lvm pattern matching no volume group",
+			casType: util.LVMCasType,
+			scName:  "lvm",
+			answers: []string{"Delete", "vgpattern", "^ssd"},
+			wantErr: true,
+		},
+		{
+			name:        "hostpath",
+			casType:     util.LocalPvHostpathCasType,
+			scName:      "hostpath",
+			answers:     []string{"Delete", "/mnt/openebs"},
+			provisioner: util.LocalPVHostpathProvisioner,
+		},
+		{
+			name:    "hostpath relative base path",
+			casType: util.LocalPvHostpathCasType,
+			scName:  "hostpath",
+			answers: []string{"Delete", "openebs"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid name",
+			casType: util.LocalPvHostpathCasType,
+			scName:  "Not_Valid",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported cas-type",
+			casType: util.MayastorCasType,
+			scName:  "mayastor",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &scriptedPrompter{answers: tt.answers}
+			got, err := newStorageClass(k, p, tt.casType, tt.scName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newStorageClass() error = %v, wantErr %v, prompts %v", err, tt.wantErr, p.labels)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(p.answers) != 0 {
+				t.Errorf("newStorageClass() didn't ask for %v", p.answers)
+			}
+			wantName := tt.wantName
+			if wantName == "" {
+				wantName = tt.scName
+			}
+			if got.Name != wantName || got.Provisioner != tt.provisioner {
+				t.Errorf("newStorageClass() = %s of %s, want %s of %s", got.Name, got.Provisioner, wantName, tt.provisioner)
+			}
+			if len(tt.params) > 0 && !reflect.DeepEqual(got.Parameters, tt.params) {
+				t.Errorf("newStorageClass() parameters = %v, want %v", got.Parameters, tt.params)
+			}
+			if !reflect.DeepEqual(got.AllowedTopologies, tt.topology) {
+				t.Errorf("newStorageClass() allowedTopologies = %v, want %v", got.AllowedTopologies, tt.topology)
+			}
+			if *got.VolumeBindingMode != storagev1.VolumeBindingWaitForFirstConsumer {
+				t.Errorf("newStorageClass() volumeBindingMode = %v", *got.VolumeBindingMode)
+			}
+		})
+	}
+}
+
+func TestPrintStorageClass(t *testing.T) {
+	k := &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset()}
+	sc, err := newStorageClass(k, &scriptedPrompter{answers: []string{"Retain", ""}}, util.LocalPvHostpathCasType, "openebs-hostpath")
+	if err != nil {
+		t.Fatalf("newStorageClass() error = %v", err)
+	}
+	var out bytes.Buffer
+	if err = printStorageClass(&out, sc, util.YAMLOutput); err != nil {
+		t.Fatalf("printStorageClass() error = %v", err)
+	}
+	for _, want := range []string{
+		"apiVersion: storage.k8s.io/v1\n",
+		"kind: StorageClass\n",
+		"  name: openebs-hostpath\n",
+		"provisioner: openebs.io/local\n",
+		"reclaimPolicy: Retain\n",
+		"volumeBindingMode: WaitForFirstConsumer\n",
+		"openebs.io/cas-type: local\n",
+		"value: /var/openebs/local",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("printStorageClass() = %s, want it to contain %q", out.String(), want)
+		}
+	}
+}
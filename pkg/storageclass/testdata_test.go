@@ -0,0 +1,101 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storageclass
+
+import (
+	"fmt"
+
+	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// scriptedPrompter answers the prompts in order, an answer which isn't one
+// of the items or which isn't valid is an error, "" picks the default input
+type scriptedPrompter struct {
+	answers []string
+	// labels are the labels of the prompts asked
+	labels []string
+}
+
+func (s *scriptedPrompter) next(label string) (string, error) {
+	s.labels = append(s.labels, label)
+	if len(s.answers) == 0 {
+		return "", fmt.Errorf("no answer for %s", label)
+	}
+	answer := s.answers[0]
+	s.answers = s.answers[1:]
+	return answer, nil
+}
+
+func (s *scriptedPrompter) Select(label string, items []string) (string, error) {
+	answer, err := s.next(label)
+	if err != nil {
+		return "", err
+	}
+	for _, item := range items {
+		if item == answer {
+			return answer, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not one of %v", answer, items)
+}
+
+func (s *scriptedPrompter) Input(label, def string, validate func(string) error) (string, error) {
+	answer, err := s.next(label)
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		answer = def
+	}
+	if validate != nil {
+		if err := validate(answer); err != nil {
+			return "", err
+		}
+	}
+	return answer, nil
+}
+
+func newNode(name, hostname string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/hostname": hostname}}}
+}
+
+var (
+	node1 = newNode("node1", "node1")
+	node2 = newNode("node2", "node2")
+	// node3 has a hostname different from its name
+	node3 = newNode("node3", "node3.example.com")
+
+	zfsNode1 = &zfs.ZFSNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+		Pools:      []zfs.Pool{{Name: "zfspv"}, {Name: "fastpool"}},
+	}
+	zfsNode3 = &zfs.ZFSNode{
+		ObjectMeta: metav1.ObjectMeta{Name: "node3", Namespace: "openebs"},
+		Pools:      []zfs.Pool{{Name: "zfspv"}},
+	}
+
+	lvmNode1 = &lvm.LVMNode{
+		ObjectMeta:   metav1.ObjectMeta{Name: "node1", Namespace: "openebs"},
+		VolumeGroups: []lvm.VolumeGroup{{Name: "lvmvg"}},
+	}
+	lvmNode2 = &lvm.LVMNode{
+		ObjectMeta:   metav1.ObjectMeta{Name: "node2", Namespace: "openebs"},
+		VolumeGroups: []lvm.VolumeGroup{{Name: "lvmvg-ssd"}, {Name: "data"}},
+	}
+)
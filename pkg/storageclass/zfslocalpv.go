@@ -0,0 +1,205 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package storageclass
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// ZFSFsTypes are the filesystems of the ZFS-LocalPV volumes, zfs creates
+// datasets & the others create zvols
+var ZFSFsTypes = []string{"zfs", "ext4", "xfs", "btrfs"}
+
+// zfsParameters asks for the pool, the filesystem & the dataset properties
+// of a ZFS-LocalPV StorageClass, only the nodes having the pool are allowed
+func zfsParameters(k *client.K8sClient, p prompter, sc *storagev1.StorageClass) error {
+	nodes, _, err := k.GetZFSNodes(nil, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return err
+	}
+	poolNodes := make(map[string][]string)
+	for _, node := range nodes.Items {
+		for _, pool := range node.Pools {
+			poolNodes[pool.Name] = append(poolNodes[pool.Name], node.Name)
+		}
+	}
+	if len(poolNodes) == 0 {
+		return fmt.Errorf("no zfs pools found in the cluster")
+	}
+	pool, err := p.Select(util.ZFSPoolNameParam, sortedKeys(poolNodes))
+	if err != nil {
+		return err
+	}
+	fsType, err := p.Select(util.ZFSFsTypeParam, ZFSFsTypes)
+	if err != nil {
+		return err
+	}
+	compression, err := p.Select(util.ZFSCompressionParam, []string{"off", "on", "lz4", "gzip", "zstd", "zle", "lzjb"})
+	if err != nil {
+		return err
+	}
+	dedup, err := p.Select(util.ZFSDedupParam, []string{"off", "on"})
+	if err != nil {
+		return err
+	}
+	params := map[string]string{
+		util.ZFSPoolNameParam:    pool,
+		util.ZFSFsTypeParam:      fsType,
+		util.ZFSCompressionParam: compression,
+		util.ZFSDedupParam:       dedup,
+	}
+	// the recordsize is of the datasets & the volblocksize is of the zvols
+	if fsType == "zfs" {
+		params[util.ZFSRecordSizeParam], err = p.Input(util.ZFSRecordSizeParam, "128k", validateBlockSize(512, 1<<20))
+	} else {
+		params[util.ZFSVolBlockSizeParam], err = p.Input(util.ZFSVolBlockSizeParam, "8k", validateBlockSize(512, 128<<10))
+	}
+	if err != nil {
+		return err
+	}
+	if sc.AllowedTopologies, err = hostnameTopology(k, poolNodes[pool]); err != nil {
+		return err
+	}
+	expansion := true
+	sc.Provisioner = util.ZFSCSIDriver
+	sc.Parameters = params
+	sc.AllowVolumeExpansion = &expansion
+	return nil
+}
+
+var (
+	// zfsCompressionRegex matches the compression values of the ZFS datasets & zvols
+	zfsCompressionRegex = regexp.MustCompile(`^(on|off|lz4|lzjb|zle|gzip(-[1-9])?|zstd(-fast)?(-[0-9]+)?)$`)
+	// zfsDedupRegex matches the dedup values of the ZFS datasets & zvols
+	zfsDedupRegex = regexp.MustCompile(`^(on|off|verify|(sha256|sha512|skein|edonr)(,verify)?)$`)
+)
+
+// validateZFS checks the filesystem & the dataset properties of a ZFS-LocalPV
+// StorageClass & that its pool exists on every allowed node
+func validateZFS(k *client.K8sClient, sc *storagev1.StorageClass, allowed []string) []util.Cause {
+	var problems []util.Cause
+	ref := "storageclass/" + sc.Name
+	add := func(severity, format string, args ...interface{}) {
+		problems = append(problems, util.Cause{Severity: severity, Resource: ref, Message: fmt.Sprintf(format, args...)})
+	}
+	// 1. Check the filesystem & the properties of the datasets & zvols
+	fsType := sc.Parameters[util.ZFSFsTypeParam]
+	if fsType != "" && !contains(ZFSFsTypes, fsType) {
+		add(util.SeverityError, "unsupported %s %s, use one of %s", util.ZFSFsTypeParam, fsType, strings.Join(ZFSFsTypes, ", "))
+	}
+	if size, ok := sc.Parameters[util.ZFSRecordSizeParam]; ok {
+		if fsType != "zfs" {
+			add(util.SeverityWarning, "%s is only used by the zfs datasets, fstype %s creates zvols, use %s", util.ZFSRecordSizeParam, orDefault(fsType), util.ZFSVolBlockSizeParam)
+		} else if err := validateBlockSize(512, 1<<20)(size); err != nil {
+			add(util.SeverityError, "invalid %s: %v", util.ZFSRecordSizeParam, err)
+		}
+	}
+	if size, ok := sc.Parameters[util.ZFSVolBlockSizeParam]; ok {
+		if fsType == "zfs" {
+			add(util.SeverityWarning, "%s is only used by the zvols, fstype zfs creates datasets, use %s", util.ZFSVolBlockSizeParam, util.ZFSRecordSizeParam)
+		} else if err := validateBlockSize(512, 128<<10)(size); err != nil {
+			add(util.SeverityError, "invalid %s: %v", util.ZFSVolBlockSizeParam, err)
+		}
+	}
+	if value, ok := sc.Parameters[util.ZFSCompressionParam]; ok && !zfsCompressionRegex.MatchString(value) {
+		add(util.SeverityError, "invalid %s %s", util.ZFSCompressionParam, value)
+	}
+	if value, ok := sc.Parameters[util.ZFSDedupParam]; ok && !zfsDedupRegex.MatchString(value) {
+		add(util.SeverityError, "invalid %s %s", util.ZFSDedupParam, value)
+	}
+	problems = append(problems, checkYesNo(sc, util.ZFSThinProvisionParam)...)
+	// 2. Check the pool on the allowed nodes
+	pool := sc.Parameters[util.ZFSPoolNameParam]
+	if pool == "" {
+		add(util.SeverityError, "the storageclass has no %s parameter", util.ZFSPoolNameParam)
+		return problems
+	}
+	nodes, _, err := k.GetZFSNodes(nil, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		add(util.SeverityWarning, "failed to get the zfsnodes, the pools of the nodes are not known: %v", err)
+		return problems
+	}
+	nodePools := make(map[string][]string)
+	for _, node := range nodes.Items {
+		for _, p := range node.Pools {
+			nodePools[node.Name] = append(nodePools[node.Name], p.Name)
+		}
+	}
+	return append(problems, checkNodes(sc, allowed, nodePools, func(p string) bool { return p == pool }, "pool "+pool)...)
+}
+
+// checkNodes checks that every allowed node has a pool or a volume group
+// matching, the nodes without any are skipped if the StorageClass has no
+// allowed topologies as the engine may not run on them
+func checkNodes(sc *storagev1.StorageClass, allowed []string, nodePools map[string][]string, matches func(string) bool, what string) []util.Cause {
+	restricted := len(sc.AllowedTopologies) > 0
+	var missing []string
+	found := false
+	for _, node := range allowed {
+		pools, ok := nodePools[node]
+		if !ok && !restricted {
+			continue
+		}
+		has := false
+		for _, p := range pools {
+			if matches(p) {
+				has = true
+				break
+			}
+		}
+		if has {
+			found = true
+		} else {
+			missing = append(missing, node)
+		}
+	}
+	ref := "storageclass/" + sc.Name
+	switch {
+	case !found:
+		return []util.Cause{{Severity: util.SeverityError, Resource: ref, Message: fmt.Sprintf("%s is not found on any of the allowed nodes", what)}}
+	case len(missing) > 0 && restricted:
+		return []util.Cause{{Severity: util.SeverityError, Resource: ref,
+			Message: fmt.Sprintf("%s is missing on the allowed nodes %s", what, strings.Join(missing, ", "))}}
+	case len(missing) > 0:
+		return []util.Cause{{Severity: util.SeverityWarning, Resource: ref,
+			Message: fmt.Sprintf("%s is missing on the nodes %s, the volumes of the pods scheduled on them fail, restrict the allowedTopologies to the nodes having it", what, strings.Join(missing, ", "))}}
+	}
+	return nil
+}
+
+// contains returns true if the value is one of the items
+func contains(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// orDefault returns "default" for an empty parameter
+func orDefault(value string) string {
+	if value == "" {
+		return "default"
+	}
+	return value
+}
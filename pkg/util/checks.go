@@ -17,7 +17,11 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // CheckForVol is used to check if the we can get the volume, if no volume attachment
@@ -44,3 +48,21 @@ func AccessModeToString(accessModeArray []corev1.PersistentVolumeAccessMode) str
 	}
 	return accessModes
 }
+
+// CheckSelectors returns an error if the label or field selector can't be
+// parsed or if they are used along with the names of the resources
+func CheckSelectors(names []string, labelSelector, fieldSelector string) error {
+	if labelSelector == "" && fieldSelector == "" {
+		return nil
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("name cannot be provided when a selector is specified")
+	}
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return fmt.Errorf("invalid label selector %s: %v", labelSelector, err)
+	}
+	if _, err := fields.ParseSelector(fieldSelector); err != nil {
+		return fmt.Errorf("invalid field selector %s: %v", fieldSelector, err)
+	}
+	return nil
+}
@@ -50,3 +50,27 @@ func TestAccessModeToString(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckSelectors(t *testing.T) {
+	tests := []struct {
+		name          string
+		names         []string
+		labelSelector string
+		fieldSelector string
+		wantErr       bool
+	}{
+		{"no selectors with names", []string{"pvc-1"}, "", "", false},
+		{"label selector", nil, "app=db,tier!=web", "", false},
+		{"field selector", nil, "", "metadata.name=pvc-1", false},
+		{"selector with names", []string{"pvc-1"}, "app=db", "", true},
+		{"invalid label selector", nil, "app==db=", "", true},
+		{"invalid field selector", nil, "", "metadata.name", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckSelectors(tt.names, tt.labelSelector, tt.fieldSelector); (err != nil) != tt.wantErr {
+				t.Errorf("CheckSelectors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
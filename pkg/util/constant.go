@@ -31,10 +31,20 @@ const (
 	LVMCasType = "localpv-lvm"
 	// LocalPvHostpathCasType cas type name
 	LocalPvHostpathCasType = "localpv-hostpath"
+	// LocalPvDeviceCasType cas type name of the device volumes of the
+	// dynamic-localpv-provisioner
+	LocalPvDeviceCasType = "localpv-device"
+	// MayastorCasType cas type name of the replicated engine
+	MayastorCasType = "mayastor"
 	// LocalHostpathCasLabel cas-type label in dynamic-localpv-provisioner
 	LocalHostpathCasLabel = "local-hostpath"
+	// LocalDeviceCasLabel cas-type label of the device volumes in dynamic-localpv-provisioner
+	LocalDeviceCasLabel = "local-device"
 	// StorageKey key present in pvc status.capacity
 	StorageKey = "storage"
+	// PersistentVolumeKey is the label of the ZFSSnapshots & LVMSnapshots
+	// having the name of their source volume
+	PersistentVolumeKey = "openebs.io/persistent-volume"
 	// NotAvailable shows something is missing, could be a component,
 	// unknown version, or some other unknowns
 	NotAvailable = "N/A"
@@ -46,14 +56,69 @@ const (
 	// LocalPVLVMCSIDriver is the name of the LVM LocalPV CSI driver
 	// NOTE: This might also mean local-hostpath, local-device or zfs-localpv later.
 	LocalPVLVMCSIDriver = "local.csi.openebs.io"
+	// MayastorCSIDriver is the name of the Mayastor CSI driver
+	MayastorCSIDriver = "io.openebs.csi-mayastor"
+	// LocalPVHostpathProvisioner is the name of the dynamic-localpv-provisioner
+	LocalPVHostpathProvisioner = "openebs.io/local"
+	// SelectedNodeKey is the annotation of a PVC having the node selected by
+	// the scheduler for a WaitForFirstConsumer StorageClass
+	SelectedNodeKey = "volume.kubernetes.io/selected-node"
+	// DefaultStorageClassKey is the annotation of the default StorageClass
+	DefaultStorageClassKey = "storageclass.kubernetes.io/is-default-class"
+)
+
+// Constant StorageClass parameters of the CSI drivers
+const (
+	// ZFSPoolNameParam is the ZFS pool of the volumes of a ZFS-LocalPV StorageClass
+	ZFSPoolNameParam = "poolname"
+	// LVMVolGroupParam is the volume group of the volumes of a LVM-LocalPV StorageClass
+	LVMVolGroupParam = "volgroup"
+	// LVMVgPatternParam is the regex of the volume groups of a LVM-LocalPV StorageClass
+	LVMVgPatternParam = "vgpattern"
+	// LVMThinProvisionParam is set to "yes" for thin provisioned LVM-LocalPV volumes
+	LVMThinProvisionParam = "thinProvision"
+	// ZFSThinProvisionParam is set to "yes" for thin provisioned ZFS-LocalPV volumes
+	ZFSThinProvisionParam = "thinprovision"
+	// ZFSFsTypeParam is the filesystem of the volumes of a ZFS-LocalPV StorageClass,
+	// zfs creates datasets & the others create zvols formatted with the filesystem
+	ZFSFsTypeParam = "fstype"
+	// ZFSCompressionParam is the compression of the ZFS datasets & zvols
+	ZFSCompressionParam = "compression"
+	// ZFSDedupParam turns deduplication of the ZFS datasets & zvols on or off
+	ZFSDedupParam = "dedup"
+	// ZFSRecordSizeParam is the recordsize of the ZFS datasets
+	ZFSRecordSizeParam = "recordsize"
+	// ZFSVolBlockSizeParam is the volblocksize of the ZFS zvols
+	ZFSVolBlockSizeParam = "volblocksize"
+	// LVMFsTypeParam is the filesystem of the volumes of a LVM-LocalPV StorageClass
+	LVMFsTypeParam = "fsType"
+	// LVMSharedParam is set to "yes" for LVM-LocalPV volumes shared by the pods of a node
+	LVMSharedParam = "shared"
+	// HostpathConfigAnnotation is the annotation of a hostpath StorageClass
+	// having the config of the dynamic-localpv-provisioner
+	HostpathConfigAnnotation = "cas.openebs.io/config"
+	// HostnameTopologyKey is the node label used in the allowed topologies of
+	// the StorageClasses of the local engines
+	HostnameTopologyKey = "kubernetes.io/hostname"
 )
 
 // Constant CSI component-name label values
 const (
+	// ComponentNameKey is the label of the component pods having their component name
+	ComponentNameKey = "openebs.io/component-name"
+	// MayastorComponentNameKey is the label of the Mayastor component pods
+	// having their component name, they don't have the openebs.io/component-name label
+	MayastorComponentNameKey = "app"
+	// MayastorRESTComponent is the component name of the Mayastor REST API pods & service
+	MayastorRESTComponent = "api-rest"
 	// LVMLocalPVcsiControllerLabelValue is the label value of CSI controller STS & pod
 	LVMLocalPVcsiControllerLabelValue = "openebs-lvm-controller"
 	// ZFSLocalPVcsiControllerLabelValue is the label value of CSI controller STS & pod
 	ZFSLocalPVcsiControllerLabelValue = "openebs-zfs-controller"
+	// LVMLocalPVcsiNodeLabelValue is the label value of CSI node daemonset & pod
+	LVMLocalPVcsiNodeLabelValue = "openebs-lvm-node"
+	// ZFSLocalPVcsiNodeLabelValue is the label value of CSI node daemonset & pod
+	ZFSLocalPVcsiNodeLabelValue = "openebs-zfs-node"
 )
 
 const (
@@ -63,6 +128,8 @@ const (
 	ZFSComponentNames = "openebs-zfs-controller,openebs-zfs-node"
 	// HostpathComponentNames for the hostpath control plane components
 	HostpathComponentNames = "openebs-localpv-provisioner"
+	// MayastorComponentNames for the mayastor control plane & data plane components
+	MayastorComponentNames = "agent-core,api-rest,csi-controller,csi-node,io-engine"
 )
 
 var (
@@ -82,19 +149,32 @@ var (
 	ProvsionerAndCasTypeMap = map[string]string{
 		LocalPVLVMCSIDriver: LVMCasType,
 		ZFSCSIDriver:        ZFSCasType,
+		MayastorCSIDriver:   MayastorCasType,
 	}
 	// CasTypeToCSIProvisionerMap stores the provisioner of corresponding cas-types
 	CasTypeToCSIProvisionerMap = map[string]string{
-		LVMCasType: LocalPVLVMCSIDriver,
-		ZFSCasType: ZFSCSIDriver,
+		LVMCasType:      LocalPVLVMCSIDriver,
+		ZFSCasType:      ZFSCSIDriver,
+		MayastorCasType: MayastorCSIDriver,
 	}
 
+	// CasTypeToCSINodeComponentMap stores the component-name of the CSI node pods of the cas-types
+	CasTypeToCSINodeComponentMap = map[string]string{
+		ZFSCasType: ZFSLocalPVcsiNodeLabelValue,
+		LVMCasType: LVMLocalPVcsiNodeLabelValue,
+	}
 	// CasTypeToComponentNamesMap stores the names of the control-plane components of each cas-types.
 	// To show statuses of new CasTypes, please update this map.
 	CasTypeToComponentNamesMap = map[string]string{
 		LocalPvHostpathCasType: HostpathComponentNames,
 		ZFSCasType:             ZFSComponentNames,
 		LVMCasType:             LVMComponentNames,
+		MayastorCasType:        MayastorComponentNames,
+	}
+	// CasTypeToComponentLabelKeyMap stores the label having the component names
+	// of the cas-types whose pods don't have the openebs.io/component-name label
+	CasTypeToComponentLabelKeyMap = map[string]string{
+		MayastorCasType: MayastorComponentNameKey,
 	}
 	// VolumeListColumnDefinations stores the Table headers for Volume Details
 	VolumeListColumnDefinations = []metav1.TableColumnDefinition{
@@ -108,12 +188,173 @@ var (
 		{Name: "Access Mode", Type: "string"},
 		{Name: "Attached Node", Type: "string"},
 	}
+	// VolumeListWideColumnDefinitions stores the Table headers for Volume Details
+	// printed with --output=wide
+	VolumeListWideColumnDefinitions = append(append([]metav1.TableColumnDefinition{}, VolumeListColumnDefinations...),
+		metav1.TableColumnDefinition{Name: "Cas Type", Type: "string"},
+		metav1.TableColumnDefinition{Name: "PVC", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Usage", Type: "string"},
+	)
+	// VolumeUsageColumnDefinitions stores the table headers of the used space of the volumes
+	VolumeUsageColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Namespace", Type: "string"},
+		{Name: "PVC", Type: "string"},
+		{Name: "Volume", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Node", Type: "string"},
+		{Name: "Capacity", Type: "string"},
+		{Name: "Used", Type: "string"},
+		{Name: "Available", Type: "string"},
+		{Name: "Used%", Type: "string"},
+	}
 	// LVMvolgroupListColumnDefinitions stores the table headers for listing lvm vg-group when displayed as tree
 	LVMvolgroupListColumnDefinitions = []metav1.TableColumnDefinition{
 		{Name: "Name", Type: "string"},
 		{Name: "FreeSize", Type: "string"},
 		{Name: "TotalSize", Type: "string"},
 	}
+	// EventColumnDefinitions stores the table headers of the events of a resource
+	EventColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Type", Type: "string"},
+		{Name: "Reason", Type: "string"},
+		{Name: "Object", Type: "string"},
+		{Name: "Count", Type: "string"},
+		{Name: "Last Seen", Type: "string"},
+		{Name: "Message", Type: "string"},
+	}
+	// CauseColumnDefinitions stores the table headers of the ranked causes of a stuck PVC
+	CauseColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Rank", Type: "string"},
+		{Name: "Severity", Type: "string"},
+		{Name: "Resource", Type: "string"},
+		{Name: "Cause", Type: "string"},
+	}
+	// StorageClassProblemColumnDefinitions stores the table headers of the
+	// problems found in a StorageClass
+	StorageClassProblemColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Severity", Type: "string"},
+		{Name: "Resource", Type: "string"},
+		{Name: "Problem", Type: "string"},
+	}
+	// StorageClassPVColumnDefinitions stores the table headers of the PVs of a StorageClass
+	StorageClassPVColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "PVC", Type: "string"},
+		{Name: "Phase", Type: "string"},
+		{Name: "Capacity", Type: "string"},
+	}
+	// StorageClassPVCColumnDefinitions stores the table headers of the PVCs of a StorageClass
+	StorageClassPVCColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Namespace", Type: "string"},
+		{Name: "Name", Type: "string"},
+		{Name: "Phase", Type: "string"},
+		{Name: "Volume", Type: "string"},
+		{Name: "Request", Type: "string"},
+	}
+	// CheckColumnDefinitions stores the table headers of the health checks
+	CheckColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Check", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Status", Type: "string"},
+		{Name: "Message", Type: "string"},
+	}
+	// NodeListColumnDefinitions stores the table headers of the nodes & the
+	// OpenEBS resources on them
+	NodeListColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Status", Type: "string"},
+		{Name: "Agents", Type: "string"},
+		{Name: "Pools", Type: "string"},
+		{Name: "Volumes", Type: "string"},
+	}
+	// NodeAgentColumnDefinitions stores the table headers of the CSI node pods of a node
+	NodeAgentColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Pod", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Ready", Type: "string"},
+		{Name: "Status", Type: "string"},
+	}
+	// NodePoolColumnDefinitions stores the table headers of the pools & volume groups of a node
+	NodePoolColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Free", Type: "string"},
+		{Name: "Total", Type: "string"},
+		{Name: "Used%", Type: "string"},
+	}
+	// NodeVolumeColumnDefinitions stores the table headers of the volumes of a node
+	NodeVolumeColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Pool", Type: "string"},
+		{Name: "Capacity", Type: "string"},
+		{Name: "Status", Type: "string"},
+		{Name: "PVC", Type: "string"},
+		{Name: "Mounted By", Type: "string"},
+	}
+	// DrainWorkloadColumnDefinitions stores the table headers of the workloads
+	// using the local volumes of a node to be drained
+	DrainWorkloadColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Namespace", Type: "string"},
+		{Name: "Workload", Type: "string"},
+		{Name: "Pods", Type: "string"},
+		{Name: "Volumes", Type: "string"},
+		{Name: "Ready Elsewhere", Type: "string"},
+		{Name: "Impact", Type: "string"},
+	}
+	// OrphanColumnDefinitions stores the table headers of the leftover resources
+	OrphanColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Kind", Type: "string"},
+		{Name: "Name", Type: "string"},
+		{Name: "Namespace", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Node", Type: "string"},
+		{Name: "Size", Type: "string"},
+		{Name: "Reason", Type: "string"},
+	}
+	// CapacityListColumnDefinitions stores the table headers of the capacity report of
+	// the nodes & their pools or volume groups when displayed as tree
+	CapacityListColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Total", Type: "string"},
+		{Name: "Free", Type: "string"},
+		{Name: "Used", Type: "string"},
+		{Name: "Provisioned", Type: "string"},
+		{Name: "Overcommit", Type: "string"},
+		{Name: "Headroom", Type: "string"},
+		{Name: "Flags", Type: "string"},
+	}
+	// MayastorReplicaColumnDefinitions stores the table headers of the replicas of a mayastor volume
+	MayastorReplicaColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Node", Type: "string"},
+		{Name: "Pool", Type: "string"},
+		{Name: "State", Type: "string"},
+		{Name: "Child Status", Type: "string"},
+		{Name: "Rebuild Progress", Type: "string"},
+	}
+	// DiskPoolListColumnDefinitions stores the table headers for listing the mayastor DiskPools
+	DiskPoolListColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Node", Type: "string"},
+		{Name: "State", Type: "string"},
+		{Name: "Pool Status", Type: "string"},
+		{Name: "Capacity", Type: "string"},
+		{Name: "Used", Type: "string"},
+		{Name: "Available", Type: "string"},
+	}
+	// BlockDeviceListColumnDefinitions stores the table headers for listing the NDM BlockDevices
+	BlockDeviceListColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Node", Type: "string"},
+		{Name: "Path", Type: "string"},
+		{Name: "Size", Type: "string"},
+		{Name: "Claim State", Type: "string"},
+		{Name: "Status", Type: "string"},
+		{Name: "FS Type", Type: "string"},
+		{Name: "Mount Point", Type: "string"},
+	}
 	// ZFSPoolListColumnDefinitions stores the table headers for listing zfs pools when displayed as tree
 	ZFSPoolListColumnDefinitions = []metav1.TableColumnDefinition{
 		{Name: "Name", Type: "string"},
@@ -124,6 +365,44 @@ var (
 		{Name: "Component", Type: "string"},
 		{Name: "Version", Type: "string"},
 	}
+	// SnapshotListColumnDefinitions stores the Table headers for Snapshot Details
+	SnapshotListColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Volume", Type: "string"},
+		{Name: "Cas Type", Type: "string"},
+		{Name: "Node", Type: "string"},
+		{Name: "Pool", Type: "string"},
+		{Name: "Size", Type: "string"},
+		{Name: "State", Type: "string"},
+		{Name: "VolumeSnapshot", Type: "string"},
+		{Name: "VolumeSnapshotContent", Type: "string"},
+	}
+	// SnapshotListWideColumnDefinitions stores the Table headers for Snapshot
+	// Details printed with --output=wide
+	SnapshotListWideColumnDefinitions = append(append([]metav1.TableColumnDefinition{}, SnapshotListColumnDefinitions...),
+		metav1.TableColumnDefinition{Name: "PVC", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Ready To Use", Type: "string"},
+		metav1.TableColumnDefinition{Name: "Snapshot Class", Type: "string"},
+	)
+	// BackupListColumnDefinitions stores the Table headers for ZFSBackup Details
+	BackupListColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Volume", Type: "string"},
+		{Name: "Node", Type: "string"},
+		{Name: "Snapshot", Type: "string"},
+		{Name: "Destination", Type: "string"},
+		{Name: "State", Type: "string"},
+		{Name: "Created", Type: "string"},
+	}
+	// RestoreListColumnDefinitions stores the Table headers for ZFSRestore Details
+	RestoreListColumnDefinitions = []metav1.TableColumnDefinition{
+		{Name: "Name", Type: "string"},
+		{Name: "Volume", Type: "string"},
+		{Name: "Node", Type: "string"},
+		{Name: "Source", Type: "string"},
+		{Name: "State", Type: "string"},
+		{Name: "Created", Type: "string"},
+	}
 	// ClusterInfoColumnDefinitions stores the Table headers for Cluster-Info details
 	ClusterInfoColumnDefinitions = []metav1.TableColumnDefinition{
 		{Name: "Cas-Type", Type: "string"},
@@ -132,4 +411,9 @@ var (
 		{Name: "Working", Type: "string"},
 		{Name: "Status", Type: "string"},
 	}
+	// ClusterInfoWideColumnDefinitions stores the Table headers for Cluster-Info
+	// details printed with --output=wide
+	ClusterInfoWideColumnDefinitions = append(append([]metav1.TableColumnDefinition{}, ClusterInfoColumnDefinitions...),
+		metav1.TableColumnDefinition{Name: "Components", Type: "string"},
+	)
 )
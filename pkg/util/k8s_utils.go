@@ -17,10 +17,15 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
 )
 
 // GetCasType from the v1pv and v1sc, this is a fallback checker method, it checks
@@ -66,6 +71,22 @@ func GetCasTypeFromPV(v1PV *corev1.PersistentVolume) string {
 	return Unknown
 }
 
+// GetNodeFromPV returns the node of the node affinity of a local PV, N/A if
+// the PV has no node affinity
+func GetNodeFromPV(v1PV *corev1.PersistentVolume) string {
+	if v1PV.Spec.NodeAffinity == nil || v1PV.Spec.NodeAffinity.Required == nil {
+		return NotAvailable
+	}
+	for _, term := range v1PV.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if len(expr.Values) > 0 {
+				return expr.Values[0]
+			}
+		}
+	}
+	return NotAvailable
+}
+
 // GetCasTypeFromSC by passing the storage class
 func GetCasTypeFromSC(v1SC *v1.StorageClass) string {
 	if v1SC != nil {
@@ -93,7 +114,124 @@ func GetReadyContainers(containers []corev1.ContainerStatus) string {
 	return strconv.Itoa(ready) + "/" + strconv.Itoa(total)
 }
 
+// IsPodReady returns true if the Ready condition of the pod is true
+func IsPodReady(pod corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // IsValidCasType to return true if the casType is supported
 func IsValidCasType(casType string) bool {
-	return casType == LVMCasType || casType == ZFSCasType
+	return casType == LVMCasType || casType == ZFSCasType || casType == MayastorCasType ||
+		casType == LocalPvHostpathCasType || casType == LocalPvDeviceCasType
+}
+
+// NormalizeCasType returns the cas-type of the cas-type label of a PV, the
+// dynamic-localpv-provisioner labels its PVs with local-hostpath & local-device
+func NormalizeCasType(casType string) string {
+	switch casType {
+	case LocalHostpathCasLabel:
+		return LocalPvHostpathCasType
+	case LocalDeviceCasLabel:
+		return LocalPvDeviceCasType
+	}
+	return casType
+}
+
+// ComponentLabelKey returns the label of the component pods of the cas-type
+// having their component name
+func ComponentLabelKey(casType string) string {
+	if key, ok := CasTypeToComponentLabelKeyMap[casType]; ok {
+		return key
+	}
+	return ComponentNameKey
+}
+
+// TopologyMatches returns true if the labels of a node match all the
+// expressions of any of the topology terms, no terms match every node
+func TopologyMatches(terms []corev1.TopologySelectorTerm, labels map[string]string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+	for _, term := range terms {
+		if termMatches(term, labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// termMatches returns true if the labels match all the expressions of the term
+func termMatches(term corev1.TopologySelectorTerm, labels map[string]string) bool {
+	for _, exp := range term.MatchLabelExpressions {
+		found := false
+		for _, v := range exp.Values {
+			if labels[exp.Key] == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// NewEvents returns the events sorted by the time they were last seen,
+// the latest event is the last one
+func NewEvents(events []corev1.Event) []Event {
+	sorted := append([]corev1.Event{}, events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return eventTime(sorted[i]).Before(eventTime(sorted[j]))
+	})
+	list := make([]Event, 0, len(sorted))
+	for _, e := range sorted {
+		lastSeen := NotAvailable
+		if t := eventTime(e); !t.IsZero() {
+			lastSeen = Duration(time.Since(t))
+		}
+		list = append(list, Event{
+			Type:     e.Type,
+			Reason:   e.Reason,
+			Object:   e.InvolvedObject.Kind + "/" + e.InvolvedObject.Name,
+			Count:    e.Count,
+			LastSeen: lastSeen,
+			Message:  e.Message,
+		})
+	}
+	return list
+}
+
+// EventRows returns the table rows of the events
+func EventRows(events []Event) []metav1.TableRow {
+	var rows []metav1.TableRow
+	for _, e := range events {
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{e.Type, e.Reason, e.Object, e.Count, e.LastSeen, e.Message}})
+	}
+	return rows
+}
+
+// PrintEvents prints the Events section of a describe output, like the one of
+// kubectl describe, the events are sorted by the time they were last seen
+func PrintEvents(events []corev1.Event) {
+	fmt.Printf("\nEvents :\n--------\n")
+	if len(events) == 0 {
+		fmt.Println("<none>")
+		return
+	}
+	TablePrinter(EventColumnDefinitions, EventRows(NewEvents(events)), printers.PrintOptions{})
+}
+
+// eventTime returns the time an event was last seen, the newer events only
+// have the event time
+func eventTime(e corev1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	return e.EventTime.Time
 }
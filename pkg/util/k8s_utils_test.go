@@ -18,8 +18,10 @@ package util
 
 import (
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetReadyContainers(t *testing.T) {
@@ -51,6 +53,26 @@ func TestGetReadyContainers(t *testing.T) {
 	}
 }
 
+func TestIsPodReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []corev1.PodCondition
+		want       bool
+	}{
+		{"ready", []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}, {Type: corev1.PodReady, Status: corev1.ConditionTrue}}, true},
+		{"not ready", []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}}, false},
+		{"no conditions", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := corev1.Pod{Status: corev1.PodStatus{Conditions: tt.conditions}}
+			if got := IsPodReady(pod); got != tt.want {
+				t.Errorf("IsPodReady() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsValidCasType(t *testing.T) {
 	type args struct {
 		casType string
@@ -65,6 +87,16 @@ func TestIsValidCasType(t *testing.T) {
 			args{casType: LVMCasType},
 			true,
 		},
+		{
+			"Valid replicated Cas Name",
+			args{casType: MayastorCasType},
+			true,
+		},
+		{
+			"Valid device Cas Name",
+			args{casType: LocalPvDeviceCasType},
+			true,
+		},
 		{
 			"Invalid Cas Name",
 			args{casType: "some-invalid-cas"},
@@ -79,3 +111,124 @@ func TestIsValidCasType(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeCasType(t *testing.T) {
+	tests := []struct {
+		casType string
+		want    string
+	}{
+		{LocalHostpathCasLabel, LocalPvHostpathCasType},
+		{LocalDeviceCasLabel, LocalPvDeviceCasType},
+		{ZFSCasType, ZFSCasType},
+		{Unknown, Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.casType, func(t *testing.T) {
+			if got := NormalizeCasType(tt.casType); got != tt.want {
+				t.Errorf("NormalizeCasType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNodeFromPV(t *testing.T) {
+	affinity := func(exprs ...corev1.NodeSelectorRequirement) *corev1.VolumeNodeAffinity {
+		return &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: exprs}}}}
+	}
+	tests := []struct {
+		name     string
+		affinity *corev1.VolumeNodeAffinity
+		want     string
+	}{
+		{"no node affinity", nil, NotAvailable},
+		{"no match expressions", affinity(), NotAvailable},
+		{"hostname expression", affinity(corev1.NodeSelectorRequirement{Key: HostnameTopologyKey, Operator: corev1.NodeSelectorOpIn, Values: []string{"node1"}}), "node1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pv := &corev1.PersistentVolume{Spec: corev1.PersistentVolumeSpec{NodeAffinity: tt.affinity}}
+			if got := GetNodeFromPV(pv); got != tt.want {
+				t.Errorf("GetNodeFromPV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponentLabelKey(t *testing.T) {
+	tests := []struct {
+		casType string
+		want    string
+	}{
+		{ZFSCasType, ComponentNameKey},
+		{LocalPvHostpathCasType, ComponentNameKey},
+		{MayastorCasType, MayastorComponentNameKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.casType, func(t *testing.T) {
+			if got := ComponentLabelKey(tt.casType); got != tt.want {
+				t.Errorf("ComponentLabelKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopologyMatches(t *testing.T) {
+	hostname := func(nodes ...string) corev1.TopologySelectorTerm {
+		return corev1.TopologySelectorTerm{
+			MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{Key: "kubernetes.io/hostname", Values: nodes}},
+		}
+	}
+	labels := map[string]string{"kubernetes.io/hostname": "node1"}
+	tests := []struct {
+		name  string
+		terms []corev1.TopologySelectorTerm
+		want  bool
+	}{
+		{"no terms", nil, true},
+		{"matching term", []corev1.TopologySelectorTerm{hostname("node1", "node2")}, true},
+		{"any matching term", []corev1.TopologySelectorTerm{hostname("node2"), hostname("node1")}, true},
+		{"no matching term", []corev1.TopologySelectorTerm{hostname("node2")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TopologyMatches(tt.terms, labels); got != tt.want {
+				t.Errorf("TopologyMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEvents(t *testing.T) {
+	now := time.Now()
+	events := []corev1.Event{
+		{
+			InvolvedObject: corev1.ObjectReference{Kind: "PersistentVolumeClaim", Name: "data"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Provisioning",
+			LastTimestamp:  metav1.NewTime(now.Add(-time.Minute)),
+		},
+		{
+			InvolvedObject: corev1.ObjectReference{Kind: "PersistentVolumeClaim", Name: "data"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "ProvisioningFailed",
+			LastTimestamp:  metav1.NewTime(now.Add(-time.Hour)),
+		},
+		{
+			InvolvedObject: corev1.ObjectReference{Kind: "PersistentVolume", Name: "pvc-1"},
+			Reason:         "VolumeFailedDelete",
+		},
+	}
+	got := NewEvents(events)
+	want := []string{"VolumeFailedDelete", "ProvisioningFailed", "Provisioning"}
+	if len(got) != len(want) {
+		t.Fatalf("NewEvents() has %d events, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		if e.Reason != want[i] {
+			t.Errorf("NewEvents()[%d] = %s, want %s", i, e.Reason, want[i])
+		}
+	}
+	if got[0].LastSeen != NotAvailable || got[1].Object != "PersistentVolumeClaim/data" {
+		t.Errorf("NewEvents() = %+v", got)
+	}
+}
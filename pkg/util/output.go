@@ -0,0 +1,280 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// OutputAPIVersion is the version of the json & yaml output schema, it
+	// must be bumped on any incompatible change of the printed fields
+	OutputAPIVersion = "openebsctl.openebs.io/v1alpha1"
+	// JSONOutput prints the resources as a json List
+	JSONOutput = "json"
+	// YAMLOutput prints the resources as a yaml List
+	YAMLOutput = "yaml"
+	// NameOutput prints the resource/name of each resource
+	NameOutput = "name"
+	// WideOutput prints the table with additional columns
+	WideOutput = "wide"
+	// CustomColumnsOutput prints a table of the columns given as
+	// HEADER:.json.path pairs, i.e. -o custom-columns=NAME:.name,POOL:.zfs.poolName
+	CustomColumnsOutput = "custom-columns"
+	// JSONPathOutput prints the fields of the json List matched by the
+	// jsonpath expression, i.e. -o jsonpath='{.items[*].name}'
+	JSONPathOutput = "jsonpath"
+	// GoTemplateOutput executes the golang template on the json List,
+	// i.e. -o go-template='{{range .items}}{{.name}}{{"\n"}}{{end}}'
+	GoTemplateOutput = "go-template"
+	// noneValue is printed in custom-columns for a missing field
+	noneValue = "<none>"
+)
+
+// OutputFormats lists all the values accepted by the --output flag
+var OutputFormats = []string{JSONOutput, YAMLOutput, NameOutput, WideOutput}
+
+// TemplateOutputFormats lists the --output values which take an argument,
+// these are only supported for listings
+var TemplateOutputFormats = []string{CustomColumnsOutput, JSONPathOutput, GoTemplateOutput}
+
+// jsonRegexp matches the relaxed jsonpath expressions of custom-columns
+var jsonRegexp = regexp.MustCompile(`^\{\.?([^{}]+)\}$|^\.?([^{}]+)$`)
+
+// OutputList is the versioned envelope of the json & yaml output
+type OutputList struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Items      []interface{} `json:"items"`
+}
+
+// NamedResource is implemented by the items printed via --output=name
+type NamedResource interface {
+	// ResourceName returns the resource/name reference of the item
+	ResourceName() string
+}
+
+// IsValidOutputFormat returns true if the output format is supported, an
+// empty format means the default table or text output
+func IsValidOutputFormat(format string) bool {
+	if format == "" {
+		return true
+	}
+	for _, f := range OutputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStructuredOutput returns true if the output format doesn't print the
+// default table or text
+func IsStructuredOutput(format string) bool {
+	return format != "" && format != WideOutput
+}
+
+// IsTemplateOutput returns true if the output format is one of the
+// TemplateOutputFormats followed by its argument, i.e. jsonpath={.items}
+func IsTemplateOutput(format string) bool {
+	name, arg := splitTemplateOutput(format)
+	return name != "" && arg != ""
+}
+
+// splitTemplateOutput splits the template output format into its name &
+// argument, empty strings are returned for other formats
+func splitTemplateOutput(format string) (string, string) {
+	for _, f := range TemplateOutputFormats {
+		if strings.HasPrefix(format, f+"=") {
+			return f, strings.TrimPrefix(format, f+"=")
+		}
+	}
+	return "", ""
+}
+
+// CheckOutputFormat returns an error if the output format is not supported
+func CheckOutputFormat(format string) error {
+	if !IsValidOutputFormat(format) {
+		return fmt.Errorf("output format %s is not supported, allowed formats are: %s",
+			format, strings.Join(OutputFormats, "|"))
+	}
+	return nil
+}
+
+// CheckListOutputFormat returns an error if the output format is not
+// supported for listings, which also support the TemplateOutputFormats
+func CheckListOutputFormat(format string) error {
+	if IsValidOutputFormat(format) || IsTemplateOutput(format) {
+		return nil
+	}
+	return fmt.Errorf("output format %s is not supported, allowed formats are: %s|%s",
+		format, strings.Join(OutputFormats, "|"), strings.Join(TemplateOutputFormats, "=...|")+"=...")
+}
+
+// CheckWatchOutputFormat returns an error if the output format can't be
+// watched, only the rows of the tables are reprinted as they change
+func CheckWatchOutputFormat(format string) error {
+	if format != "" && format != WideOutput {
+		return fmt.Errorf("output format %s is not supported with --watch, allowed formats are: %s", format, WideOutput)
+	}
+	return nil
+}
+
+// PrintList prints the items in the json, yaml or name output format
+func PrintList(format string, kind string, items []interface{}) error {
+	return FprintList(os.Stdout, format, kind, items)
+}
+
+// FprintList writes the items in the json, yaml or name output format to w
+func FprintList(w io.Writer, format string, kind string, items []interface{}) error {
+	if items == nil {
+		// print an empty list instead of null
+		items = []interface{}{}
+	}
+	list := OutputList{APIVersion: OutputAPIVersion, Kind: kind, Items: items}
+	switch format {
+	case JSONOutput:
+		data, err := json.MarshalIndent(list, "", "    ")
+		if err != nil {
+			return fmt.Errorf("error printing json output: %v", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case YAMLOutput:
+		data, err := yaml.Marshal(list)
+		if err != nil {
+			return fmt.Errorf("error printing yaml output: %v", err)
+		}
+		_, err = fmt.Fprint(w, string(data))
+		return err
+	case NameOutput:
+		for _, item := range items {
+			if r, ok := item.(NamedResource); ok {
+				if _, err := fmt.Fprintln(w, r.ResourceName()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	default:
+		if IsTemplateOutput(format) {
+			return fprintTemplate(w, format, list)
+		}
+		return fmt.Errorf("output format %s is not supported", format)
+	}
+}
+
+// fprintTemplate writes the list in one of the TemplateOutputFormats to w,
+// the templates are evaluated against the json representation of the list
+func fprintTemplate(w io.Writer, format string, list OutputList) error {
+	data, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("error converting the output to json: %v", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err = json.Unmarshal(data, &obj.Object); err != nil {
+		return fmt.Errorf("error converting the output to json: %v", err)
+	}
+	name, arg := splitTemplateOutput(format)
+	switch name {
+	case GoTemplateOutput:
+		return TemplatePrinter(w, arg, obj)
+	case JSONPathOutput:
+		p, err := printers.NewJSONPathPrinter(arg)
+		if err != nil {
+			return fmt.Errorf("error parsing jsonpath %s: %v", arg, err)
+		}
+		p.AllowMissingKeys(true)
+		return p.PrintObj(obj, w)
+	default:
+		items, _ := obj.Object["items"].([]interface{})
+		return fprintCustomColumns(w, arg, items)
+	}
+}
+
+// fprintCustomColumns writes a table of the custom columns, given as
+// HEADER:.json.path pairs separated by commas, of every item to w
+func fprintCustomColumns(w io.Writer, spec string, items []interface{}) error {
+	var headers []string
+	var parsers []*jsonpath.JSONPath
+	for _, column := range strings.Split(spec, ",") {
+		parts := strings.SplitN(column, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("unexpected custom-columns spec: %s, expected <header>:<json-path-expr>", column)
+		}
+		expr, err := relaxedJSONPathExpression(parts[1])
+		if err != nil {
+			return err
+		}
+		p := jsonpath.New(parts[0]).AllowMissingKeys(true)
+		if err = p.Parse(expr); err != nil {
+			return fmt.Errorf("error parsing jsonpath %s: %v", parts[1], err)
+		}
+		headers = append(headers, parts[0])
+		parsers = append(parsers, p)
+	}
+	tw := printers.GetNewTabWriter(w)
+	_, _ = fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, item := range items {
+		cells := make([]string, 0, len(parsers))
+		for _, p := range parsers {
+			results, err := p.FindResults(item)
+			if err != nil {
+				return err
+			}
+			var values []string
+			for _, result := range results {
+				for _, v := range result {
+					if v.IsValid() && v.CanInterface() && v.Interface() != nil {
+						values = append(values, fmt.Sprint(v.Interface()))
+					}
+				}
+			}
+			if len(values) == 0 {
+				cells = append(cells, noneValue)
+			} else {
+				cells = append(cells, strings.Join(values, ","))
+			}
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// relaxedJSONPathExpression converts the name1.name2, .name1.name2,
+// {name1.name2} or {.name1.name2} expressions to {.name1.name2}
+func relaxedJSONPathExpression(expr string) (string, error) {
+	submatches := jsonRegexp.FindStringSubmatch(expr)
+	if submatches == nil {
+		return "", fmt.Errorf("unexpected path string %s, expected a 'name1.name2' or '.name1.name2' or '{name1.name2}' or '{.name1.name2}'", expr)
+	}
+	fieldSpec := submatches[1]
+	if fieldSpec == "" {
+		fieldSpec = submatches[2]
+	}
+	return fmt.Sprintf("{.%s}", fieldSpec), nil
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIsValidOutputFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   bool
+	}{
+		{"default output", "", true},
+		{"json output", "json", true},
+		{"yaml output", "yaml", true},
+		{"name output", "name", true},
+		{"wide output", "wide", true},
+		{"unknown output", "xml", false},
+		{"upper case output", "JSON", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidOutputFormat(tt.format); got != tt.want {
+				t.Errorf("IsValidOutputFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsStructuredOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   bool
+	}{
+		{"default output", "", false},
+		{"wide output", "wide", false},
+		{"json output", "json", true},
+		{"name output", "name", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsStructuredOutput(tt.format); got != tt.want {
+				t.Errorf("IsStructuredOutput() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFprintList(t *testing.T) {
+	vol := Volume{Name: "pvc-1", Namespace: "openebs", Capacity: "4.0GiB", CasType: ZFSCasType}
+	tests := []struct {
+		name    string
+		format  string
+		items   []interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			"json output of one volume",
+			JSONOutput,
+			[]interface{}{Volume{Name: "pvc-1"}},
+			`{
+    "apiVersion": "openebsctl.openebs.io/v1alpha1",
+    "kind": "VolumeList",
+    "items": [
+        {
+            "accessMode": "",
+            "attached": "",
+            "capacity": "",
+            "name": "pvc-1",
+            "namespace": "",
+            "node": "",
+            "pvc": "",
+            "pvcNamespace": "",
+            "storageClass": "",
+            "version": "",
+            "status": "",
+            "casType": ""
+        }
+    ]
+}
+`,
+			false,
+		},
+		{
+			"json output of no volumes",
+			JSONOutput,
+			nil,
+			`{
+    "apiVersion": "openebsctl.openebs.io/v1alpha1",
+    "kind": "VolumeList",
+    "items": []
+}
+`,
+			false,
+		},
+		{
+			"yaml output of one volume",
+			YAMLOutput,
+			[]interface{}{vol},
+			`apiVersion: openebsctl.openebs.io/v1alpha1
+items:
+- accessMode: ""
+  attached: ""
+  capacity: 4.0GiB
+  casType: localpv-zfs
+  name: pvc-1
+  namespace: openebs
+  node: ""
+  pvc: ""
+  pvcNamespace: ""
+  status: ""
+  storageClass: ""
+  version: ""
+kind: VolumeList
+`,
+			false,
+		},
+		{
+			"name output of two volumes",
+			NameOutput,
+			[]interface{}{vol, Volume{Name: "pvc-2"}},
+			"volume/pvc-1\nvolume/pvc-2\n",
+			false,
+		},
+		{
+			"wide output is not a list format",
+			WideOutput,
+			[]interface{}{vol},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			err := FprintList(w, tt.format, "VolumeList", tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FprintList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("FprintList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckListOutputFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		wantErr bool
+	}{
+		{"default output", "", false},
+		{"json output", "json", false},
+		{"custom-columns output", "custom-columns=NAME:.name", false},
+		{"jsonpath output", "jsonpath={.items[*].name}", false},
+		{"go-template output", "go-template={{.kind}}", false},
+		{"jsonpath output without template", "jsonpath=", true},
+		{"jsonpath output without equals", "jsonpath", true},
+		{"unknown output", "template={{.kind}}", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckListOutputFormat(tt.format); (err != nil) != tt.wantErr {
+				t.Errorf("CheckListOutputFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := CheckOutputFormat(tt.format); IsTemplateOutput(tt.format) && err == nil {
+				t.Errorf("CheckOutputFormat() accepted the list only format %s", tt.format)
+			}
+		})
+	}
+}
+
+func TestFprintListTemplates(t *testing.T) {
+	items := []interface{}{
+		Volume{Name: "pvc-1", CasType: ZFSCasType, ZFS: &ZFSVolumeSpec{PoolName: "zfs-pool", Compression: "lz4"}},
+		Volume{Name: "pvc-2", CasType: LVMCasType, LVM: &LVMVolumeSpec{VolGroup: "lvmvg", ThinProvision: "yes"}},
+	}
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			"custom-columns with engine fields",
+			"custom-columns=NAME:.name,COMPRESSION:.zfs.compression,THIN:{.lvm.thinProvision}",
+			"NAME    COMPRESSION   THIN\npvc-1   lz4           <none>\npvc-2   <none>        yes\n",
+			false,
+		},
+		{
+			"custom-columns without a path",
+			"custom-columns=NAME",
+			"",
+			true,
+		},
+		{
+			"jsonpath over the list",
+			"jsonpath={range .items[*]}{.name}:{.casType} {end}",
+			"pvc-1:localpv-zfs pvc-2:localpv-lvm ",
+			false,
+		},
+		{
+			"invalid jsonpath",
+			"jsonpath={.items[*",
+			"",
+			true,
+		},
+		{
+			"go-template over the list",
+			`go-template={{range .items}}{{.name}} {{end}}`,
+			"pvc-1 pvc-2 ",
+			false,
+		},
+		{
+			"invalid go-template",
+			"go-template={{range .items}",
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			err := FprintList(w, tt.format, "VolumeList", items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FprintList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := w.String(); !tt.wantErr && got != tt.want {
+				t.Errorf("FprintList() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package util
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -24,85 +26,302 @@ import (
 // openebsctl commands
 type Volume struct {
 	// AccessModes contains all ways the volume can be mounted
-	AccessMode string
+	AccessMode string `json:"accessMode"`
 	// Attachment status of the PV and it's claim
-	AttachementStatus string
+	AttachementStatus string `json:"attached"`
 	// Represents the actual capacity of the underlying volume.
-	Capacity string
-	Name     string
+	Capacity string `json:"capacity"`
+	Name     string `json:"name"`
 	//Namespace defines the space within each name must be unique.
 	// An empty namespace is equivalent to the "default" namespace
-	Namespace string
-	Node      string
+	Namespace string `json:"namespace"`
+	Node      string `json:"node"`
 	// Name of the PVClaim of the underlying Persistent Volume
-	PVC string
+	PVC string `json:"pvc"`
+	// Namespace of the PVClaim of the underlying Persistent Volume
+	PVCNamespace string `json:"pvcNamespace"`
 	// Name of StorageClass to which this persistent volume belongs.
-	StorageClass string
+	StorageClass string `json:"storageClass"`
 	// version of the spec used to create the volumes
-	Version string
+	Version string `json:"version"`
+	// Status of the volume as reported by the storage engine
+	Status string `json:"status"`
+	// CasType is the storage engine of the volume
+	CasType string `json:"casType"`
+	// ZFS has the ZFSVolume details of localpv-zfs volumes
+	ZFS *ZFSVolumeSpec `json:"zfs,omitempty"`
+	// LVM has the LVMVolume details of localpv-lvm volumes
+	LVM *LVMVolumeSpec `json:"lvm,omitempty"`
+	// Mayastor has the details of the mayastor volumes from its REST API
+	Mayastor *MayastorVolumeSpec `json:"mayastor,omitempty"`
+}
+
+// VolumeFilter has the filters of the volume listing, the empty fields
+// match all the volumes
+type VolumeFilter struct {
+	// Node on which the volume is provisioned
+	Node string
+	// Pool is the ZFS pool or the LVM volume group of the volume
+	Pool string
+	// StorageClass of the PV
+	StorageClass string
+	// PVCNamespace is the namespace of the PVC bound to the PV
+	PVCNamespace string
+}
+
+// ZFSVolumeSpec has the details of the ZFSVolume CR of a Volume
+type ZFSVolumeSpec struct {
+	PoolName      string `json:"poolName"`
+	OwnerNodeID   string `json:"ownerNodeID"`
+	Capacity      string `json:"capacity"`
+	VolumeType    string `json:"volumeType"`
+	FsType        string `json:"fsType"`
+	RecordSize    string `json:"recordSize"`
+	VolBlockSize  string `json:"volBlockSize"`
+	Compression   string `json:"compression"`
+	Dedup         string `json:"dedup"`
+	Encryption    string `json:"encryption"`
+	ThinProvision string `json:"thinProvision"`
+	Shared        string `json:"shared"`
+	SnapName      string `json:"snapName"`
+}
+
+// LVMVolumeSpec has the details of the LVMVolume CR of a Volume
+type LVMVolumeSpec struct {
+	VolGroup      string `json:"volGroup"`
+	VgPattern     string `json:"vgPattern"`
+	OwnerNodeID   string `json:"ownerNodeID"`
+	Capacity      string `json:"capacity"`
+	Shared        string `json:"shared"`
+	ThinProvision string `json:"thinProvision"`
+}
+
+// MayastorVolumeSpec has the details of a mayastor Volume
+type MayastorVolumeSpec struct {
+	Replicas int `json:"replicas"`
+	// TargetNode is the node of the nexus of the volume
+	TargetNode   string   `json:"targetNode"`
+	ReplicaNodes []string `json:"replicaNodes"`
+	Thin         bool     `json:"thin"`
+}
+
+// ResourceName returns the resource/name reference of the Volume
+func (v Volume) ResourceName() string {
+	return "volume/" + v.Name
+}
+
+// VolumeUsage is the space used by the filesystem of a volume as reported by
+// the kubelet of the node mounting it, the usage of the volumes which aren't
+// mounted by any pod is unknown
+type VolumeUsage struct {
+	Name         string `json:"name"`
+	PVC          string `json:"pvc"`
+	PVCNamespace string `json:"pvcNamespace"`
+	CasType      string `json:"casType"`
+	StorageClass string `json:"storageClass"`
+	// Node is the node of the kubelet reporting the usage
+	Node           string  `json:"node"`
+	Mounted        bool    `json:"mounted"`
+	CapacityBytes  int64   `json:"capacityBytes"`
+	UsedBytes      int64   `json:"usedBytes"`
+	AvailableBytes int64   `json:"availableBytes"`
+	UsedPercent    float64 `json:"usedPercent"`
+}
+
+// ResourceName returns the resource/name reference of the VolumeUsage
+func (u VolumeUsage) ResourceName() string {
+	return "volume/" + u.Name
+}
+
+// Snapshot has the details of a ZFSSnapshot or an LVMSnapshot & of the CSI
+// VolumeSnapshot & VolumeSnapshotContent it was created for
+type Snapshot struct {
+	Name string `json:"name"`
+	// Namespace of the engine snapshot CR, i.e. the openebs namespace
+	Namespace string `json:"namespace"`
+	CasType   string `json:"casType"`
+	// Volume is the name of the source PV
+	Volume string `json:"volume"`
+	Node   string `json:"node"`
+	// Pool is the ZFS pool or the LVM volume group of the snapshot
+	Pool string `json:"pool"`
+	// Size is the space reserved for an LVM snapshot or the capacity of the
+	// source volume of a ZFS snapshot, which is needed to restore it
+	Size string `json:"size"`
+	// State of the snapshot as reported by the storage engine
+	State string `json:"state"`
+	// VolumeSnapshot is the namespace/name of the CSI VolumeSnapshot
+	VolumeSnapshot        string `json:"volumeSnapshot"`
+	VolumeSnapshotContent string `json:"volumeSnapshotContent"`
+	// PVC is the namespace/name of the source PVC of the VolumeSnapshot
+	PVC           string `json:"pvc"`
+	ReadyToUse    string `json:"readyToUse"`
+	SnapshotClass string `json:"snapshotClass"`
+	CreationTime  string `json:"creationTime"`
+}
+
+// ResourceName returns the resource/name reference of the Snapshot
+func (s Snapshot) ResourceName() string {
+	return "snapshot/" + s.Name
+}
+
+// Backup has the details of a ZFSBackup, created by the velero plugin of
+// zfs-localpv to upload a snapshot of a volume
+type Backup struct {
+	Name string `json:"name"`
+	// Namespace of the ZFSBackup, i.e. the openebs namespace
+	Namespace string `json:"namespace"`
+	Volume    string `json:"volume"`
+	Node      string `json:"node"`
+	// Snapshot is the name of the snapshot which is uploaded
+	Snapshot string `json:"snapshot"`
+	// PrevSnapshot is the previous snapshot of an incremental backup
+	PrevSnapshot string `json:"prevSnapshot,omitempty"`
+	// Destination is the remote location to which the snapshot is uploaded
+	Destination  string `json:"destination"`
+	State        string `json:"state"`
+	CreationTime string `json:"creationTime"`
+}
+
+// ResourceName returns the resource/name reference of the Backup
+func (b Backup) ResourceName() string {
+	return "backup/" + b.Name
+}
+
+// Restore has the details of a ZFSRestore, created by the velero plugin of
+// zfs-localpv to download a backup into a volume
+type Restore struct {
+	Name string `json:"name"`
+	// Namespace of the ZFSRestore, i.e. the openebs namespace
+	Namespace string `json:"namespace"`
+	Volume    string `json:"volume"`
+	Node      string `json:"node"`
+	// Source is the remote location from which the backup is downloaded
+	Source       string `json:"source"`
+	State        string `json:"state"`
+	CreationTime string `json:"creationTime"`
+}
+
+// ResourceName returns the resource/name reference of the Restore
+func (r Restore) ResourceName() string {
+	return "restore/" + r.Name
 }
 
 // VolumeInfo struct will have all the details we want to give in the output for
 // openebsctl command volume describe
 type VolumeInfo struct {
-	AccessMode string
+	AccessMode string `json:"accessMode"`
 	// Capacity of the underlying PV
-	Capacity string
+	Capacity string `json:"capacity"`
 	// Name of the volume & Namespace on which it exists
-	Name string
-	PVC  string
+	Name string `json:"name"`
+	PVC  string `json:"pvc"`
 	// Phase indicates if a volume is available, bound to a claim, or released
 	// by a claim.
-	VolumePhase corev1.PersistentVolumePhase
+	VolumePhase corev1.PersistentVolumePhase `json:"volumePhase"`
 	// Name of StorageClass to which this persistent volume belongs.
-	StorageClass string
-	Size         string
+	StorageClass string `json:"storageClass"`
+	Size         string `json:"size"`
 }
 
 type LocalHostPathVolInfo struct {
 	VolumeInfo
-	Path          string
-	ReclaimPolicy string
-	CasType       string
+	Path          string `json:"path"`
+	ReclaimPolicy string `json:"reclaimPolicy"`
+	CasType       string `json:"casType"`
+}
+
+// ResourceName returns the resource/name reference of the LocalHostPathVolInfo
+func (v LocalHostPathVolInfo) ResourceName() string {
+	return "volume/" + v.Name
+}
+
+// LocalDeviceVolInfo has the details of a localpv-device volume along with
+// the BlockDevice claimed for it
+type LocalDeviceVolInfo struct {
+	VolumeInfo
+	Path          string `json:"path"`
+	ReclaimPolicy string `json:"reclaimPolicy"`
+	CasType       string `json:"casType"`
+	Node          string `json:"node"`
+	// BlockDeviceClaim is the BlockDeviceClaim of the volume
+	BlockDeviceClaim string `json:"blockDeviceClaim"`
+	// BlockDevice is the claimed BlockDevice
+	BlockDevice         string `json:"blockDevice"`
+	BlockDevicePath     string `json:"blockDevicePath"`
+	BlockDeviceSerial   string `json:"blockDeviceSerial"`
+	BlockDeviceCapacity string `json:"blockDeviceCapacity"`
+}
+
+// ResourceName returns the resource/name reference of the LocalDeviceVolInfo
+func (v LocalDeviceVolInfo) ResourceName() string {
+	return "volume/" + v.Name
 }
 
 // LVMPVCInfo struct will have all the details we want to give in the output for describe pvc
 // details section for lvm pvc
 type LVMPVCInfo struct {
-	Name             string
-	Namespace        string
-	CasType          string
-	BoundVolume      string
-	StorageClassName string
-	Size             string
-	PVCStatus        corev1.PersistentVolumeClaimPhase
-	MountPods        string
+	Name             string                            `json:"name"`
+	Namespace        string                            `json:"namespace"`
+	CasType          string                            `json:"casType"`
+	BoundVolume      string                            `json:"boundVolume"`
+	StorageClassName string                            `json:"storageClassName"`
+	Size             string                            `json:"size"`
+	PVCStatus        corev1.PersistentVolumeClaimPhase `json:"pvcStatus"`
+	MountPods        string                            `json:"mountPods"`
 }
 
 // ZFSPVCInfo struct will have all the details we want to give in the output for describe pvc
 // details section for zfs pvc
 type ZFSPVCInfo struct {
-	Name             string
-	Namespace        string
-	CasType          string
-	BoundVolume      string
-	StorageClassName string
-	Size             string
-	PVCStatus        corev1.PersistentVolumeClaimPhase
-	MountPods        string
+	Name             string                            `json:"name"`
+	Namespace        string                            `json:"namespace"`
+	CasType          string                            `json:"casType"`
+	BoundVolume      string                            `json:"boundVolume"`
+	StorageClassName string                            `json:"storageClassName"`
+	Size             string                            `json:"size"`
+	PVCStatus        corev1.PersistentVolumeClaimPhase `json:"pvcStatus"`
+	MountPods        string                            `json:"mountPods"`
+}
+
+// LocalDevicePVCInfo struct will have all the details we want to give in the output for describe pvc
+// details section for localpv-device pvc
+type LocalDevicePVCInfo struct {
+	Name             string                            `json:"name"`
+	Namespace        string                            `json:"namespace"`
+	CasType          string                            `json:"casType"`
+	BoundVolume      string                            `json:"boundVolume"`
+	StorageClassName string                            `json:"storageClassName"`
+	Size             string                            `json:"size"`
+	PVCStatus        corev1.PersistentVolumeClaimPhase `json:"pvcStatus"`
+	MountPods        string                            `json:"mountPods"`
 }
 
 // PVCInfo struct will have all the details we want to give in the output for describe pvc
 // details section for generic pvc
 type PVCInfo struct {
-	Name             string
-	Namespace        string
-	CasType          string
-	BoundVolume      string
-	StorageClassName string
-	Size             string
-	PVStatus         corev1.PersistentVolumePhase
-	MountPods        string
+	Name             string                       `json:"name"`
+	Namespace        string                       `json:"namespace"`
+	CasType          string                       `json:"casType"`
+	BoundVolume      string                       `json:"boundVolume"`
+	StorageClassName string                       `json:"storageClassName"`
+	Size             string                       `json:"size"`
+	PVStatus         corev1.PersistentVolumePhase `json:"pvStatus"`
+	MountPods        string                       `json:"mountPods"`
+}
+
+// PVCDesc is the machine-readable output of describe pvc, Volume is one of
+// the volume describe types & is omitted if the PVC isn't bound
+type PVCDesc struct {
+	PVC    interface{} `json:"pvc"`
+	Volume interface{} `json:"volume,omitempty"`
+	// Name is used for the resource/name reference of the PVC
+	Name string `json:"-"`
+}
+
+// ResourceName returns the resource/name reference of the PVCDesc
+func (p PVCDesc) ResourceName() string {
+	return "persistentvolumeclaim/" + p.Name
 }
 
 // MapOptions struct to get the resources as Map with the provided options
@@ -132,47 +351,372 @@ const (
 
 // ZFSVolDesc is the output helper for ZfsVolDesc
 type ZFSVolDesc struct {
-	Name         string
-	Namespace    string
-	AccessMode   string
-	CSIDriver    string
-	Capacity     string
-	PVC          string
-	VolumePhase  corev1.PersistentVolumePhase
-	StorageClass string
-	Version      string
-	Status       string
-	VolumeType   string
-	PoolName     string
-	FileSystem   string
-	Compression  string
-	Dedup        string
-	NodeID       string
-	Recordsize   string
+	Name         string                       `json:"name"`
+	Namespace    string                       `json:"namespace"`
+	AccessMode   string                       `json:"accessMode"`
+	CSIDriver    string                       `json:"csiDriver"`
+	Capacity     string                       `json:"capacity"`
+	PVC          string                       `json:"pvc"`
+	VolumePhase  corev1.PersistentVolumePhase `json:"volumePhase"`
+	StorageClass string                       `json:"storageClass"`
+	Version      string                       `json:"version"`
+	Status       string                       `json:"status"`
+	VolumeType   string                       `json:"volumeType"`
+	PoolName     string                       `json:"poolName"`
+	FileSystem   string                       `json:"fileSystem"`
+	Compression  string                       `json:"compression"`
+	Dedup        string                       `json:"dedup"`
+	NodeID       string                       `json:"nodeID"`
+	Recordsize   string                       `json:"recordsize"`
+	CasType      string                       `json:"casType"`
+	Backups      []Backup                     `json:"backups,omitempty"`
+	Restores     []Restore                    `json:"restores,omitempty"`
+}
+
+// ResourceName returns the resource/name reference of the ZFSVolDesc
+func (v ZFSVolDesc) ResourceName() string {
+	return "volume/" + v.Name
 }
 
 // LVMVolDesc is the output helper for LVMVolDesc
 type LVMVolDesc struct {
-	Name            string
-	Namespace       string
-	AccessMode      string
-	CSIDriver       string
-	Capacity        string
-	PVC             string
-	VolumePhase     corev1.PersistentVolumePhase
-	StorageClass    string
-	Version         string
-	Status          string
-	VolumeGroup     string
-	Shared          string
-	ThinProvisioned string
-	NodeID          string
+	Name            string                       `json:"name"`
+	Namespace       string                       `json:"namespace"`
+	AccessMode      string                       `json:"accessMode"`
+	CSIDriver       string                       `json:"csiDriver"`
+	Capacity        string                       `json:"capacity"`
+	PVC             string                       `json:"pvc"`
+	VolumePhase     corev1.PersistentVolumePhase `json:"volumePhase"`
+	StorageClass    string                       `json:"storageClass"`
+	Version         string                       `json:"version"`
+	Status          string                       `json:"status"`
+	VolumeGroup     string                       `json:"volumeGroup"`
+	Shared          string                       `json:"shared"`
+	ThinProvisioned string                       `json:"thinProvisioned"`
+	NodeID          string                       `json:"nodeID"`
+	CasType         string                       `json:"casType"`
+}
+
+// ResourceName returns the resource/name reference of the LVMVolDesc
+func (v LVMVolDesc) ResourceName() string {
+	return "volume/" + v.Name
+}
+
+// MayastorVolDesc is the output helper for the mayastor volumes
+type MayastorVolDesc struct {
+	Name         string                       `json:"name"`
+	Namespace    string                       `json:"namespace"`
+	AccessMode   string                       `json:"accessMode"`
+	CSIDriver    string                       `json:"csiDriver"`
+	Capacity     string                       `json:"capacity"`
+	PVC          string                       `json:"pvc"`
+	VolumePhase  corev1.PersistentVolumePhase `json:"volumePhase"`
+	StorageClass string                       `json:"storageClass"`
+	Version      string                       `json:"version"`
+	Status       string                       `json:"status"`
+	Replicas     int                          `json:"replicas"`
+	Thin         bool                         `json:"thin"`
+	Protocol     string                       `json:"protocol"`
+	NexusNode    string                       `json:"nexusNode"`
+	NexusState   string                       `json:"nexusState"`
+	Rebuilds     int                          `json:"rebuilds"`
+	ReplicaList  []MayastorReplicaDesc        `json:"replicaList"`
+	CasType      string                       `json:"casType"`
+}
+
+// MayastorReplicaDesc is a replica of a mayastor volume, RebuildProgress is
+// N/A unless the replica is being rebuilt
+type MayastorReplicaDesc struct {
+	Name            string `json:"name"`
+	Node            string `json:"node"`
+	Pool            string `json:"pool"`
+	State           string `json:"state"`
+	ChildStatus     string `json:"childStatus"`
+	RebuildProgress string `json:"rebuildProgress"`
+}
+
+// ResourceName returns the resource/name reference of the MayastorVolDesc
+func (v MayastorVolDesc) ResourceName() string {
+	return "volume/" + v.Name
 }
 
 // ComponentData stores the data for each component of an engine
 type ComponentData struct {
-	Namespace string
-	Status    string
-	Version   string
-	CasType   string
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	Version   string `json:"version"`
+	CasType   string `json:"casType"`
+}
+
+// CapacityUsage is the space of a node, a ZFS pool or a LVM volume group
+// along with the space provisioned for the volumes on it
+type CapacityUsage struct {
+	Total          string `json:"total"`
+	Free           string `json:"free"`
+	UsedPercentage string `json:"usedPercentage"`
+	// Provisioned is the sum of the capacities of the volumes
+	Provisioned string `json:"provisioned"`
+	// Overcommit is the provisioned space as a percentage of the total,
+	// it is above 100% when the thin volumes are overcommitted
+	Overcommit string `json:"overcommit"`
+	// Headroom is the space which can still be provisioned without
+	// overcommitting, it is negative once overcommitted
+	Headroom string `json:"headroom"`
+	// Flags are the thresholds the usage has crossed
+	Flags []string `json:"flags,omitempty"`
+}
+
+// PoolCapacity is the capacity report of a ZFS pool or a LVM volume group
+type PoolCapacity struct {
+	Name          string `json:"name"`
+	CapacityUsage `json:",inline"`
+}
+
+// NodeCapacity is the capacity report of the ZFS pools or the LVM volume
+// groups of a node
+type NodeCapacity struct {
+	Node          string `json:"node"`
+	CasType       string `json:"casType"`
+	CapacityUsage `json:",inline"`
+	Pools         []PoolCapacity `json:"pools"`
+}
+
+// ResourceName returns the resource/name reference of the NodeCapacity
+func (c NodeCapacity) ResourceName() string {
+	return "capacity/" + c.Node
+}
+
+// Event is a kubernetes event of a resource
+type Event struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+	// Object is the kind/name of the resource of the event
+	Object   string `json:"object"`
+	Count    int32  `json:"count"`
+	LastSeen string `json:"lastSeen"`
+	Message  string `json:"message"`
+}
+
+const (
+	// SeverityError is a cause which keeps the resource from being bound or used
+	SeverityError = "Error"
+	// SeverityWarning is a cause which might keep the resource from being bound or used
+	SeverityWarning = "Warning"
+	// SeverityInfo is a state of the resource which is expected to go away
+	SeverityInfo = "Info"
+)
+
+// Cause is a likely cause of a PVC being stuck, found while diagnosing it
+type Cause struct {
+	// Severity is one of Error, Warning or Info
+	Severity string `json:"severity"`
+	// Resource is the kind/name of the resource having the problem
+	Resource string `json:"resource"`
+	Message  string `json:"message"`
+}
+
+// Diagnosis has the chain of resources of a PVC, i.e. its StorageClass, PV
+// & the volume of its engine, along with the likely causes of it being stuck
+type Diagnosis struct {
+	PVC          string `json:"pvc"`
+	Namespace    string `json:"namespace"`
+	Phase        string `json:"phase"`
+	StorageClass string `json:"storageClass"`
+	Provisioner  string `json:"provisioner"`
+	CasType      string `json:"casType"`
+	PV           string `json:"pv"`
+	// Volume is the ZFSVolume or the LVMVolume of the PVC
+	Volume      string `json:"volume"`
+	VolumeState string `json:"volumeState"`
+	// Node is the owner node of the volume or the node selected by the scheduler
+	Node   string  `json:"node"`
+	Causes []Cause `json:"causes"`
+	Events []Event `json:"events"`
+}
+
+// ResourceName returns the resource/name reference of the Diagnosis
+func (d Diagnosis) ResourceName() string {
+	return "pvc/" + d.PVC
+}
+
+// StorageClassVolume is a PV of a StorageClass along with its PVC
+type StorageClassVolume struct {
+	Name string `json:"name"`
+	// PVC is the namespace/name of the PVC of the PV
+	PVC      string `json:"pvc"`
+	Phase    string `json:"phase"`
+	Capacity string `json:"capacity"`
+}
+
+// StorageClassClaim is a PVC of a StorageClass
+type StorageClassClaim struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Phase     string `json:"phase"`
+	Volume    string `json:"volume"`
+	Request   string `json:"request"`
+}
+
+// StorageClassDesc describes a StorageClass, the problems found by checking
+// its parameters against its engine & the volumes using it
+type StorageClassDesc struct {
+	Name                 string            `json:"name"`
+	CasType              string            `json:"casType"`
+	Provisioner          string            `json:"provisioner"`
+	ReclaimPolicy        string            `json:"reclaimPolicy"`
+	VolumeBindingMode    string            `json:"volumeBindingMode"`
+	AllowVolumeExpansion bool              `json:"allowVolumeExpansion"`
+	Parameters           map[string]string `json:"parameters,omitempty"`
+	// AllowedNodes are the nodes in the allowed topologies, all the nodes
+	// are allowed if it is empty
+	AllowedNodes []string `json:"allowedNodes,omitempty"`
+	// Problems are the misconfigurations found, the Severity is Error or Warning
+	Problems []Cause              `json:"problems"`
+	PVs      []StorageClassVolume `json:"pvs"`
+	PVCs     []StorageClassClaim  `json:"pvcs"`
+	// TotalCapacity is the capacity of all the PVs
+	TotalCapacity string `json:"totalCapacity"`
+	// TotalRequested is the storage requested by all the PVCs
+	TotalRequested string `json:"totalRequested"`
+}
+
+// ResourceName returns the resource/name reference of the StorageClassDesc
+func (s StorageClassDesc) ResourceName() string {
+	return "storageclass/" + s.Name
+}
+
+// CheckResult is the result of a health check of the components & the
+// resources of an engine
+type CheckResult struct {
+	// Check is the name of the check, e.g. csi-driver
+	Check   string `json:"check"`
+	CasType string `json:"casType"`
+	// Status is one of Pass, Warn or Fail
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// ResourceName returns the resource/name reference of the CheckResult
+func (c CheckResult) ResourceName() string {
+	return "check/" + c.CasType + "/" + c.Check
+}
+
+// NodeAgent is the CSI node pod of an engine running on a node
+type NodeAgent struct {
+	Pod     string `json:"pod"`
+	CasType string `json:"casType"`
+	// Ready is the count of the ready containers of the pod
+	Ready  string `json:"ready"`
+	Status string `json:"status"`
+}
+
+// NodePool is a ZFS pool or a LVM volume group of a node
+type NodePool struct {
+	CasType      string `json:"casType"`
+	PoolCapacity `json:",inline"`
+}
+
+// NodeVolume is a volume owned by a node along with the pods mounting it
+type NodeVolume struct {
+	Name     string `json:"name"`
+	CasType  string `json:"casType"`
+	Pool     string `json:"pool"`
+	Capacity string `json:"capacity"`
+	Status   string `json:"status"`
+	// PVC is the namespace/name of the PVC of the volume
+	PVC       string   `json:"pvc"`
+	MountPods []string `json:"mountPods"`
+}
+
+// NodeDesc has the OpenEBS components, pools & volumes of a kubernetes node
+type NodeDesc struct {
+	Name string `json:"name"`
+	// Status is the Ready condition of the node
+	Status  string       `json:"status"`
+	Agents  []NodeAgent  `json:"agents"`
+	Pools   []NodePool   `json:"pools"`
+	Volumes []NodeVolume `json:"volumes"`
+}
+
+// ResourceName returns the resource/name reference of the NodeDesc
+func (n NodeDesc) ResourceName() string {
+	return "node/" + n.Name
+}
+
+// BlockDeviceDesc describes a disk of a node discovered by NDM
+type BlockDeviceDesc struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Node       string `json:"node"`
+	Path       string `json:"path"`
+	Size       string `json:"size"`
+	Serial     string `json:"serial"`
+	Model      string `json:"model"`
+	DeviceType string `json:"deviceType"`
+	ClaimState string `json:"claimState"`
+	// BlockDeviceClaim is the namespace/name of the claim, if it is claimed
+	BlockDeviceClaim string `json:"blockDeviceClaim,omitempty"`
+	Status           string `json:"status"`
+	FSType           string `json:"fsType"`
+	MountPoint       string `json:"mountPoint"`
+}
+
+// ResourceName returns the resource/name reference of the BlockDeviceDesc
+func (b BlockDeviceDesc) ResourceName() string {
+	return "blockdevice/" + b.Name
+}
+
+// DrainWorkload is a workload with pods using the local volumes of a node
+// which is to be drained
+type DrainWorkload struct {
+	Namespace string `json:"namespace"`
+	// Kind is the kind of the controller of the pods, e.g. StatefulSet, or
+	// Pod for the pods without a controller
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Pods are the pods of the workload on the node
+	Pods []string `json:"pods"`
+	// Volumes are the local volumes of the node used by the pods
+	Volumes []string `json:"volumes"`
+	// ReadyElsewhere is the count of the ready pods of the workload on the
+	// other nodes
+	ReadyElsewhere int `json:"readyElsewhere"`
+	// Impact is Unavailable if no pods of the workload are ready on the other
+	// nodes, or Degraded
+	Impact string `json:"impact"`
+}
+
+// DrainImpact is the impact of draining a node on its local volumes & the
+// workloads using them
+type DrainImpact struct {
+	Node      string          `json:"node"`
+	Volumes   []NodeVolume    `json:"volumes"`
+	Workloads []DrainWorkload `json:"workloads"`
+	// StorageLoss is true if draining the node leaves pods without their storage
+	StorageLoss bool `json:"storageLoss"`
+}
+
+// ResourceName returns the resource/name reference of the DrainImpact
+func (d DrainImpact) ResourceName() string {
+	return "node/" + d.Node
+}
+
+// Orphan is a leftover resource of an engine, i.e. its volume or its
+// snapshot is deleted but it still holds space or an attachment
+type Orphan struct {
+	// Kind is the kind of the resource, e.g. ZFSVolume or PersistentVolume
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	CasType   string `json:"casType"`
+	Node      string `json:"node"`
+	// Size is the space held by the resource, N/A if not known
+	Size   string `json:"size"`
+	Reason string `json:"reason"`
+}
+
+// ResourceName returns the resource/name reference of the Orphan
+func (o Orphan) ResourceName() string {
+	return strings.ToLower(o.Kind) + "/" + o.Name
 }
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -120,6 +121,11 @@ func PrintByTemplate(templateName string, resourceTemplate string, resource inte
 
 // TablePrinter uses cli-runtime TablePrinter to create a similar UI for the ctl
 func TablePrinter(columns []metav1.TableColumnDefinition, rows []metav1.TableRow, options printers.PrintOptions) {
+	FprintTable(os.Stdout, columns, rows, options)
+}
+
+// FprintTable prints the table to the writer
+func FprintTable(w io.Writer, columns []metav1.TableColumnDefinition, rows []metav1.TableRow, options printers.PrintOptions) {
 	table := &metav1.Table{
 		ColumnDefinitions: columns,
 		Rows:              rows,
@@ -127,16 +133,22 @@ func TablePrinter(columns []metav1.TableColumnDefinition, rows []metav1.TableRow
 	out := bytes.NewBuffer([]byte{})
 	printer := printers.NewTablePrinter(options)
 	_ = printer.PrintObj(table, out)
-	fmt.Printf("%s", out.String())
+	_, _ = fmt.Fprintf(w, "%s", out.String())
 }
 
 // TemplatePrinter uses cli-runtime TemplatePrinter to print by template without extra type
-func TemplatePrinter(template string, obj runtime.Object) {
-	p, _ := printers.NewGoTemplatePrinter([]byte(template))
+func TemplatePrinter(w io.Writer, template string, obj runtime.Object) error {
+	p, err := printers.NewGoTemplatePrinter([]byte(template))
+	if err != nil {
+		return errors.Wrap(err, "error parsing go-template")
+	}
 	p.AllowMissingKeys(true)
 	buffer := &bytes.Buffer{}
-	_ = p.PrintObj(obj, buffer)
-	fmt.Print(buffer)
+	if err = p.PrintObj(obj, buffer); err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, buffer)
+	return err
 }
 
 // ConvertToIBytes humanizes all the passed units to IBytes format
@@ -0,0 +1,75 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// WatchPrinter prints a table & then reprints the rows which changed since
+// they were last printed, like kubectl get --watch
+type WatchPrinter struct {
+	out     io.Writer
+	columns []metav1.TableColumnDefinition
+	options printers.PrintOptions
+	// keyCells is the number of leading cells identifying a row
+	keyCells int
+	// printed has the last printed cells of every row by its key
+	printed map[string]string
+	// headerPrinted is set once a table is written, an empty first listing
+	// doesn't print the header
+	headerPrinted bool
+}
+
+// NewWatchPrinter returns a WatchPrinter printing to the stdout, the rows are
+// identified by their first keyCells cells, i.e. namespace & name
+func NewWatchPrinter(columns []metav1.TableColumnDefinition, options printers.PrintOptions, keyCells int) *WatchPrinter {
+	return &WatchPrinter{out: os.Stdout, columns: columns, options: options, keyCells: keyCells}
+}
+
+// Print prints the rows which are new or changed, the header is only printed
+// along with the first rows printed
+func (p *WatchPrinter) Print(rows []metav1.TableRow) {
+	current := make(map[string]string, len(rows))
+	var changed []metav1.TableRow
+	for _, row := range rows {
+		n := p.keyCells
+		if n > len(row.Cells) {
+			n = len(row.Cells)
+		}
+		key := fmt.Sprint(row.Cells[:n])
+		cells := fmt.Sprint(row.Cells)
+		current[key] = cells
+		if last, ok := p.printed[key]; !ok || last != cells {
+			changed = append(changed, row)
+		}
+	}
+	// the deleted rows are forgotten, so that they're printed if recreated
+	p.printed = current
+	if len(changed) == 0 {
+		return
+	}
+	options := p.options
+	options.NoHeaders = p.headerPrinted
+	FprintTable(p.out, p.columns, changed, options)
+	p.headerPrinted = true
+}
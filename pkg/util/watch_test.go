@@ -0,0 +1,78 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWatchPrinter(t *testing.T) {
+	columns := []metav1.TableColumnDefinition{{Name: "Name"}, {Name: "Status"}}
+	row := func(name, status string) metav1.TableRow {
+		return metav1.TableRow{Cells: []interface{}{name, status}}
+	}
+	var out bytes.Buffer
+	p := &WatchPrinter{out: &out, columns: columns, keyCells: 1}
+	steps := []struct {
+		name string
+		rows []metav1.TableRow
+		want string
+	}{
+		{"nothing is printed without rows", nil,
+			""},
+		{"header & all rows are printed first", []metav1.TableRow{row("pv-1", "Pending"), row("pv-2", "Ready")},
+			"NAME   STATUS\npv-1   Pending\npv-2   Ready\n"},
+		{"only the changed row is printed", []metav1.TableRow{row("pv-1", "Ready"), row("pv-2", "Ready")},
+			"pv-1   Ready\n"},
+		{"nothing is printed without changes", []metav1.TableRow{row("pv-1", "Ready"), row("pv-2", "Ready")},
+			""},
+		{"deleted rows are not printed", []metav1.TableRow{row("pv-1", "Ready")},
+			""},
+		{"recreated rows are printed", []metav1.TableRow{row("pv-1", "Ready"), row("pv-2", "Pending")},
+			"pv-2   Pending\n"},
+	}
+	for _, tt := range steps {
+		out.Reset()
+		p.Print(tt.rows)
+		if got := out.String(); got != tt.want {
+			t.Errorf("%s: Print() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckWatchOutputFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		wantErr bool
+	}{
+		{"default output", "", false},
+		{"wide output", WideOutput, false},
+		{"json output", JSONOutput, true},
+		{"name output", NameOutput, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckWatchOutputFormat(tt.format); (err != nil) != tt.wantErr {
+				t.Errorf("CheckWatchOutputFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package volume
+
+import (
+	"fmt"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LocalDeviceVolInfoTemplate to store the local-device volume and its blockdevice details
+	LocalDeviceVolInfoTemplate = `
+{{.Name}} Details :
+-----------------
+NAME            : {{.Name}}
+ACCESS MODE     : {{.AccessMode}}
+CAS TYPE        : {{.CasType}}
+STORAGE CLASS   : {{.StorageClass}}
+VOLUME PHASE    : {{.VolumePhase }}
+SIZE            : {{.Size}}
+NODE            : {{.Node}}
+PATH            : {{.Path}}
+PV CLAIM        : {{.PVC}}
+RECLAIM POLICY  : {{.ReclaimPolicy}}
+
+BlockDevice Details :
+---------------------
+CLAIM           : {{.BlockDeviceClaim}}
+NAME            : {{.BlockDevice}}
+PATH            : {{.BlockDevicePath}}
+SERIAL          : {{.BlockDeviceSerial}}
+CAPACITY        : {{.BlockDeviceCapacity}}
+`
+	// blockDeviceClaimPrefix is the prefix of the name of the
+	// BlockDeviceClaim created for a localpv-device volume
+	blockDeviceClaimPrefix = "bdc-"
+)
+
+// GetLocalDevice returns a list of localpv-device volumes
+func GetLocalDevice(c *client.K8sClient, pvList *corev1.PersistentVolumeList, openebsNS string) ([]metav1.TableRow, error) {
+	var rows []metav1.TableRow
+	var storageVersion, ns string
+	// the device volumes are provisioned by the hostpath provisioner
+	deploy, err := c.GetDeploymentList("openebs.io/component-name=openebs-localpv-provisioner")
+	if err == nil && len(deploy.Items) == 1 {
+		storageVersion = deploy.Items[0].Labels["openebs.io/version"]
+		ns = deploy.Items[0].Namespace
+	} else {
+		storageVersion = util.NotAvailable
+	}
+	for _, pv := range pvList.Items {
+		// dynamic-local-provisioner has this label for PVs openebs.io/cas-type=local-device
+		if util.GetCasTypeFromPV(&pv) != util.LocalDeviceCasLabel {
+			continue
+		}
+		var accessMode corev1.PersistentVolumeAccessMode
+		if len(pv.Spec.AccessModes) > 0 {
+			accessMode = pv.Spec.AccessModes[0]
+		}
+		rows = append(rows, metav1.TableRow{
+			Cells: []interface{}{
+				ns, pv.Name, "", storageVersion, pv.Spec.Capacity.Storage(), pv.Spec.StorageClassName, pv.Status.Phase,
+				accessMode, util.GetNodeFromPV(&pv)},
+		})
+	}
+	return rows, nil
+}
+
+// DescribeLocalDeviceVolume describes a localpv-device PersistentVolume
+func DescribeLocalDeviceVolume(c *client.K8sClient, vol *corev1.PersistentVolume) error {
+	localDeviceVolInfo, err := GetLocalDeviceVolDesc(c, vol)
+	if err != nil {
+		return err
+	}
+	_ = util.PrintByTemplate("localDeviceVolumeInfo", LocalDeviceVolInfoTemplate, localDeviceVolInfo)
+	printVolumeEvents(c, vol, "")
+	return nil
+}
+
+// GetLocalDeviceVolDesc returns the details of a localpv-device
+// PersistentVolume along with its claimed BlockDevice, the BlockDevice
+// details are N/A if NDM isn't reachable or the BlockDevice isn't found
+func GetLocalDeviceVolDesc(c *client.K8sClient, vol *corev1.PersistentVolume) (util.LocalDeviceVolInfo, error) {
+	if vol == nil {
+		return util.LocalDeviceVolInfo{}, fmt.Errorf("local device volume nil")
+	}
+	info := util.LocalDeviceVolInfo{
+		VolumeInfo: util.VolumeInfo{
+			AccessMode:   util.AccessModeToString(vol.Spec.AccessModes),
+			Capacity:     util.ConvertToIBytes(vol.Spec.Capacity.Storage().String()),
+			Name:         vol.Name,
+			VolumePhase:  vol.Status.Phase,
+			StorageClass: vol.Spec.StorageClassName,
+			Size:         util.ConvertToIBytes(vol.Spec.Capacity.Storage().String()),
+		},
+		ReclaimPolicy:       string(vol.Spec.PersistentVolumeReclaimPolicy),
+		CasType:             util.LocalPvDeviceCasType,
+		Node:                util.GetNodeFromPV(vol),
+		Path:                util.NotAvailable,
+		BlockDeviceClaim:    blockDeviceClaimPrefix + vol.Name,
+		BlockDevice:         util.NotAvailable,
+		BlockDevicePath:     util.NotAvailable,
+		BlockDeviceSerial:   util.NotAvailable,
+		BlockDeviceCapacity: util.NotAvailable,
+	}
+	if vol.Spec.ClaimRef != nil {
+		info.PVC = vol.Spec.ClaimRef.Name
+	}
+	if vol.Spec.PersistentVolumeSource.Local != nil {
+		info.Path = vol.Spec.PersistentVolumeSource.Local.Path
+	}
+	bds, err := c.GetBlockDevices(nil, "", "")
+	if err != nil {
+		return info, fmt.Errorf("unable to get the blockdevice of volume %s: %v", vol.Name, err)
+	}
+	for _, bd := range bds {
+		if bd.Spec.ClaimRef == nil || bd.Spec.ClaimRef.Name != info.BlockDeviceClaim {
+			continue
+		}
+		info.BlockDeviceClaim = bd.Spec.ClaimRef.Namespace + "/" + bd.Spec.ClaimRef.Name
+		info.BlockDevice = bd.Name
+		info.BlockDevicePath = bd.Spec.Path
+		if bd.Spec.Details.Serial != "" {
+			info.BlockDeviceSerial = bd.Spec.Details.Serial
+		}
+		info.BlockDeviceCapacity = util.ConvertToIBytes(fmt.Sprintf("%d", bd.Spec.Capacity.Storage))
+		break
+	}
+	return info, nil
+}
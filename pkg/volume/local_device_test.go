@@ -0,0 +1,152 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package volume
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+// newNDMClient returns a fake dynamic client serving the NDM resources
+func newNDMClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		client.BlockDeviceGVR:      "BlockDeviceList",
+		client.BlockDeviceClaimGVR: "BlockDeviceClaimList",
+	}, objects...)
+}
+
+func TestGetLocalDevice(t *testing.T) {
+	tests := []struct {
+		name   string
+		c      *client.K8sClient
+		pvList *corev1.PersistentVolumeList
+		want   []metav1.TableRow
+	}{
+		{
+			name:   "no local device volumes present",
+			c:      &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(&localpvHostpathDpl1)},
+			pvList: &corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{zfsPV1, localHostpathPv1}},
+		},
+		{
+			name:   "one local device volume present",
+			c:      &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(&localpvHostpathDpl1)},
+			pvList: &corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{localHostpathPv1, localDevicePv1}},
+			want: []metav1.TableRow{
+				{Cells: []interface{}{"openebs", "pvc-device-1", "", "1.9.0", &fourGigiByte, "openebs-device", corev1.VolumeBound, corev1.ReadWriteOnce, "node2"}},
+			},
+		},
+		{
+			name:   "provisioner not found",
+			c:      &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset()},
+			pvList: &corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{localDevicePv1}},
+			want: []metav1.TableRow{
+				{Cells: []interface{}{"", "pvc-device-1", "", "N/A", &fourGigiByte, "openebs-device", corev1.VolumeBound, corev1.ReadWriteOnce, "node2"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetLocalDevice(tt.c, tt.pvList, "")
+			if err != nil {
+				t.Fatalf("GetLocalDevice() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetLocalDevice() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetLocalDeviceVolDesc(t *testing.T) {
+	volumeInfo := util.VolumeInfo{
+		AccessMode:   "ReadWriteOnce ",
+		Capacity:     "4.0GiB",
+		Name:         "pvc-device-1",
+		PVC:          "mongo-device",
+		VolumePhase:  corev1.VolumeBound,
+		StorageClass: "openebs-device",
+		Size:         "4.0GiB",
+	}
+	tests := []struct {
+		name    string
+		c       *client.K8sClient
+		vol     *corev1.PersistentVolume
+		want    util.LocalDeviceVolInfo
+		wantErr bool
+	}{
+		{
+			name: "claimed blockdevice present",
+			c: &client.K8sClient{DynCS: newNDMClient(
+				newBlockDevice("blockdevice-1", "node2", "/dev/sdb", "QM00001", 10737418240, "bdc-pvc-device-1"),
+				newBlockDevice("blockdevice-2", "node2", "/dev/sdc", "QM00002", 10737418240, ""))},
+			vol: &localDevicePv1,
+			want: util.LocalDeviceVolInfo{
+				VolumeInfo:          volumeInfo,
+				Path:                "/var/openebs/local/pvc-device-1",
+				ReclaimPolicy:       "Delete",
+				CasType:             util.LocalPvDeviceCasType,
+				Node:                "node2",
+				BlockDeviceClaim:    "openebs/bdc-pvc-device-1",
+				BlockDevice:         "blockdevice-1",
+				BlockDevicePath:     "/dev/sdb",
+				BlockDeviceSerial:   "QM00001",
+				BlockDeviceCapacity: "10.0GiB",
+			},
+		},
+		{
+			name: "blockdevice not found",
+			c:    &client.K8sClient{DynCS: newNDMClient(newBlockDevice("blockdevice-2", "node2", "/dev/sdc", "QM00002", 10737418240, ""))},
+			vol:  &localDevicePv1,
+			want: util.LocalDeviceVolInfo{
+				VolumeInfo:          volumeInfo,
+				Path:                "/var/openebs/local/pvc-device-1",
+				ReclaimPolicy:       "Delete",
+				CasType:             util.LocalPvDeviceCasType,
+				Node:                "node2",
+				BlockDeviceClaim:    "bdc-pvc-device-1",
+				BlockDevice:         util.NotAvailable,
+				BlockDevicePath:     util.NotAvailable,
+				BlockDeviceSerial:   util.NotAvailable,
+				BlockDeviceCapacity: util.NotAvailable,
+			},
+		},
+		{
+			name:    "nil volume",
+			c:       &client.K8sClient{DynCS: newNDMClient()},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetLocalDeviceVolDesc(tt.c, tt.vol)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetLocalDeviceVolDesc() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetLocalDeviceVolDesc() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
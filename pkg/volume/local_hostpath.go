@@ -17,6 +17,8 @@ limitations under the License.
 package volume
 
 import (
+	"fmt"
+
 	"github.com/openebs/openebsctl/pkg/client"
 	"github.com/openebs/openebsctl/pkg/util"
 	corev1 "k8s.io/api/core/v1"
@@ -54,7 +56,7 @@ func GetLocalHostpath(c *client.K8sClient, pvList *corev1.PersistentVolumeList,
 		capacity := pv.Spec.Capacity.Storage()
 		sc := pv.Spec.StorageClassName
 		attached := pv.Status.Phase
-		attachedNode := pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0].Values[0]
+		attachedNode := util.GetNodeFromPV(&pv)
 		var storageVersion, ns, customStatus string
 		deploy, err := c.GetDeploymentList("openebs.io/component-name=openebs-localpv-provisioner")
 		if err == nil && len(deploy.Items) == 1 {
@@ -75,24 +77,41 @@ func GetLocalHostpath(c *client.K8sClient, pvList *corev1.PersistentVolumeList,
 }
 
 // DescribeLocalHostpathVolume describes a localpv-hostpath PersistentVolume
-func DescribeLocalHostpathVolume(_ *client.K8sClient, vol *corev1.PersistentVolume) error {
+func DescribeLocalHostpathVolume(c *client.K8sClient, vol *corev1.PersistentVolume) error {
 	// Get Local-volume Information
-	localHostpathVolInfo := util.LocalHostPathVolInfo{
+	localHostpathVolInfo, err := GetLocalHostpathVolDesc(c, vol)
+	if err != nil {
+		return err
+	}
+	// Print the Volume information
+	_ = util.PrintByTemplate("localHostpathVolumeInfo", LocalHostpathVolInfoTemplate, localHostpathVolInfo)
+	// Print the events of the volume
+	printVolumeEvents(c, vol, "")
+	return nil
+}
+
+// GetLocalHostpathVolDesc returns the details of a localpv-hostpath PersistentVolume
+func GetLocalHostpathVolDesc(_ *client.K8sClient, vol *corev1.PersistentVolume) (util.LocalHostPathVolInfo, error) {
+	if vol == nil {
+		return util.LocalHostPathVolInfo{}, fmt.Errorf("local hostpath volume nil")
+	}
+	info := util.LocalHostPathVolInfo{
 		VolumeInfo: util.VolumeInfo{
 			AccessMode:   util.AccessModeToString(vol.Spec.AccessModes),
 			Capacity:     util.ConvertToIBytes(vol.Spec.Capacity.Storage().String()),
 			Name:         vol.Name,
-			PVC:          vol.Spec.ClaimRef.Name,
 			VolumePhase:  vol.Status.Phase,
 			StorageClass: vol.Spec.StorageClassName,
 			Size:         util.ConvertToIBytes(vol.Spec.Capacity.Storage().String()),
 		},
-		Path:          vol.Spec.PersistentVolumeSource.Local.Path,
 		ReclaimPolicy: string(vol.Spec.PersistentVolumeReclaimPolicy),
 		CasType:       util.LocalPvHostpathCasType,
 	}
-
-	// Print the Volume information
-	_ = util.PrintByTemplate("localHostpathVolumeInfo", LocalHostpathVolInfoTemplate, localHostpathVolInfo)
-	return nil
+	if vol.Spec.ClaimRef != nil {
+		info.PVC = vol.Spec.ClaimRef.Name
+	}
+	if vol.Spec.PersistentVolumeSource.Local != nil {
+		info.Path = vol.Spec.PersistentVolumeSource.Local.Path
+	}
+	return info, nil
 }
@@ -57,7 +57,7 @@ func GetLVMLocalPV(c *client.K8sClient, pvList *corev1.PersistentVolumeList, ope
 	}
 	for _, pv := range pvList.Items {
 		var attachedNode, customStatus, ns string
-		_, lvmVolMap, err := c.GetLVMvol(nil, util.Map, "", util.MapOptions{Key: util.Name})
+		_, lvmVolMap, err := c.GetLVMvol(nil, util.Map, "", "", util.MapOptions{Key: util.Name})
 		if err != nil {
 			return nil, fmt.Errorf("failed to list LVMVolumes")
 		}
@@ -88,6 +88,27 @@ func DescribeLVMLocalPVs(c *client.K8sClient, vol *corev1.PersistentVolume) erro
 	if vol == nil {
 		return fmt.Errorf("LVM volume nil")
 	}
+	v, err := GetLVMVolDesc(c, vol)
+	// 4. Print the data
+	_ = util.PrintByTemplate("volume", lvmVolInfo, v)
+	if err != nil {
+		// 5. Print the error is any, for printing the error at last, otherwise
+		// this would come in between two sections in PVC describe
+		fmt.Println()
+		fmt.Fprintf(os.Stderr, "The LVMVol for %s doesnot exist", vol.Name)
+		fmt.Println()
+	}
+	// 6. Print the events of the volume
+	printVolumeEvents(c, vol, "LVMVolume")
+	return nil
+}
+
+// GetLVMVolDesc returns the details of a single lvm-localpv volume, if the
+// LVMVolume can't be found the details of the PV are returned with an error
+func GetLVMVolDesc(c *client.K8sClient, vol *corev1.PersistentVolume) (util.LVMVolDesc, error) {
+	if vol == nil {
+		return util.LVMVolDesc{}, fmt.Errorf("LVM volume nil")
+	}
 	// 1. Fetch the version from the CSI Controller STS labels
 	var version string
 	if CSIctrl, err := c.GetCSIControllerSTS(util.LVMLocalPVcsiControllerLabelValue); err == nil {
@@ -99,39 +120,72 @@ func DescribeLVMLocalPVs(c *client.K8sClient, vol *corev1.PersistentVolume) erro
 	}
 	// 2. Fill the details using the Persistent Volume
 	v := util.LVMVolDesc{
-		AccessMode: util.AccessModeToString(vol.Spec.AccessModes),
-		Capacity:   vol.Spec.Capacity.Storage().String(),
-		CSIDriver:  vol.Spec.CSI.Driver,
-		Name:       vol.Name,
-		// assuming that LVMPVs aren't static-ally provisioned
-		PVC:          vol.Spec.ClaimRef.Name,
+		AccessMode:   util.AccessModeToString(vol.Spec.AccessModes),
+		Capacity:     vol.Spec.Capacity.Storage().String(),
+		Name:         vol.Name,
 		VolumePhase:  vol.Status.Phase,
 		StorageClass: vol.Spec.StorageClassName,
 		Version:      version,
+		CasType:      util.LVMCasType,
+	}
+	if vol.Spec.CSI != nil {
+		v.CSIDriver = vol.Spec.CSI.Driver
+	}
+	// assuming that LVMPVs aren't static-ally provisioned
+	if vol.Spec.ClaimRef != nil {
+		v.PVC = vol.Spec.ClaimRef.Name
 	}
-
 	// 3. Fetch the corresponding LVM Volume CR and fill in the other details
-	printErr := false
-	lVols, _, err := c.GetLVMvol([]string{vol.Name}, util.List, "", util.MapOptions{})
-	if err != nil || len(lVols.Items) == 0 {
-		// For printing the error at last, otherwise this would come in between two sections in PVC describe
-		printErr = true
-	} else {
-		lVol := lVols.Items[0]
-		v.Namespace = lVol.Namespace
-		v.Status = lVol.Status.State
-		v.VolumeGroup = lVol.Spec.VolGroup
-		v.Shared = lVol.Spec.Shared
-		v.ThinProvisioned = lVol.Spec.ThinProvision
-		v.NodeID = lVol.Spec.OwnerNodeID
+	lVols, _, err := c.GetLVMvol([]string{vol.Name}, util.List, "", "", util.MapOptions{})
+	if err != nil {
+		return v, err
 	}
-	// 4. Print the data
-	_ = util.PrintByTemplate("volume", lvmVolInfo, v)
-	if printErr {
-		// 5. Print the error is any
-		fmt.Println()
-		fmt.Fprintf(os.Stderr, "The LVMVol for %s doesnot exist", vol.Name)
-		fmt.Println()
+	if len(lVols.Items) == 0 {
+		return v, fmt.Errorf("lvmvolume %s not found", vol.Name)
+	}
+	lVol := lVols.Items[0]
+	v.Namespace = lVol.Namespace
+	v.Status = lVol.Status.State
+	v.VolumeGroup = lVol.Spec.VolGroup
+	v.Shared = lVol.Spec.Shared
+	v.ThinProvisioned = lVol.Spec.ThinProvision
+	v.NodeID = lVol.Spec.OwnerNodeID
+	return v, nil
+}
+
+// AddLVMVolumeSpecs adds the LVMVolume details to the localpv-lvm volumes
+func AddLVMVolumeSpecs(c *client.K8sClient, volumes []util.Volume) error {
+	_, lvmVolMap, err := c.GetLVMvol(nil, util.Map, "", "", util.MapOptions{Key: util.Name})
+	if err != nil {
+		return fmt.Errorf("failed to list LVMVolumes")
+	}
+	for i := range volumes {
+		if volumes[i].CasType != util.LVMCasType {
+			continue
+		}
+		if lv, ok := lvmVolMap[volumes[i].Name]; ok {
+			volumes[i].LVM = &util.LVMVolumeSpec{
+				VolGroup:      lv.Spec.VolGroup,
+				VgPattern:     lv.Spec.VgPattern,
+				OwnerNodeID:   lv.Spec.OwnerNodeID,
+				Capacity:      lv.Spec.Capacity,
+				Shared:        lv.Spec.Shared,
+				ThinProvision: lv.Spec.ThinProvision,
+			}
+		}
 	}
 	return nil
 }
+
+// SelectLVMVolumes returns the names of the LVMVolumes matching the selectors
+func SelectLVMVolumes(c *client.K8sClient, labelSelector, fieldSelector string) ([]string, error) {
+	lvs, _, err := c.GetLVMvol(nil, util.List, labelSelector, fieldSelector, util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(lvs.Items))
+	for _, lv := range lvs.Items {
+		names = append(names, lv.Name)
+	}
+	return names, nil
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
 	fakelvm "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/typed/lvm/v1alpha1/fake"
 	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -197,3 +198,22 @@ func lvmVolNotExists(c *client.K8sClient) {
 		return true, nil, fmt.Errorf("failed to list LVMVolumes")
 	})
 }
+
+func TestAddLVMVolumeSpecs(t *testing.T) {
+	c := &client.K8sClient{Ns: "lvmlocalpv", LVMCS: fake.NewSimpleClientset(&lvmVol1)}
+	volumes := []util.Volume{
+		{Name: "pvc-1", CasType: util.LVMCasType},
+		{Name: "pvc-1", CasType: util.ZFSCasType},
+	}
+	if err := AddLVMVolumeSpecs(c, volumes); err != nil {
+		t.Fatalf("AddLVMVolumeSpecs() error = %v", err)
+	}
+	want := &util.LVMVolumeSpec{VolGroup: "lvmpv", VgPattern: "vg1*", OwnerNodeID: "node1", Capacity: "4Gi",
+		Shared: "NotShared", ThinProvision: "No"}
+	if !reflect.DeepEqual(volumes[0].LVM, want) {
+		t.Errorf("AddLVMVolumeSpecs() = %v, want %v", volumes[0].LVM, want)
+	}
+	if volumes[1].LVM != nil {
+		t.Errorf("AddLVMVolumeSpecs() added details to a localpv-zfs volume")
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const mayastorVolInfo = `
+{{.Name}} Details :
+------------------
+NAME              : {{.Name}}
+NAMESPACE         : {{.Namespace}}
+ACCESS MODE       : {{.AccessMode}}
+CSI DRIVER        : {{.CSIDriver}}
+CAPACITY          : {{.Capacity}}
+PVC NAME          : {{.PVC}}
+VOLUME PHASE      : {{.VolumePhase}}
+STORAGE CLASS     : {{.StorageClass}}
+VERSION           : {{.Version}}
+VOLUME STATUS     : {{.Status}}
+REPLICA COUNT     : {{.Replicas}}
+THIN PROVISIONED  : {{.Thin}}
+PROTOCOL          : {{.Protocol}}
+NEXUS NODE        : {{.NexusNode}}
+NEXUS STATE       : {{.NexusState}}
+REBUILDS          : {{.Rebuilds}}
+`
+
+// GetMayastorVolumes returns a list of mayastor volumes
+func GetMayastorVolumes(c *client.K8sClient, pvList *corev1.PersistentVolumeList, openebsNS string) ([]metav1.TableRow, error) {
+	// 1. Get the PVs of the mayastor CSI driver, the REST API isn't reached
+	// if there are none
+	var pvs []corev1.PersistentVolume
+	for _, pv := range pvList.Items {
+		if pv.Spec.CSI != nil && pv.Spec.CSI.Driver == util.MayastorCSIDriver {
+			pvs = append(pvs, pv)
+		}
+	}
+	if len(pvs) == 0 {
+		return nil, nil
+	}
+	// 2. Fetch all the volumes of the REST API, they have no namespace
+	volMap, err := getMayastorVolumeMap(c)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := c.GetMayastorNamespace()
+	if err != nil {
+		return nil, err
+	}
+	if openebsNS != "" && openebsNS != ns {
+		return nil, nil
+	}
+	version := mayastorVersion(c)
+	var rows []metav1.TableRow
+	for _, pv := range pvs {
+		status, attachedNode := util.NotAvailable, util.NotAvailable
+		if vol, ok := volMap[pv.Spec.CSI.VolumeHandle]; ok {
+			status = vol.State.Status
+			if vol.State.Target != nil {
+				attachedNode = vol.State.Target.Node
+			}
+		} else {
+			_, _ = fmt.Fprintf(os.Stderr, "couldn't find mayastor volume %s\n", pv.Name)
+		}
+		var accessMode corev1.PersistentVolumeAccessMode
+		if len(pv.Spec.AccessModes) > 0 {
+			accessMode = pv.Spec.AccessModes[0]
+		}
+		rows = append(rows, metav1.TableRow{
+			Cells: []interface{}{
+				ns, pv.Name, status, version, util.ConvertToIBytes(pv.Spec.Capacity.Storage().String()), pv.Spec.StorageClassName, pv.Status.Phase,
+				accessMode, attachedNode}})
+	}
+	return rows, nil
+}
+
+// DescribeMayastorVolume describes a single mayastor volume along with its
+// replicas
+func DescribeMayastorVolume(c *client.K8sClient, vol *corev1.PersistentVolume) error {
+	if vol == nil {
+		return fmt.Errorf("mayastor volume nil")
+	}
+	v, err := GetMayastorVolDesc(c, vol)
+	_ = util.PrintByTemplate("volume", mayastorVolInfo, v)
+	if err != nil {
+		fmt.Println()
+		_, _ = fmt.Fprintf(os.Stderr, "The mayastor volume of %s can't be found: %v\n", vol.Name, err)
+	} else {
+		fmt.Println("\nReplicas :")
+		fmt.Println("----------")
+		if len(v.ReplicaList) == 0 {
+			fmt.Println("<none>")
+		} else {
+			var rows []metav1.TableRow
+			for _, r := range v.ReplicaList {
+				rows = append(rows, metav1.TableRow{Cells: []interface{}{r.Name, r.Node, r.Pool, r.State, r.ChildStatus, r.RebuildProgress}})
+			}
+			util.TablePrinter(util.MayastorReplicaColumnDefinitions, rows, printers.PrintOptions{})
+		}
+	}
+	printVolumeEvents(c, vol, "")
+	return nil
+}
+
+// GetMayastorVolDesc returns the details of a single mayastor volume, if the
+// volume can't be found in the REST API the details of the PV are returned
+// with an error
+func GetMayastorVolDesc(c *client.K8sClient, vol *corev1.PersistentVolume) (util.MayastorVolDesc, error) {
+	if vol == nil {
+		return util.MayastorVolDesc{}, fmt.Errorf("mayastor volume nil")
+	}
+	// 1. Fill the details using the Persistent Volume
+	v := util.MayastorVolDesc{
+		AccessMode:   util.AccessModeToString(vol.Spec.AccessModes),
+		Capacity:     util.ConvertToIBytes(vol.Spec.Capacity.Storage().String()),
+		Name:         vol.Name,
+		VolumePhase:  vol.Status.Phase,
+		StorageClass: vol.Spec.StorageClassName,
+		Version:      mayastorVersion(c),
+		Status:       util.NotAvailable,
+		Protocol:     util.NotAvailable,
+		NexusNode:    util.NotAvailable,
+		NexusState:   util.NotAvailable,
+		CasType:      util.MayastorCasType,
+	}
+	if vol.Spec.ClaimRef != nil {
+		v.PVC = vol.Spec.ClaimRef.Name
+	}
+	if vol.Spec.CSI == nil {
+		return v, fmt.Errorf("pv %s is not a csi volume", vol.Name)
+	}
+	v.CSIDriver = vol.Spec.CSI.Driver
+	// 2. Fetch the volume from the REST API & fill in its nexus & replicas
+	mv, err := c.GetMayastorVolume(vol.Spec.CSI.VolumeHandle)
+	if err != nil {
+		return v, err
+	}
+	v.Namespace, _ = c.GetMayastorNamespace()
+	v.Status = mv.State.Status
+	v.Replicas = mv.Spec.NumReplicas
+	v.Thin = mv.Spec.Thin
+	if t := mv.State.Target; t != nil {
+		v.Protocol = t.Protocol
+		v.NexusNode = t.Node
+		v.NexusState = t.State
+		v.Rebuilds = t.Rebuilds
+	}
+	v.ReplicaList = replicaDescs(mv)
+	return v, nil
+}
+
+// replicaDescs returns the replicas of the volume sorted by their node
+func replicaDescs(mv *client.MayastorVolume) []util.MayastorReplicaDesc {
+	replicas := make([]util.MayastorReplicaDesc, 0, len(mv.State.ReplicaTopology))
+	for name, r := range mv.State.ReplicaTopology {
+		progress := util.NotAvailable
+		if r.RebuildProgress != nil {
+			progress = strconv.Itoa(*r.RebuildProgress) + "%"
+		}
+		childStatus := r.ChildStatus
+		if childStatus == "" {
+			childStatus = util.NotAvailable
+		}
+		replicas = append(replicas, util.MayastorReplicaDesc{
+			Name:            name,
+			Node:            r.Node,
+			Pool:            r.Pool,
+			State:           r.State,
+			ChildStatus:     childStatus,
+			RebuildProgress: progress,
+		})
+	}
+	sort.Slice(replicas, func(i, j int) bool {
+		if replicas[i].Node != replicas[j].Node {
+			return replicas[i].Node < replicas[j].Node
+		}
+		return replicas[i].Name < replicas[j].Name
+	})
+	return replicas
+}
+
+// AddMayastorVolumeSpecs adds the details of the REST API volumes to the
+// mayastor volumes
+func AddMayastorVolumeSpecs(c *client.K8sClient, volumes []util.Volume) error {
+	volMap, err := getMayastorVolumeMap(c)
+	if err != nil {
+		return err
+	}
+	pvs, err := c.GetPVs(nil, "", "")
+	if err != nil {
+		return err
+	}
+	handles := make(map[string]string)
+	for _, pv := range pvs.Items {
+		if pv.Spec.CSI != nil && pv.Spec.CSI.Driver == util.MayastorCSIDriver {
+			handles[pv.Name] = pv.Spec.CSI.VolumeHandle
+		}
+	}
+	for i := range volumes {
+		if volumes[i].CasType != util.MayastorCasType {
+			continue
+		}
+		mv, ok := volMap[handles[volumes[i].Name]]
+		if !ok {
+			continue
+		}
+		spec := &util.MayastorVolumeSpec{Replicas: mv.Spec.NumReplicas, Thin: mv.Spec.Thin, TargetNode: util.NotAvailable}
+		if mv.State.Target != nil {
+			spec.TargetNode = mv.State.Target.Node
+		}
+		for _, r := range replicaDescs(&mv) {
+			spec.ReplicaNodes = append(spec.ReplicaNodes, r.Node)
+		}
+		volumes[i].Mayastor = spec
+	}
+	return nil
+}
+
+// getMayastorVolumeMap returns the volumes of the REST API by their UUID
+func getMayastorVolumeMap(c *client.K8sClient) (map[string]client.MayastorVolume, error) {
+	volumes, err := c.GetMayastorVolumes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mayastor volumes: %v", err)
+	}
+	volMap := make(map[string]client.MayastorVolume)
+	for _, vol := range volumes {
+		volMap[vol.Spec.UUID] = vol
+	}
+	return volMap, nil
+}
+
+// mayastorVersion returns the version of the mayastor REST API pods
+func mayastorVersion(c *client.K8sClient) string {
+	pods, err := c.GetPods(util.MayastorComponentNameKey+"="+util.MayastorRESTComponent, "", "")
+	if err == nil {
+		for _, pod := range pods.Items {
+			if version := pod.Labels["openebs.io/version"]; version != "" {
+				return version
+			}
+		}
+	}
+	return util.NotAvailable
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volume
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetMayastorVolumes(t *testing.T) {
+	server := newMayastorServer(mayastorVol1)
+	defer server.Close()
+	tests := []struct {
+		name      string
+		endpoint  string
+		pvList    *corev1.PersistentVolumeList
+		openebsNS string
+		want      []metav1.TableRow
+		wantErr   bool
+	}{
+		{
+			name:     "one mayastor volume present",
+			endpoint: server.URL,
+			pvList:   &corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{zfsPV1, mayastorPV1}},
+			want: []metav1.TableRow{{Cells: []interface{}{"mayastor", mayastorPV1.Name, "Degraded", "2.5.0", "4.0GiB", "mayastor-3",
+				corev1.VolumeBound, corev1.ReadWriteOnce, "node1"}}},
+		},
+		{
+			name:      "one mayastor volume present, namespace conflicts",
+			endpoint:  server.URL,
+			pvList:    &corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{mayastorPV1}},
+			openebsNS: "openebs",
+			want:      nil,
+		},
+		{
+			name:     "no access modes",
+			endpoint: server.URL,
+			pvList: &corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{func() corev1.PersistentVolume {
+				pv := *mayastorPV1.DeepCopy()
+				pv.Spec.AccessModes = nil
+				return pv
+			}()}},
+			want: []metav1.TableRow{{Cells: []interface{}{"mayastor", mayastorPV1.Name, "Degraded", "2.5.0", "4.0GiB", "mayastor-3",
+				corev1.VolumeBound, corev1.PersistentVolumeAccessMode(""), "node1"}}},
+		},
+		{
+			// the rest api isn't reached without mayastor PVs
+			name:     "no mayastor volumes, rest api not reachable",
+			endpoint: "http://127.0.0.1:1",
+			pvList:   &corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{zfsPV1}},
+			want:     nil,
+		},
+		{
+			name:     "rest api not reachable",
+			endpoint: "http://127.0.0.1:1",
+			pvList:   &corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{mayastorPV1}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &client.K8sClient{
+				K8sCS:        k8sfake.NewSimpleClientset(&mayastorRESTPod),
+				MayastorREST: &client.MayastorRESTClient{Endpoint: tt.endpoint, Namespace: "mayastor"},
+			}
+			got, err := GetMayastorVolumes(c, tt.pvList, tt.openebsNS)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetMayastorVolumes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetMayastorVolumes() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMayastorVolDesc(t *testing.T) {
+	server := newMayastorServer(mayastorVol1)
+	defer server.Close()
+	c := &client.K8sClient{
+		K8sCS:        k8sfake.NewSimpleClientset(&mayastorRESTPod),
+		MayastorREST: &client.MayastorRESTClient{Endpoint: server.URL, Namespace: "mayastor"},
+	}
+	want := util.MayastorVolDesc{
+		Name: mayastorPV1.Name, Namespace: "mayastor", AccessMode: "ReadWriteOnce ", CSIDriver: util.MayastorCSIDriver,
+		Capacity: "4.0GiB", PVC: "ms-pvc-1", VolumePhase: corev1.VolumeBound, StorageClass: "mayastor-3", Version: "2.5.0",
+		Status: "Degraded", Replicas: 3, Protocol: "nvmf", NexusNode: "node1", NexusState: "Degraded", Rebuilds: 1,
+		ReplicaList: []util.MayastorReplicaDesc{
+			{Name: "a1b2c3d4-0000-0000-0000-000000000001", Node: "node1", Pool: "pool-node1", State: "Online", ChildStatus: "Online", RebuildProgress: util.NotAvailable},
+			{Name: "a1b2c3d4-0000-0000-0000-000000000002", Node: "node2", Pool: "pool-node2", State: "Online", ChildStatus: "Online", RebuildProgress: util.NotAvailable},
+			{Name: "a1b2c3d4-0000-0000-0000-000000000003", Node: "node3", Pool: "pool-node3", State: "Online", ChildStatus: "Degraded", RebuildProgress: "42%"},
+		},
+		CasType: util.MayastorCasType,
+	}
+	got, err := GetMayastorVolDesc(c, &mayastorPV1)
+	if err != nil {
+		t.Fatalf("GetMayastorVolDesc() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMayastorVolDesc() got = %+v, want %+v", got, want)
+	}
+	// the volume missing in the rest api has the details of its PV
+	missing := mayastorPV1.DeepCopy()
+	missing.Spec.CSI.VolumeHandle = "missing"
+	got, err = GetMayastorVolDesc(c, missing)
+	if err == nil || got.Status != util.NotAvailable || got.Capacity != "4.0GiB" {
+		t.Errorf("GetMayastorVolDesc() of a missing volume got = %+v, %v", got, err)
+	}
+	if err := DescribeMayastorVolume(c, &mayastorPV1); err != nil {
+		t.Errorf("DescribeMayastorVolume() error = %v", err)
+	}
+}
+
+func TestAddMayastorVolumeSpecs(t *testing.T) {
+	server := newMayastorServer(mayastorVol1)
+	defer server.Close()
+	c := &client.K8sClient{
+		K8sCS:        k8sfake.NewSimpleClientset(&mayastorPV1, &zfsPV1),
+		MayastorREST: &client.MayastorRESTClient{Endpoint: server.URL, Namespace: "mayastor"},
+	}
+	volumes := []util.Volume{{Name: zfsPV1.Name, CasType: util.ZFSCasType}, {Name: mayastorPV1.Name, CasType: util.MayastorCasType}}
+	if err := AddMayastorVolumeSpecs(c, volumes); err != nil {
+		t.Fatalf("AddMayastorVolumeSpecs() error = %v", err)
+	}
+	want := &util.MayastorVolumeSpec{Replicas: 3, TargetNode: "node1", ReplicaNodes: []string{"node1", "node2", "node3"}}
+	if volumes[0].Mayastor != nil || !reflect.DeepEqual(volumes[1].Mayastor, want) {
+		t.Errorf("AddMayastorVolumeSpecs() got = %+v, %+v, want nil, %+v", volumes[0].Mayastor, volumes[1].Mayastor, want)
+	}
+}
+
+func TestMayastorVersionWatched(t *testing.T) {
+	// the mayastor pods don't have the openebs.io/component-name label
+	c := &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(&mayastorRESTPod)}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	if _, err := c.Watch(stopCh, client.PodResource); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if got := mayastorVersion(c); got != "2.5.0" {
+		t.Errorf("mayastorVersion() = %s, want 2.5.0", got)
+	}
+}
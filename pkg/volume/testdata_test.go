@@ -17,15 +17,21 @@ limitations under the License.
 package volume
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"time"
 
 	lvm "github.com/openebs/lvm-localpv/pkg/apis/openebs.io/lvm/v1alpha1"
+	"github.com/openebs/openebsctl/pkg/client"
 	"github.com/openebs/openebsctl/pkg/util"
 	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // Some storage sizes for PVs
@@ -271,3 +277,156 @@ var localpvHostpathDpl2 = appsv1.Deployment{
 			"openebs.io/component-name": "openebs-localpv-provisioner"},
 	},
 }
+
+/****************
+* MAYASTOR
+****************/
+
+const mayastorVol1UUID = "ec4e66fd-3b33-4439-b504-d49aba53da26"
+
+var mayastorPV1 = corev1.PersistentVolume{
+	ObjectMeta: metav1.ObjectMeta{Name: "pvc-" + mayastorVol1UUID},
+	Spec: corev1.PersistentVolumeSpec{
+		Capacity: corev1.ResourceList{corev1.ResourceStorage: fourGigiByte},
+		PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{
+			Driver: util.MayastorCSIDriver, VolumeHandle: mayastorVol1UUID}},
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		ClaimRef: &corev1.ObjectReference{Kind: "PersistentVolumeClaim", Namespace: "default",
+			Name: "ms-pvc-1", APIVersion: "v1"},
+		StorageClassName: "mayastor-3",
+	},
+	Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+}
+
+var mayastorRESTPod = corev1.Pod{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "mayastor-api-rest-5c6f8b6d9-x7k2p",
+		Namespace: "mayastor",
+		Labels:    map[string]string{util.MayastorComponentNameKey: util.MayastorRESTComponent, "openebs.io/version": "2.5.0"},
+	},
+}
+
+var rebuildProgress = 42
+
+// mayastorVol1 is a degraded volume of 3 replicas, one of them is rebuilt
+var mayastorVol1 = client.MayastorVolume{
+	Spec: client.MayastorVolumeSpec{UUID: mayastorVol1UUID, Size: 4294967296, NumReplicas: 3, Status: "Created"},
+	State: client.MayastorVolumeState{
+		UUID:   mayastorVol1UUID,
+		Size:   4294967296,
+		Status: "Degraded",
+		Target: &client.MayastorNexus{
+			UUID: "8f2a0d7c-5a36-4d55-9bb0-3f7c8d1e2a44", Node: "node1", Protocol: "nvmf", State: "Degraded", Rebuilds: 1,
+		},
+		ReplicaTopology: map[string]client.MayastorReplica{
+			"a1b2c3d4-0000-0000-0000-000000000003": {Node: "node3", Pool: "pool-node3", State: "Online", ChildStatus: "Degraded", RebuildProgress: &rebuildProgress},
+			"a1b2c3d4-0000-0000-0000-000000000001": {Node: "node1", Pool: "pool-node1", State: "Online", ChildStatus: "Online"},
+			"a1b2c3d4-0000-0000-0000-000000000002": {Node: "node2", Pool: "pool-node2", State: "Online", ChildStatus: "Online"},
+		},
+	},
+}
+
+// newMayastorServer returns a fake Mayastor REST API serving the volumes
+func newMayastorServer(volumes ...client.MayastorVolume) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v0/volumes" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"entries": volumes})
+			return
+		}
+		for _, vol := range volumes {
+			if r.URL.Path == "/v0/volumes/"+vol.Spec.UUID {
+				_ = json.NewEncoder(w).Encode(vol)
+				return
+			}
+		}
+		http.Error(w, `{"kind":"NotFound","details":"`+strings.TrimPrefix(r.URL.Path, "/v0/volumes/")+` not found"}`, http.StatusNotFound)
+	}))
+}
+
+/**************
+* Local Device
+**************/
+
+var localDevicePv1 = corev1.PersistentVolume{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: "pvc-device-1",
+		Labels: map[string]string{
+			"openebs.io/cas-type": "local-device",
+		},
+	},
+	Spec: corev1.PersistentVolumeSpec{
+		Capacity: localHostpathVolumeCapacity,
+		PersistentVolumeSource: corev1.PersistentVolumeSource{
+			Local: &corev1.LocalVolumeSource{Path: "/var/openebs/local/pvc-device-1"},
+		},
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		ClaimRef: &corev1.ObjectReference{
+			Kind:      "PersistentVolumeClaim",
+			Namespace: "local-app",
+			Name:      "mongo-device",
+		},
+		PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+		StorageClassName:              "openebs-device",
+		NodeAffinity: &corev1.VolumeNodeAffinity{
+			Required: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: "kubernetes.io/hostname", Operator: corev1.NodeSelectorOpIn, Values: []string{"node2"}},
+				}},
+			}},
+		},
+	},
+	Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+}
+
+// newBlockDevice returns a NDM BlockDevice of the node, claimed by the
+// BlockDeviceClaim if claim isn't empty
+func newBlockDevice(name, node, path, serial string, size int64, claim string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"path":           path,
+		"capacity":       map[string]interface{}{"storage": size},
+		"details":        map[string]interface{}{"serial": serial, "deviceType": "disk"},
+		"nodeAttributes": map[string]interface{}{"nodeName": node},
+	}
+	claimState := "Unclaimed"
+	if claim != "" {
+		spec["claimRef"] = map[string]interface{}{"kind": "BlockDeviceClaim", "name": claim, "namespace": "openebs"}
+		claimState = "Claimed"
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "openebs.io/v1alpha1",
+		"kind":       "BlockDevice",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "openebs"},
+		"spec":       spec,
+		"status":     map[string]interface{}{"claimState": claimState, "state": "Active"},
+	}}
+}
+
+/*************
+* Kubelet Stats
+*************/
+
+// newKubeletServer returns an apiserver serving the stats summary of the
+// kubelets of the nodes, the other nodes are unreachable
+func newKubeletServer(pods map[string][]client.PodStats) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for node, stats := range pods {
+			if r.URL.Path == "/api/v1/nodes/"+node+"/proxy/stats/summary" {
+				_ = json.NewEncoder(w).Encode(client.StatsSummary{Pods: stats})
+				return
+			}
+		}
+		http.Error(w, "error trying to reach service: dial tcp: i/o timeout", http.StatusServiceUnavailable)
+	}))
+}
+
+// newVolumeStats returns the stats of a pod volume backed by the PVC
+func newVolumeStats(namespace, pvc string, used, available int64) client.VolumeStats {
+	capacity := used + available
+	return client.VolumeStats{
+		Name:           "data",
+		PVCRef:         &client.PVCRef{Name: pvc, Namespace: namespace},
+		UsedBytes:      &used,
+		AvailableBytes: &available,
+		CapacityBytes:  &capacity,
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package volume
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// volumeUsageListKind is the kind of the machine-readable volume usage listing
+	volumeUsageListKind = "VolumeUsageList"
+	// statsPeriod is the period of the volume stats of the kubelet, the
+	// cached stats of the watches are refreshed at this period
+	statsPeriod = 10 * time.Second
+
+	// SortByUsedPercent sorts the volumes by their used percentage, the
+	// fullest volume first
+	SortByUsedPercent = "used%"
+	// SortByUsed sorts the volumes by their used bytes, the largest first
+	SortByUsed = "used"
+	// SortByAvailable sorts the volumes by their available bytes, the
+	// smallest first
+	SortByAvailable = "available"
+	// SortByName sorts the volumes by their names
+	SortByName = "name"
+)
+
+// Top shows the space used by the filesystems of the volumes as reported by
+// the kubelets, sorted by sortBy
+func Top(casType, output, sortBy string, filter util.VolumeFilter) error {
+	if casType != "" && !util.IsValidCasType(casType) {
+		return fmt.Errorf("cas-type %s is not supported", casType)
+	}
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	less, ok := usageSortMap()[sortBy]
+	if !ok {
+		return fmt.Errorf("invalid sort-by %s, use one of %s, %s, %s, %s", sortBy, SortByUsedPercent, SortByUsed, SortByAvailable, SortByName)
+	}
+	k := client.NewK8sClient()
+	_, volumes, err := listVolumes(k, nil, "", casType, "", "", "", filter)
+	if err != nil {
+		return err
+	}
+	if len(volumes) == 0 {
+		if util.IsStructuredOutput(output) {
+			return util.PrintList(output, volumeUsageListKind, nil)
+		}
+		return util.HandleEmptyTableError("Volume", "", casType)
+	}
+	usages := Usage(volumes, VolumeStats(k))
+	sort.SliceStable(usages, func(i, j int) bool {
+		// the volumes of unknown usage are listed last
+		if usages[i].Mounted != usages[j].Mounted {
+			return usages[i].Mounted
+		}
+		return less(usages[i], usages[j])
+	})
+	if util.IsStructuredOutput(output) {
+		items := make([]interface{}, len(usages))
+		for i := range usages {
+			items[i] = usages[i]
+		}
+		return util.PrintList(output, volumeUsageListKind, items)
+	}
+	util.TablePrinter(util.VolumeUsageColumnDefinitions, usageRows(usages), printers.PrintOptions{})
+	return nil
+}
+
+// usageSortMap returns the less functions ordering the volume usages by
+// each of the sort-by values
+func usageSortMap() map[string]func(a, b util.VolumeUsage) bool {
+	return map[string]func(a, b util.VolumeUsage) bool{
+		SortByUsedPercent: func(a, b util.VolumeUsage) bool { return a.UsedPercent > b.UsedPercent },
+		SortByUsed:        func(a, b util.VolumeUsage) bool { return a.UsedBytes > b.UsedBytes },
+		SortByAvailable:   func(a, b util.VolumeUsage) bool { return a.AvailableBytes < b.AvailableBytes },
+		SortByName:        func(a, b util.VolumeUsage) bool { return a.Name < b.Name },
+	}
+}
+
+// VolumeStats returns the stats of the PVC backed volumes reported by the
+// kubelets of all the nodes keyed by their PVC, the kubelets which can't be
+// reached are reported on stderr & skipped
+func VolumeStats(k *client.K8sClient) map[client.PVCRef]util.VolumeUsage {
+	stats := make(map[client.PVCRef]util.VolumeUsage)
+	nodes, err := k.GetNodes(nil, "", "")
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return stats
+	}
+	for _, node := range nodes.Items {
+		vols, err := k.GetVolumeStats(node.Name)
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		for _, vol := range vols {
+			stats[*vol.PVCRef] = newVolumeUsage(node.Name, vol)
+		}
+	}
+	return stats
+}
+
+// newVolumeUsage returns the usage of the volume stats reported by the
+// kubelet of the node
+func newVolumeUsage(node string, vol client.VolumeStats) util.VolumeUsage {
+	u := util.VolumeUsage{Node: node, Mounted: true}
+	if vol.UsedBytes != nil {
+		u.UsedBytes = *vol.UsedBytes
+	}
+	if vol.AvailableBytes != nil {
+		u.AvailableBytes = *vol.AvailableBytes
+	}
+	if vol.CapacityBytes != nil {
+		u.CapacityBytes = *vol.CapacityBytes
+	} else {
+		u.CapacityBytes = u.UsedBytes + u.AvailableBytes
+	}
+	if u.CapacityBytes > 0 {
+		u.UsedPercent = float64(u.UsedBytes) / float64(u.CapacityBytes) * 100
+	}
+	return u
+}
+
+// Usage returns the usage of the volumes joined to the stats by the PVCs of
+// the volumes, in the order of the volumes
+func Usage(volumes []util.Volume, stats map[client.PVCRef]util.VolumeUsage) []util.VolumeUsage {
+	usages := make([]util.VolumeUsage, 0, len(volumes))
+	for _, vol := range volumes {
+		u := stats[client.PVCRef{Name: vol.PVC, Namespace: vol.PVCNamespace}]
+		u.Name = vol.Name
+		u.PVC = vol.PVC
+		u.PVCNamespace = vol.PVCNamespace
+		u.CasType = vol.CasType
+		u.StorageClass = vol.StorageClass
+		usages = append(usages, u)
+	}
+	return usages
+}
+
+// usageRows returns the rows of the volume usages
+func usageRows(usages []util.VolumeUsage) []metav1.TableRow {
+	rows := make([]metav1.TableRow, 0, len(usages))
+	for _, u := range usages {
+		namespace, pvc := u.PVCNamespace, u.PVC
+		if pvc == "" {
+			namespace, pvc = util.NotAvailable, util.NotAvailable
+		}
+		if !u.Mounted {
+			rows = append(rows, metav1.TableRow{Cells: []interface{}{namespace, pvc, u.Name, u.CasType,
+				util.NotAvailable, util.NotAvailable, util.NotAvailable, util.NotAvailable, util.NotAvailable}})
+			continue
+		}
+		rows = append(rows, metav1.TableRow{Cells: []interface{}{namespace, pvc, u.Name, u.CasType, u.Node,
+			iBytes(u.CapacityBytes), iBytes(u.UsedBytes), iBytes(u.AvailableBytes), usedPercent(u)}})
+	}
+	return rows
+}
+
+// usedPercent returns the used percentage cell of the volume usage
+func usedPercent(u util.VolumeUsage) string {
+	if !u.Mounted {
+		return util.NotAvailable
+	}
+	return fmt.Sprintf("%0.1f%%", u.UsedPercent)
+}
+
+// iBytes humanizes the bytes to IBytes format
+func iBytes(bytes int64) string {
+	return util.ConvertToIBytes(strconv.FormatInt(bytes, 10))
+}
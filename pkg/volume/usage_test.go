@@ -0,0 +1,106 @@
+/*
+Copyright 2020-2022 The OpenEBS Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package volume
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestVolumeStats(t *testing.T) {
+	server := newKubeletServer(map[string][]client.PodStats{
+		"node1": {
+			{Volumes: []client.VolumeStats{newVolumeStats("pvc-namespace", "zfs-pvc-1", 3<<30, 1<<30), {Name: "kube-api-access"}}},
+			// the PVC is mounted by a second pod of the node
+			{Volumes: []client.VolumeStats{newVolumeStats("pvc-namespace", "zfs-pvc-1", 3<<30, 1<<30)}},
+		},
+		"node2": {
+			{Volumes: []client.VolumeStats{{Name: "data", PVCRef: &client.PVCRef{Name: "lvm-pvc-1", Namespace: "pvc-namespace"}}}},
+		},
+	})
+	defer server.Close()
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "unreachable"}}),
+		Kubelet: &client.KubeletClient{Endpoint: server.URL},
+	}
+	want := map[client.PVCRef]util.VolumeUsage{
+		{Name: "zfs-pvc-1", Namespace: "pvc-namespace"}: {Node: "node1", Mounted: true, CapacityBytes: 4 << 30, UsedBytes: 3 << 30, AvailableBytes: 1 << 30, UsedPercent: 75},
+		// the kubelet didn't report the bytes of the volume yet
+		{Name: "lvm-pvc-1", Namespace: "pvc-namespace"}: {Node: "node2", Mounted: true},
+	}
+	if got := VolumeStats(k); !reflect.DeepEqual(got, want) {
+		t.Errorf("VolumeStats() = %v, want %v", got, want)
+	}
+	if got := VolumeStats(&client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})}); len(got) != 0 {
+		t.Errorf("VolumeStats() without the kubelet client = %v, want none", got)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	stats := map[client.PVCRef]util.VolumeUsage{
+		{Name: "zfs-pvc-1", Namespace: "pvc-namespace"}: {Node: "node1", Mounted: true, CapacityBytes: 4 << 30, UsedBytes: 3 << 30, AvailableBytes: 1 << 30, UsedPercent: 75},
+		{Name: "lvm-pvc-1", Namespace: "pvc-namespace"}: {Node: "node2", Mounted: true, CapacityBytes: 8 << 30, UsedBytes: 4 << 30, AvailableBytes: 4 << 30, UsedPercent: 50},
+	}
+	volumes := []util.Volume{
+		{Name: "pvc-2", PVC: "lvm-pvc-1", PVCNamespace: "pvc-namespace", CasType: util.LVMCasType, StorageClass: "lvm-sc-1"},
+		{Name: "pvc-3", PVC: "unmounted", PVCNamespace: "pvc-namespace", CasType: util.ZFSCasType},
+		{Name: "pvc-1", PVC: "zfs-pvc-1", PVCNamespace: "pvc-namespace", CasType: util.ZFSCasType, StorageClass: "zfs-sc-1"},
+		{Name: "pvc-4", CasType: util.LocalPvHostpathCasType},
+	}
+	usages := Usage(volumes, stats)
+	want := []util.VolumeUsage{
+		{Name: "pvc-2", PVC: "lvm-pvc-1", PVCNamespace: "pvc-namespace", CasType: util.LVMCasType, StorageClass: "lvm-sc-1", Node: "node2", Mounted: true, CapacityBytes: 8 << 30, UsedBytes: 4 << 30, AvailableBytes: 4 << 30, UsedPercent: 50},
+		{Name: "pvc-3", PVC: "unmounted", PVCNamespace: "pvc-namespace", CasType: util.ZFSCasType},
+		{Name: "pvc-1", PVC: "zfs-pvc-1", PVCNamespace: "pvc-namespace", CasType: util.ZFSCasType, StorageClass: "zfs-sc-1", Node: "node1", Mounted: true, CapacityBytes: 4 << 30, UsedBytes: 3 << 30, AvailableBytes: 1 << 30, UsedPercent: 75},
+		{Name: "pvc-4", CasType: util.LocalPvHostpathCasType},
+	}
+	if !reflect.DeepEqual(usages, want) {
+		t.Fatalf("Usage() = %v, want %v", usages, want)
+	}
+	tests := map[string][]string{
+		SortByUsedPercent: {"pvc-1", "pvc-2"},
+		SortByUsed:        {"pvc-2", "pvc-1"},
+		SortByAvailable:   {"pvc-1", "pvc-2"},
+		SortByName:        {"pvc-1", "pvc-2"},
+	}
+	for sortBy, wantNames := range tests {
+		mounted := []util.VolumeUsage{usages[0], usages[2]}
+		less := usageSortMap()[sortBy]
+		sort.SliceStable(mounted, func(i, j int) bool { return less(mounted[i], mounted[j]) })
+		if got := []string{mounted[0].Name, mounted[1].Name}; !reflect.DeepEqual(got, wantNames) {
+			t.Errorf("sort by %s = %v, want %v", sortBy, got, wantNames)
+		}
+	}
+	wantRows := []metav1.TableRow{
+		{Cells: []interface{}{"pvc-namespace", "lvm-pvc-1", "pvc-2", util.LVMCasType, "node2", "8.0GiB", "4.0GiB", "4.0GiB", "50.0%"}},
+		{Cells: []interface{}{"pvc-namespace", "unmounted", "pvc-3", util.ZFSCasType, "N/A", "N/A", "N/A", "N/A", "N/A"}},
+		{Cells: []interface{}{"pvc-namespace", "zfs-pvc-1", "pvc-1", util.ZFSCasType, "node1", "4.0GiB", "3.0GiB", "1.0GiB", "75.0%"}},
+		{Cells: []interface{}{"N/A", "N/A", "pvc-4", util.LocalPvHostpathCasType, "N/A", "N/A", "N/A", "N/A", "N/A"}},
+	}
+	if got := usageRows(usages); !reflect.DeepEqual(got, wantRows) {
+		t.Errorf("usageRows() = %v, want %v", got, wantRows)
+	}
+}
@@ -18,6 +18,8 @@ package volume
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/openebs/openebsctl/pkg/client"
 	"github.com/openebs/openebsctl/pkg/util"
@@ -27,25 +29,75 @@ import (
 	"k8s.io/cli-runtime/pkg/printers"
 )
 
-// Get manages various implementations of Volume listing
-func Get(vols []string, openebsNS, casType string) error {
+const (
+	// volumeListKind is the kind of the machine-readable volume listing
+	volumeListKind = "VolumeList"
+	// volumeDescribeKind is the kind of the machine-readable volume describe
+	volumeDescribeKind = "VolumeDescriptionList"
+)
+
+// Get manages various implementations of Volume listing, the volumes are
+// filtered by the label & field selectors and the filter if they are not empty.
+// With watch the volumes are read from informer caches & the rows of the
+// volumes are reprinted as they change.
+func Get(vols []string, openebsNS, casType, output, labelSelector, fieldSelector string, filter util.VolumeFilter, watch bool) error {
 	if casType != "" && !util.IsValidCasType(casType) {
 		return fmt.Errorf("cas-type %s is not supported", casType)
 	}
+	if err := util.CheckListOutputFormat(output); err != nil {
+		return err
+	}
+	if watch {
+		if err := util.CheckWatchOutputFormat(output); err != nil {
+			return err
+		}
+	}
+	if err := util.CheckSelectors(vols, labelSelector, fieldSelector); err != nil {
+		return err
+	}
 	// TODO: Prefer passing the client from outside
 	k := client.NewK8sClient()
-	// 1. Get a list of required PersistentVolumes
-	var pvList *corev1.PersistentVolumeList
-	var err error
-	if vols == nil {
-		pvList, err = k.GetPVs(nil, "")
-	} else {
-		pvList, err = k.GetPVs(vols, "")
+	if watch {
+		return watchVolumes(k, vols, openebsNS, casType, output, labelSelector, fieldSelector, filter)
 	}
+	rows, volumes, err := listVolumes(k, vols, openebsNS, casType, output, labelSelector, fieldSelector, filter)
 	if err != nil {
-		// stop if no PVs found
 		return err
 	}
+
+	// 4. Return Error or Print volumes from rows
+	if len(rows) == 0 {
+		if util.IsStructuredOutput(output) {
+			return util.PrintList(output, volumeListKind, nil)
+		}
+		return util.HandleEmptyTableError("Volume", openebsNS, casType)
+	}
+	switch output {
+	case "":
+		util.TablePrinter(util.VolumeListColumnDefinations, rows, printers.PrintOptions{Wide: true})
+	case util.WideOutput:
+		util.TablePrinter(util.VolumeListWideColumnDefinitions, wideRows(rows, volumes, VolumeStats(k)), printers.PrintOptions{Wide: true})
+	default:
+		items := make([]interface{}, len(volumes))
+		for i := range volumes {
+			items[i] = volumes[i]
+		}
+		return util.PrintList(output, volumeListKind, items)
+	}
+	return nil
+}
+
+// listVolumes returns the rows & the volumes matching the selectors & the
+// filter, the engine details are only added to the volumes if the output or
+// the filter needs them
+func listVolumes(k *client.K8sClient, vols []string, openebsNS, casType, output, labelSelector, fieldSelector string, filter util.VolumeFilter) ([]metav1.TableRow, []util.Volume, error) {
+	// 1. Get a list of required PersistentVolumes
+	pvList, err := getPVs(k, vols, labelSelector, fieldSelector)
+	if err != nil {
+		// stop if no PVs found
+		return nil, nil, err
+	}
+	pvList = filterPVs(pvList, filter)
 	// TODO: (improvisation) Only call specific cas-functions for a
 	// list-obj-by-name & if only 2-3 cas-exist
 	var rows []metav1.TableRow
@@ -53,7 +105,7 @@ func Get(vols []string, openebsNS, casType string) error {
 	if work, ok := CasListMap()[casType]; ok {
 		var err error
 		if rows, err = work(k, pvList, openebsNS); err != nil {
-			return err
+			return nil, nil, err
 		}
 	} else {
 		for _, t := range CasList() {
@@ -62,31 +114,276 @@ func Get(vols []string, openebsNS, casType string) error {
 			}
 		}
 	}
+	volumes := VolumesFromRows(rows, pvList)
+	// 3. Add the engine details, needed by the node & pool filters too
+	if filter.Node != "" || filter.Pool != "" || (util.IsStructuredOutput(output) && output != util.NameOutput) {
+		addEngineSpecs(k, volumes)
+	}
+	rows, volumes = filterVolumes(rows, volumes, filter)
+	return rows, volumes, nil
+}
 
-	// 3. Return Error or Print volumes from rows
-	if len(rows) == 0 {
-		return util.HandleEmptyTableError("Volume", openebsNS, casType)
+// watchVolumes prints the volumes & then reprints the rows of the volumes
+// which change, the PVs, the engine volume CRs & the engine controllers are
+// read from informer caches so that no api calls are made on a change. The
+// Mayastor volumes are read from its REST API, these are relisted when the
+// Mayastor pods or DiskPools change. The kubelet stats of the wide output are
+// cached & refreshed every statsPeriod instead of on every change
+func watchVolumes(k *client.K8sClient, vols []string, openebsNS, casType, output, labelSelector, fieldSelector string, filter util.VolumeFilter) error {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	changed, err := k.Watch(stopCh, client.PVResource, client.ZFSVolumeResource, client.LVMVolumeResource, client.ControllerResource,
+		client.PodResource, client.DiskPoolResource)
+	if err != nil {
+		return err
+	}
+	columns := util.VolumeListColumnDefinations
+	if output == util.WideOutput {
+		columns = util.VolumeListWideColumnDefinitions
+	}
+	// the rows are identified by the namespace & name cells
+	printer := util.NewWatchPrinter(columns, printers.PrintOptions{Wide: true}, 2)
+	var stats map[client.PVCRef]util.VolumeUsage
+	// refresh stays nil, i.e. never ready, unless the stats are printed
+	var refresh <-chan time.Time
+	if output == util.WideOutput {
+		stats = VolumeStats(k)
+		ticker := time.NewTicker(statsPeriod)
+		defer ticker.Stop()
+		refresh = ticker.C
+	}
+	for first := true; ; first = false {
+		rows, volumes, err := listVolumes(k, vols, openebsNS, casType, output, labelSelector, fieldSelector, filter)
+		if err != nil {
+			return err
+		}
+		if first && len(rows) == 0 {
+			_, _ = fmt.Fprintln(os.Stderr, util.HandleEmptyTableError("Volume", openebsNS, casType))
+		}
+		if output == util.WideOutput {
+			rows = wideRows(rows, volumes, stats)
+		}
+		printer.Print(rows)
+		select {
+		case <-changed:
+		case <-refresh:
+			stats = VolumeStats(k)
+		}
+	}
+}
+
+// wideRows adds the cells of the wide output columns to the rows, the usage
+// of the volumes is joined to the kubelet stats by their PVCs
+func wideRows(rows []metav1.TableRow, volumes []util.Volume, stats map[client.PVCRef]util.VolumeUsage) []metav1.TableRow {
+	usages := Usage(volumes, stats)
+	for i := range rows {
+		rows[i].Cells = append(rows[i].Cells, volumes[i].CasType, pvcRef(volumes[i]), usedPercent(usages[i]))
+	}
+	return rows
+}
+
+// List returns the volumes of all the engines matching the filter, along
+// with the details of their engine CRs
+func List(k *client.K8sClient, filter util.VolumeFilter) ([]util.Volume, error) {
+	_, volumes, err := listVolumes(k, nil, "", "", "", "", "", filter)
+	if err != nil {
+		return nil, err
+	}
+	// the engine details are only added by listVolumes for the node & pool filters
+	if filter.Node == "" && filter.Pool == "" {
+		addEngineSpecs(k, volumes)
+	}
+	return volumes, nil
+}
+
+// filterPVs returns the PVs matching the storage class & PVC namespace of
+// the filter, these don't need the engine details
+func filterPVs(pvList *corev1.PersistentVolumeList, filter util.VolumeFilter) *corev1.PersistentVolumeList {
+	if filter.StorageClass == "" && filter.PVCNamespace == "" {
+		return pvList
+	}
+	filtered := &corev1.PersistentVolumeList{}
+	for _, pv := range pvList.Items {
+		if filter.StorageClass != "" && pv.Spec.StorageClassName != filter.StorageClass {
+			continue
+		}
+		if filter.PVCNamespace != "" && (pv.Spec.ClaimRef == nil || pv.Spec.ClaimRef.Namespace != filter.PVCNamespace) {
+			continue
+		}
+		filtered.Items = append(filtered.Items, pv)
+	}
+	return filtered
+}
+
+// filterVolumes returns the rows & the volumes matching the node & pool of
+// the filter, the engine details must be added to the volumes beforehand
+func filterVolumes(rows []metav1.TableRow, volumes []util.Volume, filter util.VolumeFilter) ([]metav1.TableRow, []util.Volume) {
+	if filter.Node == "" && filter.Pool == "" {
+		return rows, volumes
+	}
+	var filteredRows []metav1.TableRow
+	var filteredVolumes []util.Volume
+	for i, vol := range volumes {
+		if filter.Node != "" && NodeOf(vol) != filter.Node {
+			continue
+		}
+		if filter.Pool != "" && PoolOf(vol) != filter.Pool {
+			continue
+		}
+		filteredRows = append(filteredRows, rows[i])
+		filteredVolumes = append(filteredVolumes, vol)
+	}
+	return filteredRows, filteredVolumes
+}
+
+// NodeOf returns the node of the volume, the node of the localpv-hostpath
+// volumes is the one of the PV node affinity
+func NodeOf(vol util.Volume) string {
+	switch {
+	case vol.ZFS != nil:
+		return vol.ZFS.OwnerNodeID
+	case vol.LVM != nil:
+		return vol.LVM.OwnerNodeID
+	default:
+		return vol.Node
+	}
+}
+
+// PoolOf returns the ZFS pool or the LVM volume group of the volume
+func PoolOf(vol util.Volume) string {
+	switch {
+	case vol.ZFS != nil:
+		return vol.ZFS.PoolName
+	case vol.LVM != nil:
+		return vol.LVM.VolGroup
+	default:
+		return ""
 	}
-	util.TablePrinter(util.VolumeListColumnDefinations, rows, printers.PrintOptions{Wide: true})
-	return nil
+}
+
+// getPVs returns the PersistentVolumes matching the selectors or having an
+// engine volume CR matching the selectors, i.e. a selector on the
+// kubernetes.io/nodename label lists the ZFSVolumes of that node
+func getPVs(k *client.K8sClient, vols []string, labelSelector, fieldSelector string) (*corev1.PersistentVolumeList, error) {
+	// 1. Get the PVs matching the selectors
+	pvList, err := k.GetPVs(vols, labelSelector, fieldSelector)
+	if err != nil || (labelSelector == "" && fieldSelector == "") {
+		return pvList, err
+	}
+	// 2. Get the names of the engine volume CRs matching the selectors, the
+	// errors are ignored as all the engines may not be installed
+	found := make(map[string]bool)
+	for _, pv := range pvList.Items {
+		found[pv.Name] = true
+	}
+	selected := make(map[string]bool)
+	for _, sel := range CasSelectList() {
+		if names, err := sel(k, labelSelector, fieldSelector); err == nil {
+			for _, name := range names {
+				if !found[name] {
+					selected[name] = true
+				}
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return pvList, nil
+	}
+	// 3. Add the PVs of the selected engine volume CRs
+	allPVs, err := k.GetPVs(vols, "", "")
+	if err != nil {
+		return nil, err
+	}
+	for _, pv := range allPVs.Items {
+		if selected[pv.Name] {
+			pvList.Items = append(pvList.Items, pv)
+		}
+	}
+	return pvList, nil
+}
+
+// VolumesFromRows maps the rows built by the CasList functions to Volumes, the
+// cells of every row are in the order of util.VolumeListColumnDefinations
+func VolumesFromRows(rows []metav1.TableRow, pvList *corev1.PersistentVolumeList) []util.Volume {
+	pvMap := make(map[string]corev1.PersistentVolume)
+	for _, pv := range pvList.Items {
+		pvMap[pv.Name] = pv
+	}
+	volumes := make([]util.Volume, 0, len(rows))
+	for _, row := range rows {
+		cell := func(i int) string {
+			if i >= len(row.Cells) || row.Cells[i] == nil {
+				return ""
+			}
+			return fmt.Sprint(row.Cells[i])
+		}
+		vol := util.Volume{
+			Namespace:         cell(0),
+			Name:              cell(1),
+			Status:            cell(2),
+			Version:           cell(3),
+			Capacity:          util.ConvertToIBytes(cell(4)),
+			StorageClass:      cell(5),
+			AttachementStatus: cell(6),
+			AccessMode:        cell(7),
+			Node:              cell(8),
+		}
+		if pv, ok := pvMap[vol.Name]; ok {
+			vol.CasType = util.NormalizeCasType(util.GetCasTypeFromPV(&pv))
+			if pv.Spec.ClaimRef != nil {
+				vol.PVC = pv.Spec.ClaimRef.Name
+				vol.PVCNamespace = pv.Spec.ClaimRef.Namespace
+			}
+		}
+		volumes = append(volumes, vol)
+	}
+	return volumes
+}
+
+// addEngineSpecs adds the details of the engine CRs to the volumes of the
+// cas-types which have them
+func addEngineSpecs(k *client.K8sClient, volumes []util.Volume) {
+	casTypes := make(map[string]bool)
+	for _, vol := range volumes {
+		casTypes[vol.CasType] = true
+	}
+	for casType, add := range CasSpecMap() {
+		if casTypes[casType] {
+			if err := add(k, volumes); err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+}
+
+// pvcRef returns the namespace/name of the PVC of the volume
+func pvcRef(vol util.Volume) string {
+	if vol.PVC == "" {
+		return util.NotAvailable
+	}
+	return vol.PVCNamespace + "/" + vol.PVC
 }
 
 // Describe manages various implementations of Volume Describing
-func Describe(vols []string, openebsNs string) error {
+func Describe(vols []string, openebsNs, output string) error {
 	if len(vols) == 0 || vols == nil {
 		return errors.New("please provide atleast one pv name to describe")
 	}
+	if err := util.CheckOutputFormat(output); err != nil {
+		return err
+	}
 	// Clienset creation
 	k := client.NewK8sClient(openebsNs)
 
 	// 1. Get a list of required PersistentVolumes
 	var pvList *corev1.PersistentVolumeList
-	pvList, err := k.GetPVs(vols, "")
+	pvList, err := k.GetPVs(vols, "", "")
 	if err != nil {
 		return errors.New("no volumes found corresponding to the names")
 	}
 	// 2. Get the namespaces
 	nsMap, _ := k.GetOpenEBSNamespaceMap()
+	var items []interface{}
 	// 3. Range over the list of PVs
 	for _, pv := range pvList.Items {
 		// 4. Fetch the storage class, used to get the cas-type
@@ -99,30 +396,82 @@ func Describe(vols []string, openebsNs string) error {
 		} else {
 			casType = util.GetCasType(&pv, sc)
 		}
+		casType = util.NormalizeCasType(casType)
 		// 6. Assign a namespace corresponding to the engine
 		if openebsNs == "" {
 			if val, ok := nsMap[casType]; ok {
 				k.Ns = val
-			} else if casType != util.ZFSCasType && casType != util.LVMCasType {
+			} else if casType != util.ZFSCasType && casType != util.LVMCasType && casType != util.MayastorCasType && casType != util.LocalPvDeviceCasType {
 				// The reason for above condition is that, newer lvm has cas type
 				return errors.New("could not determine the underlying storage engine ns, please provide using '--openebs-namespace' flag")
 			}
 		}
 		// 7. Describe the volume based on its casType
-		if desc, ok := CasDescribeMap()[casType]; ok {
+		if util.IsStructuredOutput(output) {
+			if desc, ok := CasDescribeOutputMap()[casType]; ok {
+				item, err := desc(k, &pv)
+				if err != nil {
+					_, _ = fmt.Fprintln(os.Stderr, err)
+					continue
+				}
+				items = append(items, item)
+			}
+		} else if desc, ok := CasDescribeMap()[casType]; ok {
 			err = desc(k, &pv)
 			if err != nil {
 				continue
 			}
 		}
 	}
+	if util.IsStructuredOutput(output) {
+		return util.PrintList(output, volumeDescribeKind, items)
+	}
 	return nil
 }
 
+// printVolumeEvents prints the events of the PV, of its PVC & of the volume
+// CR of its engine, kind is the kind of the CR & is empty if it has none
+func printVolumeEvents(c *client.K8sClient, vol *corev1.PersistentVolume, kind string) {
+	objects := []corev1.ObjectReference{{Kind: "PersistentVolume", Name: vol.Name}}
+	if vol.Spec.ClaimRef != nil {
+		objects = append(objects, corev1.ObjectReference{Kind: "PersistentVolumeClaim", Name: vol.Spec.ClaimRef.Name, Namespace: vol.Spec.ClaimRef.Namespace})
+	}
+	if kind != "" {
+		objects = append(objects, corev1.ObjectReference{Kind: kind, Name: vol.Name})
+	}
+	// the events are only a part of the description, they are skipped on errors
+	if events, err := c.GetObjectEvents(objects...); err == nil {
+		util.PrintEvents(events)
+	}
+}
+
+// CasDescribeOutputMap returns a map cas-types to functions which return the
+// details of a volume for the machine-readable describe output
+func CasDescribeOutputMap() map[string]func(*client.K8sClient, *corev1.PersistentVolume) (interface{}, error) {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient, *corev1.PersistentVolume) (interface{}, error){
+		util.ZFSCasType: func(c *client.K8sClient, pv *corev1.PersistentVolume) (interface{}, error) {
+			return GetZFSVolDesc(c, pv)
+		},
+		util.LVMCasType: func(c *client.K8sClient, pv *corev1.PersistentVolume) (interface{}, error) {
+			return GetLVMVolDesc(c, pv)
+		},
+		util.LocalPvHostpathCasType: func(c *client.K8sClient, pv *corev1.PersistentVolume) (interface{}, error) {
+			return GetLocalHostpathVolDesc(c, pv)
+		},
+		util.LocalPvDeviceCasType: func(c *client.K8sClient, pv *corev1.PersistentVolume) (interface{}, error) {
+			return GetLocalDeviceVolDesc(c, pv)
+		},
+		util.MayastorCasType: func(c *client.K8sClient, pv *corev1.PersistentVolume) (interface{}, error) {
+			return GetMayastorVolDesc(c, pv)
+		},
+	}
+}
+
 // CasList returns a list of functions by cas-types for volume listing
 func CasList() []func(*client.K8sClient, *corev1.PersistentVolumeList, string) ([]metav1.TableRow, error) {
 	// a good hack to implement immutable lists in Golang & also write tests for it
-	return []func(*client.K8sClient, *corev1.PersistentVolumeList, string) ([]metav1.TableRow, error){GetZFSLocalPVs, GetLVMLocalPV, GetLocalHostpath}
+	return []func(*client.K8sClient, *corev1.PersistentVolumeList, string) ([]metav1.TableRow, error){GetZFSLocalPVs, GetLVMLocalPV, GetLocalHostpath, GetLocalDevice, GetMayastorVolumes}
 }
 
 // CasListMap returns a map cas-types to functions for volume listing
@@ -132,6 +481,25 @@ func CasListMap() map[string]func(*client.K8sClient, *corev1.PersistentVolumeLis
 		util.ZFSCasType:             GetZFSLocalPVs,
 		util.LVMCasType:             GetLVMLocalPV,
 		util.LocalPvHostpathCasType: GetLocalHostpath,
+		util.LocalPvDeviceCasType:   GetLocalDevice,
+		util.MayastorCasType:        GetMayastorVolumes,
+	}
+}
+
+// CasSelectList returns a list of functions which return the names of the
+// engine volume CRs matching the label & field selectors
+func CasSelectList() []func(*client.K8sClient, string, string) ([]string, error) {
+	return []func(*client.K8sClient, string, string) ([]string, error){SelectZFSVolumes, SelectLVMVolumes}
+}
+
+// CasSpecMap returns a map of cas-types to functions adding the engine CR
+// details to the volumes
+func CasSpecMap() map[string]func(*client.K8sClient, []util.Volume) error {
+	// a good hack to implement immutable maps in Golang & also write tests for it
+	return map[string]func(*client.K8sClient, []util.Volume) error{
+		util.ZFSCasType:      AddZFSVolumeSpecs,
+		util.LVMCasType:      AddLVMVolumeSpecs,
+		util.MayastorCasType: AddMayastorVolumeSpecs,
 	}
 }
 
@@ -142,5 +510,7 @@ func CasDescribeMap() map[string]func(*client.K8sClient, *corev1.PersistentVolum
 		util.ZFSCasType:             DescribeZFSLocalPVs,
 		util.LVMCasType:             DescribeLVMLocalPVs,
 		util.LocalPvHostpathCasType: DescribeLocalHostpathVolume,
+		util.LocalPvDeviceCasType:   DescribeLocalDeviceVolume,
+		util.MayastorCasType:        DescribeMayastorVolume,
 	}
 }
@@ -22,10 +22,21 @@ more mistakes in build time than in run-time.
 package volume
 
 import (
+	"context"
+	"reflect"
 	"testing"
+	"time"
+
+	lvmfake "github.com/openebs/lvm-localpv/pkg/generated/clientset/internalclientset/fake"
+	"github.com/openebs/openebsctl/pkg/client"
+	"github.com/openebs/openebsctl/pkg/util"
+	"github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
 
-const supportedCasTypeCount = 3
+const supportedCasTypeCount = 5
 
 // TestCasList is a dummy test which ensures that each cas-type volumes can be
 // listed individually as well as collectively
@@ -52,3 +63,205 @@ func TestCasMap_EmptyAbsent(t *testing.T) {
 		t.Fatalf("\"\" is not a valid cas-type, please remove it, it'll break some logic")
 	}
 }
+
+func TestVolumesFromRows(t *testing.T) {
+	rows := []metav1.TableRow{
+		{Cells: []interface{}{"zfslocalpv", "pvc-1", "Ready", "1.9.0", "4.0GiB", "zfs-sc-1", corev1.VolumeBound, corev1.ReadWriteOnce, "node1"}},
+		{Cells: []interface{}{"", "pvc-1", "", "N/A", &fourGigiByte, "pvc-1-local", corev1.VolumeBound, corev1.ReadWriteOnce, "node1"}},
+	}
+	tests := []struct {
+		name   string
+		rows   []metav1.TableRow
+		pvList *corev1.PersistentVolumeList
+		want   []util.Volume
+	}{
+		{
+			"zfs volume with its PV",
+			rows[:1],
+			&corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{zfsPV1}},
+			[]util.Volume{{Namespace: "zfslocalpv", Name: "pvc-1", Status: "Ready", Version: "1.9.0",
+				Capacity: "4.0GiB", StorageClass: "zfs-sc-1", AttachementStatus: "Bound", AccessMode: "ReadWriteOnce",
+				Node: "node1", CasType: util.ZFSCasType, PVC: "zfs-pvc-1", PVCNamespace: "pvc-namespace"}},
+		},
+		{
+			"hostpath volume with its PV",
+			rows[1:],
+			&corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{localHostpathPv1}},
+			[]util.Volume{{Namespace: "", Name: "pvc-1", Status: "", Version: "N/A",
+				Capacity: "4.0GiB", StorageClass: "pvc-1-local", AttachementStatus: "Bound", AccessMode: "ReadWriteOnce",
+				Node: "node1", CasType: util.LocalPvHostpathCasType, PVC: "mongo-local", PVCNamespace: "local-app"}},
+		},
+		{
+			"volume without its PV",
+			rows[:1],
+			&corev1.PersistentVolumeList{},
+			[]util.Volume{{Namespace: "zfslocalpv", Name: "pvc-1", Status: "Ready", Version: "1.9.0",
+				Capacity: "4.0GiB", StorageClass: "zfs-sc-1", AttachementStatus: "Bound", AccessMode: "ReadWriteOnce",
+				Node: "node1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VolumesFromRows(tt.rows, tt.pvList); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VolumesFromRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCasSpecMap ensures the engine details are added for the cas-types
+// having an engine volume CR
+func TestCasSpecMap(t *testing.T) {
+	for _, casType := range []string{util.ZFSCasType, util.LVMCasType} {
+		if _, ok := CasSpecMap()[casType]; !ok {
+			t.Errorf("missing the engine details of the cas-type %s", casType)
+		}
+	}
+}
+
+func TestGetPVs(t *testing.T) {
+	appPV := lvmPV1.DeepCopy()
+	appPV.Name = "pvc-2"
+	appPV.Labels = map[string]string{"app": "db"}
+	tests := []struct {
+		name          string
+		vols          []string
+		labelSelector string
+		want          []string
+	}{
+		{"no selector", nil, "", []string{"pvc-1", "pvc-2"}},
+		{"selector matching the PV labels", nil, "app=db", []string{"pvc-2"}},
+		{"selector matching the ZFSVolume labels", nil, "kubernetes.io/nodename=node1", []string{"pvc-1"}},
+		{"selector matching nothing", nil, "app=web", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := &client.K8sClient{
+				K8sCS: k8sfake.NewSimpleClientset(&zfsPV1, appPV),
+				ZFCS:  fake.NewSimpleClientset(&zfsVol1),
+				LVMCS: lvmfake.NewSimpleClientset(),
+			}
+			pvList, err := getPVs(k, tt.vols, tt.labelSelector, "")
+			if err != nil {
+				t.Fatalf("getPVs() error = %v", err)
+			}
+			var got []string
+			for _, pv := range pvList.Items {
+				got = append(got, pv.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getPVs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterPVs(t *testing.T) {
+	pvList := &corev1.PersistentVolumeList{Items: []corev1.PersistentVolume{zfsPV1, lvmPV1, localHostpathPv1}}
+	tests := []struct {
+		name   string
+		filter util.VolumeFilter
+		want   []string
+	}{
+		{"no filter", util.VolumeFilter{}, []string{"zfs-sc-1", "lvm-sc-1", "pvc-1-local"}},
+		{"storage class filter", util.VolumeFilter{StorageClass: "lvm-sc-1"}, []string{"lvm-sc-1"}},
+		{"pvc namespace filter", util.VolumeFilter{PVCNamespace: "local-app"}, []string{"pvc-1-local"}},
+		{"both filters", util.VolumeFilter{StorageClass: "zfs-sc-1", PVCNamespace: "pvc-namespace"}, []string{"zfs-sc-1"}},
+		{"node & pool filters are ignored", util.VolumeFilter{Node: "node2", Pool: "zfspv"}, []string{"zfs-sc-1", "lvm-sc-1", "pvc-1-local"}},
+		{"filter matching nothing", util.VolumeFilter{StorageClass: "zfs-sc-1", PVCNamespace: "local-app"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, pv := range filterPVs(pvList, tt.filter).Items {
+				got = append(got, pv.Spec.StorageClassName)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterPVs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterVolumes(t *testing.T) {
+	volumes := []util.Volume{
+		{Name: "pvc-1", Node: "node1", CasType: util.ZFSCasType, ZFS: &util.ZFSVolumeSpec{PoolName: "zfspv", OwnerNodeID: "node1"}},
+		{Name: "pvc-2", Node: "node2", CasType: util.LVMCasType, LVM: &util.LVMVolumeSpec{VolGroup: "lvmvg", OwnerNodeID: "node2"}},
+		{Name: "pvc-3", Node: "node1", CasType: util.LocalPvHostpathCasType},
+		{Name: "pvc-4", Node: "", CasType: util.ZFSCasType},
+	}
+	rows := make([]metav1.TableRow, len(volumes))
+	for i, vol := range volumes {
+		rows[i] = metav1.TableRow{Cells: []interface{}{vol.Name}}
+	}
+	tests := []struct {
+		name   string
+		filter util.VolumeFilter
+		want   []string
+	}{
+		{"no filter", util.VolumeFilter{}, []string{"pvc-1", "pvc-2", "pvc-3", "pvc-4"}},
+		{"node filter across engines", util.VolumeFilter{Node: "node1"}, []string{"pvc-1", "pvc-3"}},
+		{"zfs pool filter", util.VolumeFilter{Pool: "zfspv"}, []string{"pvc-1"}},
+		{"lvm volume group filter", util.VolumeFilter{Pool: "lvmvg"}, []string{"pvc-2"}},
+		{"node & pool filters", util.VolumeFilter{Node: "node1", Pool: "lvmvg"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRows, gotVolumes := filterVolumes(rows, volumes, tt.filter)
+			if len(gotRows) != len(gotVolumes) {
+				t.Fatalf("filterVolumes() returned %d rows for %d volumes", len(gotRows), len(gotVolumes))
+			}
+			var got []string
+			for i, vol := range gotVolumes {
+				if gotRows[i].Cells[0] != vol.Name {
+					t.Errorf("filterVolumes() row %v doesn't match the volume %s", gotRows[i].Cells, vol.Name)
+				}
+				got = append(got, vol.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterVolumes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListVolumesWatched(t *testing.T) {
+	k := &client.K8sClient{
+		K8sCS: k8sfake.NewSimpleClientset(&zfsPV1, &localpvzfsCSICtrlSTS),
+		ZFCS:  fake.NewSimpleClientset(&zfsVol1),
+		LVMCS: lvmfake.NewSimpleClientset(),
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	changed, err := k.Watch(stopCh, client.PVResource, client.ZFSVolumeResource, client.LVMVolumeResource, client.ControllerResource)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	status := func() string {
+		_, volumes, err := listVolumes(k, nil, "", "", "", "", "", util.VolumeFilter{})
+		if err != nil {
+			t.Fatalf("listVolumes() error = %v", err)
+		}
+		if len(volumes) != 1 {
+			t.Fatalf("listVolumes() = %v, want 1 volume", volumes)
+		}
+		return volumes[0].Status
+	}
+	if got := status(); got != "Ready" {
+		t.Errorf("listVolumes() status = %s, want Ready", got)
+	}
+	// the volume is read from the cache once the informer gets the update
+	zv := zfsVol1.DeepCopy()
+	zv.Status.State = "Pending"
+	if _, err := k.ZFCS.ZfsV1().ZFSVolumes(zv.Namespace).Update(context.TODO(), zv, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	timeout := time.After(5 * time.Second)
+	for got := status(); got != "Pending"; got = status() {
+		select {
+		case <-changed:
+		case <-timeout:
+			t.Fatalf("listVolumes() status = %s, want Pending", got)
+		}
+	}
+}
@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/openebs/openebsctl/pkg/backup"
 	"github.com/openebs/openebsctl/pkg/client"
 	"github.com/openebs/openebsctl/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/printers"
 )
 
 const zfsVolInfo = `
@@ -51,7 +53,7 @@ RECORD SIZE       : {{.Recordsize}}
 // GetZFSLocalPVs returns a list of ZFSVolumes
 func GetZFSLocalPVs(c *client.K8sClient, pvList *corev1.PersistentVolumeList, openebsNS string) ([]metav1.TableRow, error) {
 	// 1. Fetch all relevant volume CRs without worrying about openebsNS
-	_, zvolMap, err := c.GetZFSVols(nil, util.Map, "", util.MapOptions{Key: util.Name})
+	_, zvolMap, err := c.GetZFSVols(nil, util.Map, "", "", util.MapOptions{Key: util.Name})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list ZFSVolumes")
 	}
@@ -103,6 +105,35 @@ func DescribeZFSLocalPVs(c *client.K8sClient, vol *corev1.PersistentVolume) erro
 	if vol == nil {
 		return fmt.Errorf("ZFS volume nil")
 	}
+	v, err := GetZFSVolDesc(c, vol)
+	// 5. Print the data
+	_ = util.PrintByTemplate("volume", zfsVolInfo, v)
+	if err != nil {
+		// 6. Print the error is any
+		fmt.Println()
+		fmt.Fprintf(os.Stderr, "The ZFSVol for %s doesnot exist", vol.Name)
+		fmt.Println()
+	}
+	// 7. Print the backups & restores of the volume
+	if len(v.Backups) > 0 {
+		fmt.Printf("\nBackups :\n---------\n")
+		util.TablePrinter(util.BackupListColumnDefinitions, backup.BackupRows(v.Backups), printers.PrintOptions{Wide: true})
+	}
+	if len(v.Restores) > 0 {
+		fmt.Printf("\nRestores :\n----------\n")
+		util.TablePrinter(util.RestoreListColumnDefinitions, backup.RestoreRows(v.Restores), printers.PrintOptions{Wide: true})
+	}
+	// 8. Print the events of the volume
+	printVolumeEvents(c, vol, "ZFSVolume")
+	return nil
+}
+
+// GetZFSVolDesc returns the details of a single zfs-localpv volume, if the
+// ZFSVolume can't be found the details of the PV are returned with an error
+func GetZFSVolDesc(c *client.K8sClient, vol *corev1.PersistentVolume) (util.ZFSVolDesc, error) {
+	if vol == nil {
+		return util.ZFSVolDesc{}, fmt.Errorf("ZFS volume nil")
+	}
 	// 1. Fetch the version from the CSI Controller STS labels
 	var version string
 	if CSIctrl, err := c.GetCSIControllerSTS(util.ZFSLocalPVcsiControllerLabelValue); err == nil {
@@ -114,41 +145,86 @@ func DescribeZFSLocalPVs(c *client.K8sClient, vol *corev1.PersistentVolume) erro
 	}
 	// 2. Fill the details using the Persistent Volume
 	v := util.ZFSVolDesc{
-		AccessMode: util.AccessModeToString(vol.Spec.AccessModes),
-		Capacity:   vol.Spec.Capacity.Storage().String(),
-		CSIDriver:  vol.Spec.CSI.Driver,
-		Name:       vol.Name,
-		// assuming that zfsPVs aren't static-ally provisioned
-		PVC:          vol.Spec.ClaimRef.Name,
+		AccessMode:   util.AccessModeToString(vol.Spec.AccessModes),
+		Capacity:     vol.Spec.Capacity.Storage().String(),
+		Name:         vol.Name,
 		VolumePhase:  vol.Status.Phase,
 		StorageClass: vol.Spec.StorageClassName,
 		Version:      version,
+		CasType:      util.ZFSCasType,
 	}
-	// 3. Fetch the corresponding ZFS Volume CR and fill in the other details
-	printErr := false
-	zvols, _, err := c.GetZFSVols([]string{vol.Name}, util.List, "", util.MapOptions{})
+	if vol.Spec.CSI != nil {
+		v.CSIDriver = vol.Spec.CSI.Driver
+	}
+	// assuming that zfsPVs aren't static-ally provisioned
+	if vol.Spec.ClaimRef != nil {
+		v.PVC = vol.Spec.ClaimRef.Name
+	}
+	// 3. Fetch the backups & restores of the volume, the errors are ignored
+	// as the velero plugin CRDs may not be installed
+	v.Backups, _ = backup.GetZFSBackups(c, nil, vol.Name)
+	v.Restores, _ = backup.GetZFSRestores(c, nil, vol.Name)
+	// 4. Fetch the corresponding ZFS Volume CR and fill in the other details
+	zvols, _, err := c.GetZFSVols([]string{vol.Name}, util.List, "", "", util.MapOptions{})
 	if err != nil {
-		printErr = true
-	} else {
-		zvol := zvols.Items[0]
-		v.Namespace = zvol.Namespace
-		v.Status = zvol.Status.State
-		v.VolumeType = zvol.Spec.VolumeType // DATASET or ZVOL
-		v.PoolName = zvol.Spec.PoolName
-		v.FileSystem = zvol.Spec.FsType
-		v.Compression = zvol.Spec.Compression
-		v.Dedup = zvol.Spec.Dedup
-		v.NodeID = zvol.Spec.OwnerNodeID
-		v.Recordsize = zvol.Spec.RecordSize
-	}
-	// 4. Print the data
-	_ = util.PrintByTemplate("volume", zfsVolInfo, v)
-	if printErr {
-		// 5. Print the error is any
-		fmt.Println()
-		fmt.Fprintf(os.Stderr, "The LVMVol for %s doesnot exist", vol.Name)
-		fmt.Println()
+		return v, err
+	}
+	if len(zvols.Items) == 0 {
+		return v, fmt.Errorf("zfsvolume %s not found", vol.Name)
+	}
+	zvol := zvols.Items[0]
+	v.Namespace = zvol.Namespace
+	v.Status = zvol.Status.State
+	v.VolumeType = zvol.Spec.VolumeType // DATASET or ZVOL
+	v.PoolName = zvol.Spec.PoolName
+	v.FileSystem = zvol.Spec.FsType
+	v.Compression = zvol.Spec.Compression
+	v.Dedup = zvol.Spec.Dedup
+	v.NodeID = zvol.Spec.OwnerNodeID
+	v.Recordsize = zvol.Spec.RecordSize
+	return v, nil
+}
+
+// AddZFSVolumeSpecs adds the ZFSVolume details to the localpv-zfs volumes
+func AddZFSVolumeSpecs(c *client.K8sClient, volumes []util.Volume) error {
+	_, zvolMap, err := c.GetZFSVols(nil, util.Map, "", "", util.MapOptions{Key: util.Name})
+	if err != nil {
+		return fmt.Errorf("failed to list ZFSVolumes")
+	}
+	for i := range volumes {
+		if volumes[i].CasType != util.ZFSCasType {
+			continue
+		}
+		if zv, ok := zvolMap[volumes[i].Name]; ok {
+			volumes[i].ZFS = &util.ZFSVolumeSpec{
+				PoolName:      zv.Spec.PoolName,
+				OwnerNodeID:   zv.Spec.OwnerNodeID,
+				Capacity:      zv.Spec.Capacity,
+				VolumeType:    zv.Spec.VolumeType,
+				FsType:        zv.Spec.FsType,
+				RecordSize:    zv.Spec.RecordSize,
+				VolBlockSize:  zv.Spec.VolBlockSize,
+				Compression:   zv.Spec.Compression,
+				Dedup:         zv.Spec.Dedup,
+				Encryption:    zv.Spec.Encryption,
+				ThinProvision: zv.Spec.ThinProvision,
+				Shared:        zv.Spec.Shared,
+				SnapName:      zv.Spec.SnapName,
+			}
+		}
 	}
-	// TODO: Add ZFSbackup, ZFSrestores, ZFSsnapshot info if available
 	return nil
 }
+
+// SelectZFSVolumes returns the names of the ZFSVolumes matching the selectors
+func SelectZFSVolumes(c *client.K8sClient, labelSelector, fieldSelector string) ([]string, error) {
+	zvols, _, err := c.GetZFSVols(nil, util.List, labelSelector, fieldSelector, util.MapOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(zvols.Items))
+	for _, zv := range zvols.Items {
+		names = append(names, zv.Name)
+	}
+	return names, nil
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/openebs/openebsctl/pkg/client"
 	"github.com/openebs/openebsctl/pkg/util"
+	zfs "github.com/openebs/zfs-localpv/pkg/apis/openebs.io/zfs/v1"
 	"github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/fake"
 	fakezfs "github.com/openebs/zfs-localpv/pkg/generated/clientset/internalclientset/typed/zfs/v1/fake"
 	corev1 "k8s.io/api/core/v1"
@@ -204,3 +205,48 @@ func zfsVolNotExists(c *client.K8sClient) {
 		return true, nil, fmt.Errorf("failed to list ZFSVolumes")
 	})
 }
+
+func TestAddZFSVolumeSpecs(t *testing.T) {
+	c := &client.K8sClient{Ns: "zfslocalpv", ZFCS: fake.NewSimpleClientset(&zfsVol1)}
+	volumes := []util.Volume{
+		{Name: "pvc-1", CasType: util.ZFSCasType},
+		{Name: "pvc-1", CasType: util.LVMCasType},
+		{Name: "pvc-2", CasType: util.ZFSCasType},
+	}
+	if err := AddZFSVolumeSpecs(c, volumes); err != nil {
+		t.Fatalf("AddZFSVolumeSpecs() error = %v", err)
+	}
+	want := &util.ZFSVolumeSpec{PoolName: "zfspv", OwnerNodeID: "node1", Capacity: "4Gi", VolumeType: "DATASET",
+		FsType: "zfs", RecordSize: "4k", Compression: "off", Dedup: "off", ThinProvision: "No", Shared: "NotShared"}
+	if !reflect.DeepEqual(volumes[0].ZFS, want) {
+		t.Errorf("AddZFSVolumeSpecs() = %v, want %v", volumes[0].ZFS, want)
+	}
+	if volumes[1].ZFS != nil || volumes[2].ZFS != nil {
+		t.Errorf("AddZFSVolumeSpecs() added details to the volumes without ZFSVolumes")
+	}
+	zfsVolNotExists(c)
+	if err := AddZFSVolumeSpecs(c, volumes); err == nil {
+		t.Errorf("AddZFSVolumeSpecs() expected an error when ZFSVolumes can't be listed")
+	}
+}
+
+func TestGetZFSVolDescBackups(t *testing.T) {
+	bkp := zfs.ZFSBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly.pvc-1", Namespace: "zfslocalpv"},
+		Spec:       zfs.ZFSBackupSpec{VolumeName: "pvc-1", SnapName: "nightly"},
+		Status:     zfs.BKPZFSStatusDone,
+	}
+	other := bkp.DeepCopy()
+	other.Name, other.Spec.VolumeName = "nightly.pvc-2", "pvc-2"
+	c := &client.K8sClient{K8sCS: k8sfake.NewSimpleClientset(), ZFCS: fake.NewSimpleClientset(&zfsVol1, &bkp, other)}
+	v, err := GetZFSVolDesc(c, &zfsPV1)
+	if err != nil {
+		t.Fatalf("GetZFSVolDesc() error = %v", err)
+	}
+	if len(v.Backups) != 1 || v.Backups[0].Name != "nightly.pvc-1" {
+		t.Errorf("GetZFSVolDesc() backups = %v, want nightly.pvc-1", v.Backups)
+	}
+	if len(v.Restores) != 0 {
+		t.Errorf("GetZFSVolDesc() restores = %v, want none", v.Restores)
+	}
+}